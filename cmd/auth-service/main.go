@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"chinese-bridge-game/docs"
 	"chinese-bridge-game/internal/auth/handler"
@@ -10,6 +17,9 @@ import (
 	"chinese-bridge-game/internal/auth/service"
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/pkg/audit"
+	"chinese-bridge-game/pkg/buildinfo"
+	"chinese-bridge-game/pkg/logging"
 	"chinese-bridge-game/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -35,31 +45,51 @@ func main() {
 	// Initialize configuration
 	cfg := config.Load()
 
+	// Operational logs, including the standard log package's, go through slog
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Printf("Using info level: %v", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	slog.SetDefault(logger)
+
 	// Initialize database
-	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL, database.PoolOptions(cfg.Database))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	if err := database.RegisterPoolMetrics(db, "chinese_bridge"); err != nil {
+		log.Printf("Failed to register database pool metrics: %v", err)
+	}
 
 	// Database migrations are handled manually for now
 	log.Println("Skipping automatic migrations - using manual schema")
 
-	// Initialize Redis
-	redisClient := database.NewRedisClient(cfg.RedisURL)
-
-	// Test Redis connection
-	if err := redisClient.Ping(redisClient.Context()).Err(); err != nil {
+	// Initialize Redis, waiting for it to come up if it is not reachable yet
+	redisClient, err := database.NewRedisClient(cfg.RedisURL, database.RedisOptions(cfg.Redis))
+	if err != nil {
 		log.Fatal("Failed to connect to Redis:", err)
 	}
 
 	// Initialize repositories
 	authRepo := repository.NewAuthRepository(db)
 
+	// Security events are written to stdout as JSON, apart from the request logs
+	auditLogger := audit.NewLogger(audit.NewJSONSink(os.Stdout))
+
 	// Initialize services
-	authService := service.NewAuthService(authRepo, redisClient, cfg)
+	authService, err := service.NewAuthService(authRepo, redisClient, cfg, auditLogger)
+	if err != nil {
+		log.Fatal("Failed to initialize auth service:", err)
+	}
+
+	// Delete expired sessions in the background until shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	service.NewSessionCleaner(authRepo).SchedulePeriodicCleanup(ctx, cfg.SessionCleanupInterval)
 
 	// Initialize handlers
-	authHandler := handler.NewAuthHandler(authService)
+	authHandler := handler.NewAuthHandler(authService, auditLogger)
 
 	// Setup router
 	if cfg.Environment == "production" {
@@ -73,8 +103,12 @@ func main() {
 	router.Use(middleware.SecurityHeaders())
 	router.Use(middleware.CORS())
 	router.Use(middleware.Logger())
+	router.Use(middleware.Metrics())
 	router.Use(middleware.RateLimiter(100, 200)) // Global rate limit: 100 req/sec, burst 200
 
+	// Prometheus metrics
+	router.GET("/metrics", middleware.MetricsHandler())
+
 	// Swagger documentation
 	docs.SwaggerInfo.Host = "localhost:" + getPort()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -91,11 +125,23 @@ func main() {
 
 	// Start server
 	port := getPort()
-	log.Printf("Auth service starting on port %s", port)
+	log.Printf("Auth service %s (commit %s) starting on port %s", buildinfo.Version, buildinfo.Commit, port)
 	log.Printf("Swagger documentation available at: http://localhost:%s/swagger/index.html", port)
 	
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	server := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down auth service")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server cleanly: %v", err)
 	}
 }
 
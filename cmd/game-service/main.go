@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	authrepo "chinese-bridge-game/internal/auth/repository"
+	authservice "chinese-bridge-game/internal/auth/service"
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
 	"chinese-bridge-game/internal/game/handler"
 	"chinese-bridge-game/internal/game/repository"
 	"chinese-bridge-game/internal/game/service"
+	"chinese-bridge-game/internal/game/ws"
+	"chinese-bridge-game/pkg/audit"
+	"chinese-bridge-game/pkg/buildinfo"
+	"chinese-bridge-game/pkg/logging"
 	"chinese-bridge-game/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -24,58 +35,119 @@ func main() {
 	// Initialize configuration
 	cfg := config.Load()
 
+	// Operational logs, including the standard log package's, go through slog
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Printf("Using info level: %v", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	slog.SetDefault(logger)
+
 	// Initialize database
-	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL, database.PoolOptions(cfg.Database))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	if err := database.RegisterPoolMetrics(db, "chinese_bridge"); err != nil {
+		log.Printf("Failed to register database pool metrics: %v", err)
+	}
 
 	// Initialize Redis
-	redisClient := database.NewRedisClient(cfg.RedisURL)
+	redisClient, err := database.NewRedisClient(cfg.RedisURL, database.RedisOptions(cfg.Redis))
+	if err != nil {
+		log.Fatal("Failed to connect to Redis:", err)
+	}
+
+	// Initialize repositories. Stats updates invalidate the cached profiles
+	// the user service reads.
+	cache := database.NewInstrumentedCache(database.NewRedisCache(redisClient))
+	gameRepo := repository.NewCachedGameRepository(db, cache, logger)
 
-	// Initialize repositories
-	gameRepo := repository.NewGameRepository(db)
+	// Initialize WebSocket hub
+	hub := ws.NewHub(cfg.Game.AllowedOrigins)
+
+	// Security events are written to stdout as JSON, apart from the request logs
+	auditLogger := audit.NewLogger(audit.NewJSONSink(os.Stdout))
 
 	// Initialize services
-	gameService := service.NewGameService(gameRepo, redisClient)
+	authService, err := authservice.NewAuthService(authrepo.NewAuthRepository(db), redisClient, cfg, auditLogger)
+	if err != nil {
+		log.Fatal("Failed to initialize auth service:", err)
+	}
+	gameStateStore := service.NewRedisGameStateStore(cache)
+	gameService := service.NewGameService(gameRepo, gameStateStore, cache, cache, hub, cfg)
+	roomService := service.NewRoomService(gameRepo, cache, hub)
+	hub.SetDisconnectHandler(func(gameID, userID string) {
+		if err := gameService.HandleDisconnect(context.Background(), gameID, userID); err != nil {
+			log.Printf("Failed to handle disconnect of user %s from game %s: %v", userID, gameID, err)
+		}
+	})
+
+	// Close abandoned waiting rooms in the background until shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	roomReaper := service.NewRoomReaper(gameRepo, database.NewCacheInvalidationStrategy(cache, logger), hub, cfg.Game.RoomIdleTTL)
+	roomReaper.SchedulePeriodicReaping(ctx, cfg.Game.RoomReapInterval)
 
-	// Initialize handlers
-	gameHandler := handler.NewGameHandler(gameService)
+	// Initialize handlers. Bids, plays and chat sent over a game's WebSocket
+	// go through the same services as the HTTP endpoints.
+	gameHandler := handler.NewGameHandler(gameService, roomService, hub)
+	hub.SetMessageHandler(gameHandler.HandleWSMessage)
 
-	// Setup router
-	router := gin.Default()
+	// Setup router. gin's default request logger is left out because it logs
+	// the WebSocket token query parameter; middleware.Logger redacts it.
+	router := gin.New()
+	router.Use(gin.Recovery())
 	router.Use(middleware.CORS())
 	router.Use(middleware.Logger())
+	router.Use(middleware.Metrics())
+
+	// Prometheus metrics
+	router.GET("/metrics", middleware.MetricsHandler())
 
 	// Setup routes
 	api := router.Group("/api/v1")
 	
 	// Health check routes (no auth required)
-	api.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "healthy",
-			"service": "game-service",
-		})
-	})
+	api.GET("/health", gameHandler.HealthCheck)
 	api.GET("/ready", func(c *gin.Context) {
+		pool, err := database.GetPoolStats(db)
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":  "unavailable",
+				"service": "game-service",
+			})
+			return
+		}
 		c.JSON(200, gin.H{
-			"status": "ready",
-			"service": "game-service",
+			"status":   "ready",
+			"service":  "game-service",
+			"database": pool,
 		})
 	})
 	
 	// Protected routes (auth required)
 	protected := api.Group("/")
-	protected.Use(middleware.JWTAuth(cfg.JWTSecret))
+	protected.Use(middleware.JWTAuth(authService, auditLogger))
 	gameHandler.RegisterRoutes(protected)
 
+	// WebSocket routes, which also accept the token as a query parameter
+	websocket := api.Group("/")
+	websocket.Use(middleware.JWTAuthWS(authService, auditLogger))
+	gameHandler.RegisterWebSocketRoutes(websocket)
+
+	// Admin routes
+	admin := protected.Group("/")
+	admin.Use(middleware.RequireRole(database.RoleAdmin))
+	gameHandler.RegisterAdminRoutes(admin)
+
 	// Start server
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8082"
 	}
 
-	log.Printf("Game service starting on port %s", port)
+	log.Printf("Game service %s (commit %s) starting on port %s", buildinfo.Version, buildinfo.Commit, port)
 	if err := router.Run(":" + port); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/pkg/logging"
 
 	"github.com/joho/godotenv"
 )
@@ -18,58 +21,21 @@ func main() {
 	// Initialize configuration
 	cfg := config.Load()
 
-	// Initialize database
-	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Printf("Using info level: %v", err)
 	}
+	logger := logging.New(os.Stdout, logLevel)
 
-	// Run migrations manually
-	log.Println("Starting manual database migrations...")
-
-	// Create tables manually with raw SQL
-	tables := []string{
-		`CREATE TABLE IF NOT EXISTS users (
-			id VARCHAR(36) PRIMARY KEY,
-			google_id VARCHAR(255) UNIQUE NOT NULL,
-			email VARCHAR(255) UNIQUE NOT NULL,
-			name VARCHAR(255) NOT NULL,
-			avatar VARCHAR(500),
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE TABLE IF NOT EXISTS user_stats (
-			user_id VARCHAR(36) PRIMARY KEY,
-			games_played INTEGER DEFAULT 0,
-			games_won INTEGER DEFAULT 0,
-			games_as_declarer INTEGER DEFAULT 0,
-			declarer_wins INTEGER DEFAULT 0,
-			total_points INTEGER DEFAULT 0,
-			average_bid DECIMAL(5,2) DEFAULT 0,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
-		)`,
-		`CREATE TABLE IF NOT EXISTS sessions (
-			id VARCHAR(36) PRIMARY KEY,
-			user_id VARCHAR(36) NOT NULL,
-			token VARCHAR(500) NOT NULL,
-			expires_at TIMESTAMP NOT NULL,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_users_email ON users(email)`,
-		`CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_sessions_token ON sessions(token)`,
-		`CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at)`,
+	// Initialize database
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL, database.PoolOptions(cfg.Database))
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
 	}
 
-	for _, table := range tables {
-		if err := db.Exec(table).Error; err != nil {
-			log.Printf("Warning: Failed to create table/index: %v", err)
-		}
+	// Apply every schema migration the database has not seen yet
+	migrator := database.NewMigrationManager(db, logger)
+	if err := migrator.RunMigrations(context.Background()); err != nil {
+		log.Fatal("Failed to run database migrations:", err)
 	}
-
-	log.Println("Manual database migrations completed successfully")
-}
\ No newline at end of file
+}
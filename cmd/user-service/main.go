@@ -2,13 +2,19 @@ package main
 
 import (
 	"log"
+	"log/slog"
 	"os"
 
+	authrepo "chinese-bridge-game/internal/auth/repository"
+	authservice "chinese-bridge-game/internal/auth/service"
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
 	"chinese-bridge-game/internal/user/handler"
 	"chinese-bridge-game/internal/user/repository"
 	"chinese-bridge-game/internal/user/service"
+	"chinese-bridge-game/pkg/audit"
+	"chinese-bridge-game/pkg/buildinfo"
+	"chinese-bridge-game/pkg/logging"
 	"chinese-bridge-game/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -24,39 +30,64 @@ func main() {
 	// Initialize configuration
 	cfg := config.Load()
 
+	// Operational logs, including the standard log package's, go through slog
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Printf("Using info level: %v", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	slog.SetDefault(logger)
+
 	// Initialize database
-	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL, database.PoolOptions(cfg.Database))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	if err := database.RegisterPoolMetrics(db, "chinese_bridge"); err != nil {
+		log.Printf("Failed to register database pool metrics: %v", err)
+	}
 
 	// Initialize Redis
-	redisClient := database.NewRedisClient(cfg.RedisURL)
+	redisClient, err := database.NewRedisClient(cfg.RedisURL, database.RedisOptions(cfg.Redis))
+	if err != nil {
+		log.Fatal("Failed to connect to Redis:", err)
+	}
 
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
+	// Initialize repositories. User profiles are read through the cache.
+	cache := database.NewInstrumentedCache(database.NewRedisCache(redisClient))
+	userRepo := repository.NewCachedUserRepository(db, cache, logger)
+
+	// Security events are written to stdout as JSON, apart from the request logs
+	auditLogger := audit.NewLogger(audit.NewJSONSink(os.Stdout))
 
 	// Initialize services
-	userService := service.NewUserService(userRepo, redisClient)
+	authService, err := authservice.NewAuthService(authrepo.NewAuthRepository(db), redisClient, cfg, auditLogger)
+	if err != nil {
+		log.Fatal("Failed to initialize auth service:", err)
+	}
+	leaderboard := database.NewLeaderboardReader(cache, database.NewGormRepository(db), logger)
+	userService := service.NewUserService(userRepo, redisClient, leaderboard, nil)
+
+	cacheWarmup := database.NewCacheWarmupStrategy(cache, database.NewCachedUserRepository(database.NewGormRepository(db), cache, logger), logger)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService)
+	adminHandler := handler.NewAdminHandler(cacheWarmup)
 
 	// Setup router
 	router := gin.Default()
 	router.Use(middleware.CORS())
 	router.Use(middleware.Logger())
+	router.Use(middleware.Metrics())
+
+	// Prometheus metrics
+	router.GET("/metrics", middleware.MetricsHandler())
 
 	// Setup routes
 	api := router.Group("/api/v1")
 	
 	// Health check routes (no auth required)
-	api.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "healthy",
-			"service": "user-service",
-		})
-	})
+	api.GET("/health", userHandler.HealthCheck)
 	api.GET("/ready", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status": "ready",
@@ -66,16 +97,21 @@ func main() {
 	
 	// Protected routes (auth required)
 	protected := api.Group("/")
-	protected.Use(middleware.JWTAuth(cfg.JWTSecret))
+	protected.Use(middleware.JWTAuth(authService, auditLogger))
 	userHandler.RegisterRoutes(protected)
 
+	// Admin routes
+	admin := protected.Group("/")
+	admin.Use(middleware.RequireRole(database.RoleAdmin))
+	adminHandler.RegisterRoutes(admin)
+
 	// Start server
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
 	}
 
-	log.Printf("User service starting on port %s", port)
+	log.Printf("User service %s (commit %s) starting on port %s", buildinfo.Version, buildinfo.Commit, port)
 	if err := router.Run(":" + port); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
@@ -68,10 +68,24 @@ type JWTClaims struct {
 	UserID   string `json:"user_id"`
 	Email    string `json:"email"`
 	Name     string `json:"name"`
+	Role     string `json:"role"`
 	IssuedAt int64  `json:"iat"`
 	ExpiresAt int64 `json:"exp"`
 }
 
+// ActiveSession is a login the user can see and revoke
+type ActiveSession struct {
+	ID          string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DeviceLabel string    `json:"device_label" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ActiveSessionsResponse lists the user's active sessions
+type ActiveSessionsResponse struct {
+	Sessions []ActiveSession `json:"sessions"`
+}
+
 // SessionInfo represents session information stored in Redis
 type SessionInfo struct {
 	UserID       string    `json:"user_id"`
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"chinese-bridge-game/internal/auth/dto"
 	"chinese-bridge-game/internal/auth/service"
+	"chinese-bridge-game/pkg/audit"
+	"chinese-bridge-game/pkg/buildinfo"
 	"chinese-bridge-game/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -12,11 +16,13 @@ import (
 
 type AuthHandler struct {
 	authService service.AuthService
+	auditLogger *audit.Logger
 }
 
-func NewAuthHandler(authService service.AuthService) *AuthHandler {
+func NewAuthHandler(authService service.AuthService, auditLogger *audit.Logger) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		auditLogger: auditLogger,
 	}
 }
 
@@ -30,7 +36,9 @@ func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
 		auth.GET("/google/url", h.GetGoogleOAuthURL)
 		auth.POST("/google", h.GoogleOAuthCallback)
 		auth.POST("/refresh", h.RefreshToken)
-		auth.POST("/logout", middleware.JWTAuth(h.authService), h.Logout)
+		auth.POST("/logout", middleware.JWTAuth(h.authService, h.auditLogger), h.Logout)
+		auth.GET("/sessions", middleware.JWTAuth(h.authService, h.auditLogger), h.GetSessions)
+		auth.DELETE("/sessions/:id", middleware.JWTAuth(h.authService, h.auditLogger), h.RevokeSession)
 	}
 }
 
@@ -82,7 +90,8 @@ func (h *AuthHandler) GoogleOAuthCallback(c *gin.Context) {
 		return
 	}
 
-	authResponse, err := h.authService.GoogleOAuthLogin(c.Request.Context(), req.Code)
+	ctx := service.WithDeviceLabel(auditContext(c), c.Request.UserAgent())
+	authResponse, err := h.authService.GoogleOAuthLogin(ctx, req.Code)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
 			Code:    "AUTHENTICATION_ERROR",
@@ -120,7 +129,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	tokenResponse, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	tokenResponse, err := h.authService.RefreshToken(auditContext(c), req.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
 			Code:    "AUTHENTICATION_ERROR",
@@ -156,7 +165,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.Logout(c.Request.Context(), userID); err != nil {
+	if err := h.authService.Logout(auditContext(c), userID); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Failed to logout user",
@@ -171,18 +180,82 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	})
 }
 
+// GetSessions godoc
+// @Summary List active sessions
+// @Description List the user's active logins across devices
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ActiveSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	sessions, err := h.authService.GetActiveSessions(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get sessions",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ActiveSessionsResponse{Sessions: sessions})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Log out one of the user's sessions, invalidating its refresh and access tokens
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	err := h.authService.RevokeSession(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, dto.MessageResponse{
+			Message: "Session revoked",
+		})
+	case errors.Is(err, service.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Session not found",
+			TraceID: c.GetString("trace_id"),
+		})
+	case errors.Is(err, service.ErrSessionForbidden):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Code:    "AUTHORIZATION_ERROR",
+			Message: "Cannot revoke another user's session",
+			TraceID: c.GetString("trace_id"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to revoke session",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+	}
+}
+
 // HealthCheck godoc
 // @Summary Health check
 // @Description Check if the auth service is healthy
 // @Tags health
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Success 200 {object} buildinfo.Health
 // @Router /health [get]
 func (h *AuthHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "auth-service",
-	})
+	c.JSON(http.StatusOK, buildinfo.NewHealth("healthy", "auth-service"))
 }
 
 // ReadyCheck godoc
@@ -197,4 +270,10 @@ func (h *AuthHandler) ReadyCheck(c *gin.Context) {
 		"status":  "ready",
 		"service": "auth-service",
 	})
-}
\ No newline at end of file
+}
+
+// auditContext returns the request context carrying the client details that
+// audit events are recorded with
+func auditContext(c *gin.Context) context.Context {
+	return audit.WithRequest(c.Request.Context(), c.ClientIP(), c.GetString("trace_id"))
+}
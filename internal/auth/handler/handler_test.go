@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"chinese-bridge-game/internal/auth/dto"
 	"chinese-bridge-game/internal/auth/service"
+	"chinese-bridge-game/pkg/buildinfo"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -55,6 +57,19 @@ func (m *MockAuthService) GetGoogleOAuthURL(state string) string {
 	return args.String(0)
 }
 
+func (m *MockAuthService) GetActiveSessions(ctx context.Context, userID string) ([]dto.ActiveSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ActiveSession), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
 func setupTestRouter(authService service.AuthService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -65,7 +80,7 @@ func setupTestRouter(authService service.AuthService) *gin.Engine {
 		c.Next()
 	})
 	
-	handler := NewAuthHandler(authService)
+	handler := NewAuthHandler(authService, nil)
 	api := router.Group("/api/v1")
 	
 	// Add health endpoints
@@ -278,6 +293,87 @@ func TestAuthHandler_Logout_Unauthorized(t *testing.T) {
 	assert.Equal(t, "AUTHENTICATION_ERROR", response.Code)
 }
 
+// authenticate makes the mock service accept "valid-token" as the given user
+func authenticate(mockService *MockAuthService, userID string) {
+	mockService.On("ValidateToken", mock.Anything, "valid-token").Return(&dto.JWTClaims{
+		UserID: userID,
+		Email:  "test@example.com",
+		Name:   "Test User",
+	}, nil)
+}
+
+func TestAuthHandler_GetSessions(t *testing.T) {
+	mockService := new(MockAuthService)
+	router := setupTestRouter(mockService)
+	authenticate(mockService, "test-user-id")
+
+	sessions := []dto.ActiveSession{
+		{ID: "session-1", DeviceLabel: "Firefox", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: "session-2", DeviceLabel: "iPhone", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	mockService.On("GetActiveSessions", mock.Anything, "test-user-id").Return(sessions, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/auth/sessions", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.ActiveSessionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Sessions, 2)
+	assert.Equal(t, "session-1", response.Sessions[0].ID)
+	assert.Equal(t, "iPhone", response.Sessions[1].DeviceLabel)
+	assert.NotContains(t, w.Body.String(), "token", "refresh tokens are never listed")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeSession_Own(t *testing.T) {
+	mockService := new(MockAuthService)
+	router := setupTestRouter(mockService)
+	authenticate(mockService, "test-user-id")
+
+	mockService.On("RevokeSession", mock.Anything, "test-user-id", "session-1").Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/auth/sessions/session-1", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.MessageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Session revoked", response.Message)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeSession_OtherUsers(t *testing.T) {
+	mockService := new(MockAuthService)
+	router := setupTestRouter(mockService)
+	authenticate(mockService, "test-user-id")
+
+	mockService.On("RevokeSession", mock.Anything, "test-user-id", "session-of-another-user").
+		Return(service.ErrSessionForbidden)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/auth/sessions/session-of-another-user", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "AUTHORIZATION_ERROR", response.Code)
+}
+
 func TestAuthHandler_HealthCheck(t *testing.T) {
 	// Setup
 	mockService := new(MockAuthService)
@@ -300,6 +396,37 @@ func TestAuthHandler_HealthCheck(t *testing.T) {
 	assert.Equal(t, "auth-service", response["service"])
 }
 
+func TestAuthHandler_HealthCheckReportsBuildInfo(t *testing.T) {
+	// Setup
+	defer func(version, commit string) {
+		buildinfo.Version, buildinfo.Commit = version, commit
+	}(buildinfo.Version, buildinfo.Commit)
+	buildinfo.Version = "v1.4.2"
+	buildinfo.Commit = "abc1234"
+
+	mockService := new(MockAuthService)
+	router := setupTestRouter(mockService)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/health", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response, "version")
+	assert.Equal(t, "v1.4.2", response["version"])
+	assert.Equal(t, "abc1234", response["commit"])
+	assert.NotEmpty(t, response["started_at"])
+	assert.NotEmpty(t, response["uptime"])
+}
+
 func TestAuthHandler_ReadyCheck(t *testing.T) {
 	// Setup
 	mockService := new(MockAuthService)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"chinese-bridge-game/internal/common/database"
 
@@ -20,15 +21,21 @@ type AuthRepository interface {
 	GetSessionByToken(ctx context.Context, token string) (*database.Session, error)
 	DeleteSession(ctx context.Context, token string) error
 	DeleteUserSessions(ctx context.Context, userID string) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	GetSessionByID(ctx context.Context, id string) (*database.Session, error)
+	GetSessionsByUserID(ctx context.Context, userID string) ([]database.Session, error)
+	UpdateSessionAccessTokenID(ctx context.Context, token, accessTokenID string) error
 }
 
 type authRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	shared database.Repository
 }
 
 func NewAuthRepository(db *gorm.DB) AuthRepository {
 	return &authRepository{
-		db: db,
+		db:     db,
+		shared: database.NewGormRepository(db),
 	}
 }
 
@@ -122,4 +129,42 @@ func (r *authRepository) DeleteSession(ctx context.Context, token string) error
 
 func (r *authRepository) DeleteUserSessions(ctx context.Context, userID string) error {
 	return r.db.WithContext(ctx).Delete(&database.Session{}, "user_id = ?", userID).Error
-}
\ No newline at end of file
+}
+
+// DeleteExpiredSessions removes every session past its expiry and returns how many were deleted
+func (r *authRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	return r.shared.DeleteExpiredSessions(ctx)
+}
+
+func (r *authRepository) GetSessionByID(ctx context.Context, id string) (*database.Session, error) {
+	var session database.Session
+	err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetSessionsByUserID returns the user's unexpired sessions, newest first
+func (r *authRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]database.Session, error) {
+	var sessions []database.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// UpdateSessionAccessTokenID records the jti of the access token most recently
+// issued for the session with the given refresh token
+func (r *authRepository) UpdateSessionAccessTokenID(ctx context.Context, token, accessTokenID string) error {
+	return r.db.WithContext(ctx).
+		Model(&database.Session{}).
+		Where("token = ?", token).
+		Update("access_token_id", accessTokenID).Error
+}
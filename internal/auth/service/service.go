@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"chinese-bridge-game/internal/auth/repository"
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/pkg/audit"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt/v5"
@@ -26,19 +28,20 @@ const (
 	// Redis key prefixes
 	sessionPrefix = "session:"
 	userPrefix    = "user:"
-	
-	// Token expiration times
-	accessTokenExpiry  = 1 * time.Hour
-	refreshTokenExpiry = 24 * time.Hour * 7 // 7 days
-	sessionExpiry      = 24 * time.Hour * 7 // 7 days
 )
 
+// ErrEmailInUse is returned when a Google account's email belongs to an
+// existing user it cannot be linked to
+var ErrEmailInUse = errors.New("email is already registered to another account")
+
 type AuthService interface {
 	GoogleOAuthLogin(ctx context.Context, code string) (*dto.AuthResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenResponse, error)
 	ValidateToken(ctx context.Context, tokenString string) (*dto.JWTClaims, error)
 	Logout(ctx context.Context, userID string) error
 	GetGoogleOAuthURL(state string) string
+	GetActiveSessions(ctx context.Context, userID string) ([]dto.ActiveSession, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
 
 type authService struct {
@@ -46,9 +49,22 @@ type authService struct {
 	redisClient  RedisClient
 	config       *config.Config
 	oauthConfig  *oauth2.Config
+	signer       *tokenSigner
+	accessTTL    time.Duration
+	refreshTTL   time.Duration
+	audit        *audit.Logger
 }
 
-func NewAuthService(repo repository.AuthRepository, redisClient RedisClient, config *config.Config) AuthService {
+// NewAuthService creates an auth service that signs tokens with the algorithm,
+// keys and lifetimes set in the config. Logins, logouts and token refreshes
+// are recorded with the audit logger, which may be nil.
+func NewAuthService(repo repository.AuthRepository, redisClient RedisClient, config *config.Config, auditLogger *audit.Logger) (AuthService, error) {
+	signer, err := newTokenSigner(config)
+	if err != nil {
+		return nil, err
+	}
+	accessTTL, refreshTTL := tokenTTLs(config)
+
 	oauthConfig := &oauth2.Config{
 		ClientID:     config.GoogleOAuth.ClientID,
 		ClientSecret: config.GoogleOAuth.ClientSecret,
@@ -65,7 +81,11 @@ func NewAuthService(repo repository.AuthRepository, redisClient RedisClient, con
 		redisClient: redisClient,
 		config:      config,
 		oauthConfig: oauthConfig,
-	}
+		signer:      signer,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		audit:       auditLogger,
+	}, nil
 }
 
 func (s *authService) GetGoogleOAuthURL(state string) string {
@@ -73,6 +93,39 @@ func (s *authService) GetGoogleOAuthURL(state string) string {
 }
 
 func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.AuthResponse, error) {
+	userInfo, err := s.getGoogleUserInfo(ctx, code)
+	if err != nil {
+		s.audit.LogResult(ctx, audit.EventLogin, "", err)
+		return nil, err
+	}
+	return s.loginGoogleUser(ctx, userInfo)
+}
+
+// linkGoogleAccount finds the user already registered with the Google
+// account's email so the account can be linked to it, or returns nil if there
+// is none. Only an email Google has verified may claim an existing user.
+func (s *authService) linkGoogleAccount(ctx context.Context, userInfo *oauth2v2.Userinfo) (*database.User, error) {
+	if userInfo.Email == "" {
+		return nil, nil
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, userInfo.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	verified := userInfo.VerifiedEmail != nil && *userInfo.VerifiedEmail
+	if !verified || user.GoogleID != "" {
+		return nil, ErrEmailInUse
+	}
+	return user, nil
+}
+
+// getGoogleUserInfo exchanges the authorization code and fetches the Google account it belongs to
+func (s *authService) getGoogleUserInfo(ctx context.Context, code string) (*oauth2v2.Userinfo, error) {
 	// Exchange authorization code for token
 	token, err := s.oauthConfig.Exchange(ctx, code)
 	if err != nil {
@@ -89,6 +142,15 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
+	return userInfo, nil
+}
+
+// loginGoogleUser signs in the Google account, creating its user on first
+// login unless an existing user with the same email can be linked to it, and
+// starts a new session
+func (s *authService) loginGoogleUser(ctx context.Context, userInfo *oauth2v2.Userinfo) (response *dto.AuthResponse, err error) {
+	userID := ""
+	defer func() { s.audit.LogResult(ctx, audit.EventLogin, userID, err) }()
 
 	// Check if user exists or create new user
 	user, err := s.repo.GetUserByGoogleID(ctx, userInfo.Id)
@@ -96,6 +158,12 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 		return nil, fmt.Errorf("failed to get user by google id: %w", err)
 	}
 
+	if user == nil {
+		if user, err = s.linkGoogleAccount(ctx, userInfo); err != nil {
+			return nil, err
+		}
+	}
+
 	if user == nil {
 		// Create new user
 		user = &database.User{
@@ -104,6 +172,7 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 			Email:    userInfo.Email,
 			Name:     userInfo.Name,
 			Avatar:   userInfo.Picture,
+			Role:     database.RolePlayer,
 		}
 
 		if err := s.repo.CreateUser(ctx, user); err != nil {
@@ -116,16 +185,18 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 		}
 		user.Stats = stats
 	} else {
-		// Update existing user info
+		// Update existing user info, linking the Google account if it is new
+		user.GoogleID = userInfo.Id
 		user.Name = userInfo.Name
 		user.Avatar = userInfo.Picture
 		if err := s.repo.UpdateUser(ctx, user); err != nil {
 			return nil, fmt.Errorf("failed to update user: %w", err)
 		}
 	}
+	userID = user.ID
 
 	// Generate JWT tokens
-	accessToken, err := s.generateAccessToken(user)
+	accessToken, accessTokenID, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -142,7 +213,7 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 		Name:         user.Name,
 		RefreshToken: refreshToken,
 		CreatedAt:    time.Now(),
-		ExpiresAt:    time.Now().Add(sessionExpiry),
+		ExpiresAt:    time.Now().Add(s.refreshTTL),
 	}
 
 	if err := s.storeSession(ctx, refreshToken, sessionInfo); err != nil {
@@ -151,10 +222,12 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 
 	// Store session in database
 	dbSession := &database.Session{
-		ID:        uuid.New().String(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: sessionInfo.ExpiresAt,
+		ID:            uuid.New().String(),
+		UserID:        user.ID,
+		Token:         refreshToken,
+		DeviceLabel:   deviceLabelFrom(ctx),
+		AccessTokenID: accessTokenID,
+		ExpiresAt:     sessionInfo.ExpiresAt,
 	}
 
 	if err := s.repo.CreateSession(ctx, dbSession); err != nil {
@@ -165,7 +238,7 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		TokenType:    "Bearer",
-		ExpiresIn:    int(accessTokenExpiry.Seconds()),
+		ExpiresIn:    int(s.accessTTL.Seconds()),
 		User: dto.UserInfo{
 			ID:       user.ID,
 			GoogleID: user.GoogleID,
@@ -176,7 +249,10 @@ func (s *authService) GoogleOAuthLogin(ctx context.Context, code string) (*dto.A
 	}, nil
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenResponse, error) {
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (response *dto.TokenResponse, err error) {
+	userID := ""
+	defer func() { s.audit.LogResult(ctx, audit.EventTokenRefresh, userID, err) }()
+
 	// Get session from Redis
 	sessionInfo, err := s.getSession(ctx, refreshToken)
 	if err != nil {
@@ -186,6 +262,7 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	if sessionInfo == nil {
 		return nil, fmt.Errorf("invalid refresh token")
 	}
+	userID = sessionInfo.UserID
 
 	// Check if session is expired
 	if time.Now().After(sessionInfo.ExpiresAt) {
@@ -206,25 +283,25 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	}
 
 	// Generate new access token
-	accessToken, err := s.generateAccessToken(user)
+	accessToken, accessTokenID, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
+	// Remember the new token so revoking the session can denylist it
+	if err := s.repo.UpdateSessionAccessTokenID(ctx, refreshToken, accessTokenID); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
 	return &dto.TokenResponse{
 		AccessToken: accessToken,
 		TokenType:   "Bearer",
-		ExpiresIn:   int(accessTokenExpiry.Seconds()),
+		ExpiresIn:   int(s.accessTTL.Seconds()),
 	}, nil
 }
 
 func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*dto.JWTClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.JWTSecret), nil
-	})
+	token, err := s.signer.parse(tokenString)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -254,6 +331,12 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		return nil, fmt.Errorf("invalid name claim")
 	}
 
+	// Tokens issued before roles were added belong to players
+	role, ok := claims["role"].(string)
+	if !ok {
+		role = database.RolePlayer
+	}
+
 	iat, ok := claims["iat"].(float64)
 	if !ok {
 		return nil, fmt.Errorf("invalid iat claim")
@@ -264,16 +347,30 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		return nil, fmt.Errorf("invalid exp claim")
 	}
 
+	// Tokens issued before jti was added cannot be revoked individually
+	if tokenID, ok := claims["jti"].(string); ok {
+		revoked, err := s.isRevoked(ctx, tokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	return &dto.JWTClaims{
 		UserID:    userID,
 		Email:     email,
 		Name:      name,
+		Role:      role,
 		IssuedAt:  int64(iat),
 		ExpiresAt: int64(exp),
 	}, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userID string) error {
+func (s *authService) Logout(ctx context.Context, userID string) (err error) {
+	defer func() { s.audit.LogResult(ctx, audit.EventLogout, userID, err) }()
+
 	// Delete all user sessions from database
 	if err := s.repo.DeleteUserSessions(ctx, userID); err != nil {
 		return fmt.Errorf("failed to delete user sessions: %w", err)
@@ -306,18 +403,33 @@ func (s *authService) Logout(ctx context.Context, userID string) error {
 	return nil
 }
 
-func (s *authService) generateAccessToken(user *database.User) (string, error) {
+// generateAccessToken returns a signed access token for the user and its jti
+func (s *authService) generateAccessToken(user *database.User) (string, string, error) {
 	now := time.Now()
+	tokenID := uuid.New().String()
 	claims := jwt.MapClaims{
+		"jti":     tokenID,
 		"user_id": user.ID,
 		"email":   user.Email,
 		"name":    user.Name,
+		"role":    userRole(user),
 		"iat":     now.Unix(),
-		"exp":     now.Add(accessTokenExpiry).Unix(),
+		"exp":     now.Add(s.accessTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.JWTSecret))
+	token, err := s.signer.sign(claims)
+	if err != nil {
+		return "", "", err
+	}
+	return token, tokenID, nil
+}
+
+// userRole returns the user's role, treating users loaded without one as players
+func userRole(user *database.User) string {
+	if user.Role == "" {
+		return database.RolePlayer
+	}
+	return user.Role
 }
 
 func (s *authService) generateRefreshToken() (string, error) {
@@ -335,7 +447,7 @@ func (s *authService) storeSession(ctx context.Context, refreshToken string, ses
 	}
 
 	key := sessionPrefix + refreshToken
-	return s.redisClient.Set(ctx, key, sessionData, sessionExpiry).Err()
+	return s.redisClient.Set(ctx, key, sessionData, s.refreshTTL).Err()
 }
 
 func (s *authService) getSession(ctx context.Context, refreshToken string) (*dto.SessionInfo, error) {
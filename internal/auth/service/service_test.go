@@ -1,19 +1,30 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"chinese-bridge-game/internal/auth/dto"
 	"chinese-bridge-game/internal/common/config"
 	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/pkg/audit"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	oauth2v2 "google.golang.org/api/oauth2/v2"
 )
 
 // MockAuthRepository is a mock implementation of AuthRepository
@@ -78,6 +89,29 @@ func (m *MockAuthRepository) DeleteUserSessions(ctx context.Context, userID stri
 	return args.Error(0)
 }
 
+func (m *MockAuthRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetSessionByID(ctx context.Context, id string) (*database.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Session), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]database.Session, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]database.Session), args.Error(1)
+}
+
+func (m *MockAuthRepository) UpdateSessionAccessTokenID(ctx context.Context, token, accessTokenID string) error {
+	args := m.Called(ctx, token, accessTokenID)
+	return args.Error(0)
+}
+
 // MockRedisClient is a mock implementation of RedisClient interface
 type MockRedisClient struct {
 	mock.Mock
@@ -148,6 +182,22 @@ func (m *MockRedisClient) Keys(ctx context.Context, pattern string) *redis.Strin
 	return cmd
 }
 
+// newTestAuthService creates an auth service, failing the test if the config is invalid
+func newTestAuthService(t *testing.T, repo *MockAuthRepository, redisClient *MockRedisClient, cfg *config.Config) *authService {
+	t.Helper()
+
+	service, err := NewAuthService(repo, redisClient, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthService() error = %v", err)
+	}
+
+	// No access tokens are revoked unless a test adds them to the denylist
+	redisClient.On("Get", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, denylistPrefix)
+	})).Return(nil).Maybe()
+	return service.(*authService)
+}
+
 func TestAuthService_ValidateToken(t *testing.T) {
 	// Setup
 	mockRepo := new(MockAuthRepository)
@@ -156,7 +206,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		JWTSecret: "test-secret",
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg).(*authService)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 
 	// Test data
 	user := &database.User{
@@ -166,7 +216,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	}
 
 	// Generate a valid token
-	token, err := service.generateAccessToken(user)
+	token, _, err := service.generateAccessToken(user)
 	assert.NoError(t, err)
 
 	// Test valid token
@@ -175,6 +225,15 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	assert.Equal(t, user.ID, claims.UserID)
 	assert.Equal(t, user.Email, claims.Email)
 	assert.Equal(t, user.Name, claims.Name)
+	assert.Equal(t, database.RolePlayer, claims.Role)
+
+	// The role is carried in the token
+	admin := &database.User{ID: "admin-id", Email: "admin@example.com", Name: "Admin", Role: database.RoleAdmin}
+	adminToken, _, err := service.generateAccessToken(admin)
+	assert.NoError(t, err)
+	claims, err = service.ValidateToken(context.Background(), adminToken)
+	assert.NoError(t, err)
+	assert.Equal(t, database.RoleAdmin, claims.Role)
 
 	// Test invalid token
 	_, err = service.ValidateToken(context.Background(), "invalid-token")
@@ -204,7 +263,7 @@ func TestAuthService_GenerateAccessToken(t *testing.T) {
 		JWTSecret: "test-secret",
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg).(*authService)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 
 	// Test data
 	user := &database.User{
@@ -214,7 +273,7 @@ func TestAuthService_GenerateAccessToken(t *testing.T) {
 	}
 
 	// Generate token
-	tokenString, err := service.generateAccessToken(user)
+	tokenString, _, err := service.generateAccessToken(user)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
@@ -233,6 +292,172 @@ func TestAuthService_GenerateAccessToken(t *testing.T) {
 	assert.Equal(t, user.Name, claims["name"])
 }
 
+func TestAuthService_GenerateAccessTokenUsesConfiguredTTL(t *testing.T) {
+	cfg := &config.Config{
+		JWTSecret:    "test-secret",
+		JWTAccessTTL: 15 * time.Minute,
+	}
+	service := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), cfg)
+
+	tokenString, _, err := service.generateAccessToken(&database.User{ID: "test-user-id", Email: "test@example.com", Name: "Test User"})
+	assert.NoError(t, err)
+
+	claims, err := service.ValidateToken(context.Background(), tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15*time.Minute/time.Second), claims.ExpiresAt-claims.IssuedAt)
+}
+
+func TestAuthService_ValidateTokenRejectsOtherAlgorithms(t *testing.T) {
+	cfg := &config.Config{
+		JWTSecret: "test-secret",
+	}
+	service := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), cfg)
+
+	claims := jwt.MapClaims{
+		"user_id": "test-user-id",
+		"email":   "test@example.com",
+		"name":    "Test User",
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}
+
+	// Signed with the right secret but a different HMAC algorithm
+	hs512, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte(cfg.JWTSecret))
+	assert.NoError(t, err)
+	_, err = service.ValidateToken(context.Background(), hs512)
+	assert.Error(t, err)
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+	_, err = service.ValidateToken(context.Background(), unsigned)
+	assert.Error(t, err)
+}
+
+func TestAuthService_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "jwt.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	assert.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+
+	cfg := &config.Config{
+		JWTSecret:    "test-secret",
+		JWTAlgorithm: "RS256",
+		JWTKeyPath:   keyPath,
+	}
+	service := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), cfg)
+
+	user := &database.User{ID: "test-user-id", Email: "test@example.com", Name: "Test User"}
+	tokenString, _, err := service.generateAccessToken(user)
+	assert.NoError(t, err)
+
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "RS256", token.Header["alg"])
+
+	claims, err := service.ValidateToken(context.Background(), tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+
+	// An HS256 token signed with the shared secret is not accepted once RS256 is configured
+	hs256 := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), &config.Config{JWTSecret: cfg.JWTSecret})
+	hsToken, _, err := hs256.generateAccessToken(user)
+	assert.NoError(t, err)
+	_, err = service.ValidateToken(context.Background(), hsToken)
+	assert.Error(t, err)
+}
+
+func TestAuthService_KeyRotation(t *testing.T) {
+	user := &database.User{ID: "test-user-id", Email: "test@example.com", Name: "Test User"}
+
+	oldService := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), &config.Config{
+		JWTSecret: "old-secret",
+		JWTKeyID:  "2024-01",
+	})
+	oldToken, _, err := oldService.generateAccessToken(user)
+	assert.NoError(t, err)
+
+	service := newTestAuthService(t, new(MockAuthRepository), NewMockRedisClient(), &config.Config{
+		JWTSecret:       "new-secret",
+		JWTKeyID:        "2024-02",
+		JWTPreviousKeys: map[string]string{"2024-01": "old-secret"},
+	})
+
+	newToken, _, err := service.generateAccessToken(user)
+	assert.NoError(t, err)
+	token, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02", token.Header["kid"])
+
+	for _, tokenString := range []string{newToken, oldToken} {
+		claims, err := service.ValidateToken(context.Background(), tokenString)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+	}
+
+	// Tokens naming a key the service does not trust, or no key at all, are rejected
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"name":    user.Name,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}
+	for _, keyID := range []interface{}{"2023-12", nil} {
+		unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		if keyID != nil {
+			unknown.Header["kid"] = keyID
+		}
+		tokenString, err := unknown.SignedString([]byte("new-secret"))
+		assert.NoError(t, err)
+
+		_, err = service.ValidateToken(context.Background(), tokenString)
+		assert.Error(t, err, "kid %v", keyID)
+	}
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	service, mockRepo, mockRedis := setupTestService(t)
+	ctx := context.Background()
+
+	user := &database.User{ID: "test-user-id", Email: "test@example.com", Name: "Test User"}
+	accessToken, tokenID, err := service.generateAccessToken(user)
+	assert.NoError(t, err)
+
+	session := &database.Session{ID: "session-1", UserID: user.ID, Token: "refresh-token", AccessTokenID: tokenID}
+	mockRepo.On("GetSessionByID", mock.Anything, "session-1").Return(session, nil)
+	mockRepo.On("GetSessionByID", mock.Anything, "missing").Return(nil, nil)
+	mockRepo.On("DeleteSession", mock.Anything, "refresh-token").Return(nil)
+	mockRedis.On("Del", mock.Anything, []string{"session:refresh-token"}).Return(nil)
+	mockRedis.On("Set", mock.Anything, "denylist:"+tokenID, "revoked", defaultAccessTokenExpiry).Return(nil)
+
+	assert.ErrorIs(t, service.RevokeSession(ctx, "another-user", "session-1"), ErrSessionForbidden)
+	assert.ErrorIs(t, service.RevokeSession(ctx, user.ID, "missing"), ErrSessionNotFound)
+	mockRepo.AssertNotCalled(t, "DeleteSession", mock.Anything, mock.Anything)
+
+	assert.NoError(t, service.RevokeSession(ctx, user.ID, "session-1"))
+	mockRepo.AssertExpectations(t)
+	mockRedis.AssertExpectations(t)
+
+	// The session's access token no longer validates
+	_, err = service.ValidateToken(ctx, accessToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+}
+
+func TestNewAuthService_InvalidSigningConfig(t *testing.T) {
+	configs := map[string]*config.Config{
+		"unsupported algorithm": {JWTSecret: "test-secret", JWTAlgorithm: "ES256"},
+		"missing RS256 key":     {JWTAlgorithm: "RS256"},
+		"unreadable RS256 key":  {JWTAlgorithm: "RS256", JWTKeyPath: filepath.Join(t.TempDir(), "missing.pem")},
+		"reused key id":         {JWTSecret: "test-secret", JWTKeyID: "current", JWTPreviousKeys: map[string]string{"current": "old-secret"}},
+	}
+
+	for name, cfg := range configs {
+		_, err := NewAuthService(new(MockAuthRepository), NewMockRedisClient(), cfg, nil)
+		assert.Error(t, err, name)
+	}
+}
+
 func TestAuthService_GenerateRefreshToken(t *testing.T) {
 	// Setup
 	mockRepo := new(MockAuthRepository)
@@ -241,7 +466,7 @@ func TestAuthService_GenerateRefreshToken(t *testing.T) {
 		JWTSecret: "test-secret",
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg).(*authService)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 
 	// Generate refresh token
 	token1, err := service.generateRefreshToken()
@@ -269,7 +494,7 @@ func TestAuthService_Logout(t *testing.T) {
 		JWTSecret: "test-secret",
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 
 	userID := "test-user-id"
 
@@ -297,7 +522,7 @@ func TestAuthService_GetGoogleOAuthURL(t *testing.T) {
 		},
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 
 	// Test URL generation
 	state := "test-state"
@@ -311,7 +536,7 @@ func TestAuthService_GetGoogleOAuthURL(t *testing.T) {
 }
 
 // Integration test helper functions
-func setupTestService() (*authService, *MockAuthRepository, *MockRedisClient) {
+func setupTestService(t *testing.T) (*authService, *MockAuthRepository, *MockRedisClient) {
 	mockRepo := new(MockAuthRepository)
 	mockRedis := NewMockRedisClient()
 	cfg := &config.Config{
@@ -323,12 +548,12 @@ func setupTestService() (*authService, *MockAuthRepository, *MockRedisClient) {
 		},
 	}
 
-	service := NewAuthService(mockRepo, mockRedis, cfg).(*authService)
+	service := newTestAuthService(t, mockRepo, mockRedis, cfg)
 	return service, mockRepo, mockRedis
 }
 
 func TestAuthService_StoreAndGetSession(t *testing.T) {
-	service, _, mockRedis := setupTestService()
+	service, _, mockRedis := setupTestService(t)
 
 	// Test data
 	refreshToken := "test-refresh-token"
@@ -342,7 +567,7 @@ func TestAuthService_StoreAndGetSession(t *testing.T) {
 	}
 
 	// Setup expectations
-	mockRedis.On("Set", mock.Anything, "session:"+refreshToken, mock.Anything, sessionExpiry).Return(nil)
+	mockRedis.On("Set", mock.Anything, "session:"+refreshToken, mock.Anything, defaultRefreshTokenExpiry).Return(nil)
 	mockRedis.On("Get", mock.Anything, "session:"+refreshToken).Return(nil)
 
 	// Store session
@@ -355,4 +580,106 @@ func TestAuthService_StoreAndGetSession(t *testing.T) {
 	assert.Equal(t, sessionInfo.UserID, retrievedSession.UserID)
 	assert.Equal(t, sessionInfo.Email, retrievedSession.Email)
 	assert.Equal(t, sessionInfo.Name, retrievedSession.Name)
-}
\ No newline at end of file
+}
+func TestAuthService_LoginIsAudited(t *testing.T) {
+	service, mockRepo, mockRedis := setupTestService(t)
+	var auditLog bytes.Buffer
+	service.audit = audit.NewLogger(audit.NewJSONSink(&auditLog))
+
+	user := &database.User{ID: "test-user-id", GoogleID: "google-id", Email: "test@example.com", Name: "Test User"}
+	mockRepo.On("GetUserByGoogleID", mock.Anything, "google-id").Return(user, nil)
+	mockRepo.On("UpdateUser", mock.Anything, user).Return(nil)
+	mockRepo.On("CreateSession", mock.Anything, mock.Anything).Return(nil)
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := audit.WithRequest(context.Background(), "203.0.113.7", "test-trace-id")
+	_, err := service.loginGoogleUser(ctx, &oauth2v2.Userinfo{Id: "google-id", Email: user.Email, Name: "Test User"})
+	assert.NoError(t, err)
+
+	var event audit.Event
+	assert.NoError(t, json.Unmarshal(auditLog.Bytes(), &event))
+	assert.Equal(t, audit.EventLogin, event.Type)
+	assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+	assert.Equal(t, user.ID, event.UserID)
+	assert.Equal(t, "203.0.113.7", event.IP)
+	assert.Equal(t, "test-trace-id", event.TraceID)
+	assert.Empty(t, event.Reason)
+}
+
+func TestAuthService_GoogleLoginLinksExistingEmailUser(t *testing.T) {
+	service, mockRepo, mockRedis := setupTestService(t)
+	ctx := context.Background()
+	verified := true
+
+	existing := &database.User{ID: "email-user-id", Email: "test@example.com", Name: "Old Name"}
+	mockRepo.On("GetUserByGoogleID", ctx, "google-id").Return(nil, nil)
+	mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(existing, nil)
+	mockRepo.On("UpdateUser", ctx, mock.MatchedBy(func(user *database.User) bool {
+		return user.ID == "email-user-id" && user.GoogleID == "google-id"
+	})).Return(nil)
+	mockRepo.On("CreateSession", ctx, mock.Anything).Return(nil)
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	response, err := service.loginGoogleUser(ctx, &oauth2v2.Userinfo{
+		Id: "google-id", Email: "test@example.com", VerifiedEmail: &verified, Name: "Test User",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "email-user-id", response.User.ID)
+	assert.Equal(t, "google-id", existing.GoogleID)
+	assert.Equal(t, "Test User", existing.Name)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_GoogleLoginCreatesUserForNewEmail(t *testing.T) {
+	service, mockRepo, mockRedis := setupTestService(t)
+	ctx := context.Background()
+	verified := true
+
+	var created *database.User
+	mockRepo.On("GetUserByGoogleID", ctx, "google-id").Return(nil, nil)
+	mockRepo.On("GetUserByEmail", ctx, "new@example.com").Return(nil, nil)
+	mockRepo.On("CreateUser", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*database.User)
+	}).Return(nil)
+	mockRepo.On("CreateSession", ctx, mock.Anything).Return(nil)
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.loginGoogleUser(ctx, &oauth2v2.Userinfo{
+		Id: "google-id", Email: "new@example.com", VerifiedEmail: &verified, Name: "New User",
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, "google-id", created.GoogleID)
+		assert.Equal(t, "new@example.com", created.Email)
+	}
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_GoogleLoginRefusesToLinkEmail(t *testing.T) {
+	verified, unverified := true, false
+	tests := []struct {
+		name     string
+		existing *database.User
+		verified *bool
+	}{
+		{"Unverified email", &database.User{ID: "email-user-id", Email: "test@example.com"}, &unverified},
+		{"Linked to another Google account", &database.User{ID: "email-user-id", GoogleID: "other-google-id", Email: "test@example.com"}, &verified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := setupTestService(t)
+			ctx := context.Background()
+			mockRepo.On("GetUserByGoogleID", ctx, "google-id").Return(nil, nil)
+			mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(tt.existing, nil)
+
+			_, err := service.loginGoogleUser(ctx, &oauth2v2.Userinfo{
+				Id: "google-id", Email: "test@example.com", VerifiedEmail: tt.verified,
+			})
+			assert.ErrorIs(t, err, ErrEmailInUse)
+			mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+		})
+	}
+}
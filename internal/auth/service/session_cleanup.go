@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"chinese-bridge-game/internal/auth/repository"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var expiredSessionsDeleted = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "auth_expired_sessions_deleted_total",
+	Help: "Total number of expired sessions removed from the database.",
+})
+
+// SessionCleaner removes expired sessions from the database so the sessions
+// table does not grow without bound
+type SessionCleaner struct {
+	repo repository.AuthRepository
+}
+
+// NewSessionCleaner creates a session cleaner
+func NewSessionCleaner(repo repository.AuthRepository) *SessionCleaner {
+	return &SessionCleaner{repo: repo}
+}
+
+// DeleteExpiredSessions removes every expired session and returns how many were deleted
+func (c *SessionCleaner) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	deleted, err := c.repo.DeleteExpiredSessions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	expiredSessionsDeleted.Add(float64(deleted))
+	if deleted > 0 {
+		log.Printf("Deleted %d expired sessions", deleted)
+	}
+	return deleted, nil
+}
+
+// SchedulePeriodicCleanup starts deleting expired sessions on every interval
+// until the context is cancelled. A zero interval disables the cleanup.
+func (c *SessionCleaner) SchedulePeriodicCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("Periodic session cleanup disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Session cleanup scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := c.DeleteExpiredSessions(ctx); err != nil {
+					log.Printf("Error during periodic session cleanup: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("Started periodic session cleanup with interval: %v", interval)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/auth/repository"
+	"chinese-bridge-game/internal/common/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSessionCleaner_DeleteExpiredSessions(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.NewMigrationManager(db, nil).RunMigrations(context.Background()))
+
+	ctx := context.Background()
+	repo := repository.NewAuthRepository(db)
+	user := &database.User{GoogleID: "google-1", Email: "test@example.com", Name: "Test User"}
+	require.NoError(t, repo.CreateUser(ctx, user))
+
+	sessions := map[string]time.Duration{
+		"expired-1": -2 * time.Hour,
+		"expired-2": -time.Minute,
+		"valid":     time.Hour,
+	}
+	for token, expiresIn := range sessions {
+		require.NoError(t, repo.CreateSession(ctx, &database.Session{
+			UserID:    user.ID,
+			Token:     token,
+			ExpiresAt: time.Now().Add(expiresIn),
+		}))
+	}
+
+	deleted, err := NewSessionCleaner(repo).DeleteExpiredSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	var remaining []database.Session
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "valid", remaining[0].Token)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"chinese-bridge-game/internal/auth/dto"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis key prefix for the jti of access tokens revoked before they expire
+const denylistPrefix = "denylist:"
+
+// maxDeviceLabelLength matches the size of the sessions.device_label column
+const maxDeviceLabelLength = 255
+
+var (
+	// ErrSessionNotFound is returned when a session does not exist or has been revoked
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrSessionForbidden is returned when a user acts on another user's session
+	ErrSessionForbidden = errors.New("session belongs to another user")
+	// ErrTokenRevoked is returned when an access token's session has been revoked
+	ErrTokenRevoked = errors.New("token has been revoked")
+)
+
+type deviceLabelKey struct{}
+
+// WithDeviceLabel returns a context carrying a label, such as the client's
+// user agent, for the session created by a login made with it
+func WithDeviceLabel(ctx context.Context, label string) context.Context {
+	if len(label) > maxDeviceLabelLength {
+		label = label[:maxDeviceLabelLength]
+	}
+	return context.WithValue(ctx, deviceLabelKey{}, label)
+}
+
+// deviceLabelFrom returns the device label carried by the context, if any
+func deviceLabelFrom(ctx context.Context) string {
+	label, _ := ctx.Value(deviceLabelKey{}).(string)
+	return label
+}
+
+func (s *authService) GetActiveSessions(ctx context.Context, userID string) ([]dto.ActiveSession, error) {
+	sessions, err := s.repo.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	active := make([]dto.ActiveSession, 0, len(sessions))
+	for _, session := range sessions {
+		active = append(active, dto.ActiveSession{
+			ID:          session.ID,
+			DeviceLabel: session.DeviceLabel,
+			CreatedAt:   session.CreatedAt,
+			ExpiresAt:   session.ExpiresAt,
+		})
+	}
+	return active, nil
+}
+
+// RevokeSession ends one of the user's sessions. Its refresh token is deleted
+// from the database and Redis, and its current access token is denylisted so
+// it stops working before it expires.
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return ErrSessionNotFound
+	}
+	if session.UserID != userID {
+		return ErrSessionForbidden
+	}
+
+	if err := s.repo.DeleteSession(ctx, session.Token); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if err := s.deleteSession(ctx, session.Token); err != nil {
+		return fmt.Errorf("failed to delete cached session: %w", err)
+	}
+
+	if session.AccessTokenID != "" {
+		key := denylistPrefix + session.AccessTokenID
+		if err := s.redisClient.Set(ctx, key, "revoked", s.accessTTL).Err(); err != nil {
+			return fmt.Errorf("failed to revoke access token: %w", err)
+		}
+	}
+	return nil
+}
+
+// isRevoked checks if the access token with the given jti has been denylisted
+func (s *authService) isRevoked(ctx context.Context, tokenID string) (bool, error) {
+	err := s.redisClient.Get(ctx, denylistPrefix+tokenID).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"chinese-bridge-game/internal/common/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Token expiration times used when the config leaves them unset
+	defaultAccessTokenExpiry  = 1 * time.Hour
+	defaultRefreshTokenExpiry = 24 * time.Hour * 7 // 7 days
+)
+
+// tokenSigner signs access tokens with the current key and verifies them with
+// whichever trusted key their kid header names, so rotating the signing key
+// does not invalidate tokens issued under the previous one
+type tokenSigner struct {
+	method     jwt.SigningMethod
+	keyID      string
+	signKey    interface{}
+	verifyKeys map[string]interface{}
+}
+
+// newTokenSigner loads the keys for the configured algorithm. HS256 uses the
+// shared JWT secret and RS256 the RSA private key at the configured path.
+// Previous keys are secrets for HS256 and RSA key paths for RS256.
+func newTokenSigner(cfg *config.Config) (*tokenSigner, error) {
+	algorithm := cfg.JWTAlgorithm
+	if algorithm == "" {
+		algorithm = jwt.SigningMethodHS256.Alg()
+	}
+
+	signer := &tokenSigner{keyID: cfg.JWTKeyID, verifyKeys: make(map[string]interface{})}
+	var loadVerifyKey func(value string) (interface{}, error)
+
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		signer.method = jwt.SigningMethodHS256
+		signer.signKey = []byte(cfg.JWTSecret)
+		signer.verifyKeys[signer.keyID] = signer.signKey
+		loadVerifyKey = func(secret string) (interface{}, error) {
+			return []byte(secret), nil
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if cfg.JWTKeyPath == "" {
+			return nil, fmt.Errorf("JWT key path is required for %s", algorithm)
+		}
+		data, err := os.ReadFile(cfg.JWTKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT key: %w", err)
+		}
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT key: %w", err)
+		}
+		signer.method = jwt.SigningMethodRS256
+		signer.signKey = key
+		signer.verifyKeys[signer.keyID] = &key.PublicKey
+		loadVerifyKey = loadRSAPublicKey
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+
+	for keyID, value := range cfg.JWTPreviousKeys {
+		if keyID == signer.keyID {
+			return nil, fmt.Errorf("previous JWT key %q has the same id as the current key", keyID)
+		}
+		key, err := loadVerifyKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous JWT key %q: %w", keyID, err)
+		}
+		signer.verifyKeys[keyID] = key
+	}
+	return signer, nil
+}
+
+// loadRSAPublicKey reads an RSA public key, or the public half of a private
+// key, from the PEM file at path
+func loadRSAPublicKey(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	return &key.PublicKey, nil
+}
+
+// sign returns the claims signed with the current key, naming it in the kid header
+func (s *tokenSigner) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.keyID != "" {
+		token.Header["kid"] = s.keyID
+	}
+	return token.SignedString(s.signKey)
+}
+
+// parse verifies the token's signature and standard claims, rejecting tokens
+// signed with any algorithm other than the configured one or by a key that is
+// not trusted. Tokens without a kid are checked against the key with no id.
+func (s *tokenSigner) parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		key, ok := s.verifyKeys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", keyID)
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{s.method.Alg()}))
+}
+
+// tokenTTLs returns the configured access and refresh token lifetimes,
+// falling back to the defaults for any left unset
+func tokenTTLs(cfg *config.Config) (time.Duration, time.Duration) {
+	accessTTL, refreshTTL := cfg.JWTAccessTTL, cfg.JWTRefreshTTL
+	if accessTTL <= 0 {
+		accessTTL = defaultAccessTokenExpiry
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenExpiry
+	}
+	return accessTTL, refreshTTL
+}
@@ -3,10 +3,23 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrCacheMiss is returned when a requested key is not in the cache
+	ErrCacheMiss = errors.New("key not found")
+	// ErrStaleGameState is returned when a game state was changed by someone else
+	// since it was read, so the caller should reload and retry
+	ErrStaleGameState = errors.New("game state has been modified")
+	// ErrLockNotAcquired is returned when a lock is still held by someone else
+	// when the caller's context ends
+	ErrLockNotAcquired = errors.New("lock not acquired")
 )
 
 // Cache interface defines caching operations
@@ -16,6 +29,11 @@ type Cache interface {
 	GetUserSession(ctx context.Context, userID string) (string, error)
 	DeleteUserSession(ctx context.Context, userID string) error
 
+	// User profile caching
+	SetUserProfile(ctx context.Context, userID string, profile interface{}, ttl time.Duration) error
+	GetUserProfile(ctx context.Context, userID string) (string, error)
+	DeleteUserProfile(ctx context.Context, userID string) error
+
 	// Room state caching
 	SetRoomState(ctx context.Context, roomID string, roomState interface{}, ttl time.Duration) error
 	GetRoomState(ctx context.Context, roomID string) (string, error)
@@ -26,6 +44,21 @@ type Cache interface {
 	GetGameState(ctx context.Context, gameID string) (string, error)
 	DeleteGameState(ctx context.Context, gameID string) error
 
+	// Versioned full game state, written only if the stored version matches
+	SetFullGameState(ctx context.Context, gameID string, gameState interface{}, expectedVersion int, ttl time.Duration) error
+	GetFullGameState(ctx context.Context, gameID string) (string, error)
+
+	// Per-game distributed lock, released by calling the returned function
+	AcquireGameLock(ctx context.Context, gameID string, ttl time.Duration) (release func(), err error)
+
+	// Results of requests made with an idempotency key, replayed on retries
+	SetIdempotentResult(ctx context.Context, key string, result interface{}, ttl time.Duration) error
+	GetIdempotentResult(ctx context.Context, key string) (string, error)
+
+	// Room chat, keeping only the most recent messages
+	AddChatMessage(ctx context.Context, roomID string, message interface{}, maxMessages int) error
+	GetChatMessages(ctx context.Context, roomID string, offset, limit int) ([]string, error)
+
 	// Leaderboard caching
 	SetLeaderboard(ctx context.Context, leaderboardData interface{}, ttl time.Duration) error
 	GetLeaderboard(ctx context.Context) (string, error)
@@ -62,9 +95,15 @@ func NewRedisCache(client *redis.Client) Cache {
 // Cache key constants
 const (
 	UserSessionKeyPrefix    = "session:user:"
+	UserProfileKeyPrefix    = "user:profile:"
 	RoomStateKeyPrefix      = "room:state:"
 	GameStateKeyPrefix      = "game:state:"
+	FullGameStateKeyPrefix  = "game:full:"
+	GameLockKeyPrefix       = "game:lock:"
+	IdempotencyKeyPrefix    = "idempotency:"
+	ChatKeyPrefix           = "room:chat:"
 	LeaderboardKey          = "leaderboard:global"
+	LeaderboardRankingKeyPrefix = "leaderboard:ranking:"
 	WSConnectionKeyPrefix   = "ws:user:"
 	MatchmakingQueueKey     = "queue:matchmaking"
 )
@@ -72,10 +111,13 @@ const (
 // Default TTL values
 const (
 	DefaultUserSessionTTL = 24 * time.Hour
+	DefaultUserProfileTTL = 10 * time.Minute
 	DefaultRoomStateTTL   = 30 * time.Minute
 	DefaultGameStateTTL   = 2 * time.Hour
 	DefaultLeaderboardTTL = 5 * time.Minute
 	DefaultWSConnectionTTL = 1 * time.Hour
+	DefaultIdempotencyTTL  = 24 * time.Hour
+	DefaultChatTTL         = 24 * time.Hour
 )
 
 // User session operations
@@ -94,6 +136,22 @@ func (c *redisCache) DeleteUserSession(ctx context.Context, userID string) error
 	return c.Delete(ctx, key)
 }
 
+// User profile operations
+func (c *redisCache) SetUserProfile(ctx context.Context, userID string, profile interface{}, ttl time.Duration) error {
+	key := UserProfileKeyPrefix + userID
+	return c.Set(ctx, key, profile, ttl)
+}
+
+func (c *redisCache) GetUserProfile(ctx context.Context, userID string) (string, error) {
+	key := UserProfileKeyPrefix + userID
+	return c.Get(ctx, key)
+}
+
+func (c *redisCache) DeleteUserProfile(ctx context.Context, userID string) error {
+	key := UserProfileKeyPrefix + userID
+	return c.Delete(ctx, key)
+}
+
 // Room state operations
 func (c *redisCache) SetRoomState(ctx context.Context, roomID string, roomState interface{}, ttl time.Duration) error {
 	key := RoomStateKeyPrefix + roomID
@@ -126,6 +184,122 @@ func (c *redisCache) DeleteGameState(ctx context.Context, gameID string) error {
 	return c.Delete(ctx, key)
 }
 
+// setFullGameStateScript replaces the stored game state only when its version
+// matches the one the caller read. A missing state counts as version 0.
+var setFullGameStateScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'version')
+if (current or '0') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'version', ARGV[2], 'data', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// Full game state operations
+func (c *redisCache) SetFullGameState(ctx context.Context, gameID string, gameState interface{}, expectedVersion int, ttl time.Duration) error {
+	key := FullGameStateKeyPrefix + gameID
+	data, err := json.Marshal(gameState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game state: %w", err)
+	}
+
+	written, err := setFullGameStateScript.Run(ctx, c.client, []string{key},
+		expectedVersion, expectedVersion+1, data, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if written == 0 {
+		return ErrStaleGameState
+	}
+	return nil
+}
+
+func (c *redisCache) GetFullGameState(ctx context.Context, gameID string) (string, error) {
+	key := FullGameStateKeyPrefix + gameID
+	result, err := c.client.HGet(ctx, key, "data").Result()
+	if err == redis.Nil {
+		return "", fmt.Errorf("%w: %s", ErrCacheMiss, key)
+	}
+	return result, err
+}
+
+// gameLockRetryInterval is how long AcquireGameLock waits between attempts
+const gameLockRetryInterval = 10 * time.Millisecond
+
+// releaseLockScript deletes a lock only if it still holds the caller's token,
+// so a caller whose lock expired cannot release a lock someone else now holds
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Game lock operations
+func (c *redisCache) AcquireGameLock(ctx context.Context, gameID string, ttl time.Duration) (func(), error) {
+	key := GameLockKeyPrefix + gameID
+	token := uuid.New().String()
+
+	for {
+		acquired, err := c.client.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s", ErrLockNotAcquired, key)
+		case <-time.After(gameLockRetryInterval):
+		}
+	}
+
+	release := func() {
+		// Release even if the caller's context has been cancelled
+		releaseLockScript.Run(context.Background(), c.client, []string{key}, token)
+	}
+	return release, nil
+}
+
+// Idempotency operations
+func (c *redisCache) SetIdempotentResult(ctx context.Context, key string, result interface{}, ttl time.Duration) error {
+	return c.Set(ctx, IdempotencyKeyPrefix+key, result, ttl)
+}
+
+func (c *redisCache) GetIdempotentResult(ctx context.Context, key string) (string, error) {
+	return c.Get(ctx, IdempotencyKeyPrefix+key)
+}
+
+// Chat operations
+func (c *redisCache) AddChatMessage(ctx context.Context, roomID string, message interface{}, maxMessages int) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	key := ChatKeyPrefix + roomID
+	pipe := c.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.LTrim(ctx, key, int64(-maxMessages), -1)
+	pipe.Expire(ctx, key, DefaultChatTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetChatMessages returns up to limit messages in the order they were sent,
+// skipping the offset most recent ones
+func (c *redisCache) GetChatMessages(ctx context.Context, roomID string, offset, limit int) ([]string, error) {
+	if limit <= 0 {
+		return []string{}, nil
+	}
+	start := -int64(offset + limit)
+	stop := -int64(offset + 1)
+	return c.client.LRange(ctx, ChatKeyPrefix+roomID, start, stop).Result()
+}
+
 // Leaderboard operations
 func (c *redisCache) SetLeaderboard(ctx context.Context, leaderboardData interface{}, ttl time.Duration) error {
 	return c.Set(ctx, LeaderboardKey, leaderboardData, ttl)
@@ -205,7 +379,7 @@ func (c *redisCache) Set(ctx context.Context, key string, value interface{}, ttl
 func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
 	result, err := c.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("%w: %s", ErrCacheMiss, key)
 	}
 	return result, err
 }
@@ -266,6 +440,7 @@ type LeaderboardEntry struct {
 	GamesWon    int     `json:"games_won"`
 	GamesPlayed int     `json:"games_played"`
 	WinRate     float64 `json:"win_rate"`
+	Rating      int     `json:"rating"`
 }
 
 type CachedMatchmakingUser struct {
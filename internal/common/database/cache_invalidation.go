@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
+
+	"chinese-bridge-game/pkg/logging"
 )
 
 // CacheInvalidationStrategy defines cache invalidation policies
@@ -30,13 +32,16 @@ type CacheInvalidationStrategy interface {
 
 // cacheInvalidationManager implements cache invalidation strategies
 type cacheInvalidationManager struct {
-	cache Cache
+	cache  Cache
+	logger *slog.Logger
 }
 
-// NewCacheInvalidationStrategy creates a new cache invalidation manager
-func NewCacheInvalidationStrategy(cache Cache) CacheInvalidationStrategy {
+// NewCacheInvalidationStrategy creates a new cache invalidation manager. A nil
+// logger uses the default logger.
+func NewCacheInvalidationStrategy(cache Cache, logger *slog.Logger) CacheInvalidationStrategy {
 	return &cacheInvalidationManager{
-		cache: cache,
+		cache:  cache,
+		logger: logging.OrDefault(logger),
 	}
 }
 
@@ -49,6 +54,11 @@ func (c *cacheInvalidationManager) InvalidateUserData(ctx context.Context, userI
 		errors = append(errors, fmt.Errorf("failed to invalidate user session: %w", err))
 	}
 
+	// Invalidate user profile
+	if err := c.cache.DeleteUserProfile(ctx, userID); err != nil {
+		errors = append(errors, fmt.Errorf("failed to invalidate user profile: %w", err))
+	}
+
 	// Invalidate WebSocket connection
 	if err := c.cache.DeleteWSConnection(ctx, userID); err != nil {
 		errors = append(errors, fmt.Errorf("failed to invalidate WS connection: %w", err))
@@ -68,7 +78,7 @@ func (c *cacheInvalidationManager) InvalidateUserData(ctx context.Context, userI
 		return fmt.Errorf("multiple invalidation errors: %v", errors)
 	}
 
-	log.Printf("Invalidated cache data for user: %s", userID)
+	c.logger.Debug("Invalidated cache data for user", "user_id", userID)
 	return nil
 }
 
@@ -85,7 +95,7 @@ func (c *cacheInvalidationManager) InvalidateRoomData(ctx context.Context, roomI
 		return fmt.Errorf("multiple invalidation errors: %v", errors)
 	}
 
-	log.Printf("Invalidated cache data for room: %s", roomID)
+	c.logger.Debug("Invalidated cache data for room", "room_id", roomID)
 	return nil
 }
 
@@ -107,7 +117,7 @@ func (c *cacheInvalidationManager) InvalidateGameData(ctx context.Context, gameI
 		return fmt.Errorf("multiple invalidation errors: %v", errors)
 	}
 
-	log.Printf("Invalidated cache data for game: %s", gameID)
+	c.logger.Debug("Invalidated cache data for game", "game_id", gameID)
 	return nil
 }
 
@@ -117,14 +127,14 @@ func (c *cacheInvalidationManager) InvalidateLeaderboard(ctx context.Context) er
 		return fmt.Errorf("failed to invalidate leaderboard: %w", err)
 	}
 
-	log.Println("Invalidated leaderboard cache")
+	c.logger.Debug("Invalidated leaderboard cache")
 	return nil
 }
 
 // InvalidateExpiredEntries removes expired cache entries
 // Note: Redis automatically handles TTL expiration, but this can be used for manual cleanup
 func (c *cacheInvalidationManager) InvalidateExpiredEntries(ctx context.Context) error {
-	log.Println("Expired entries cleanup completed (Redis handles TTL automatically)")
+	c.logger.Debug("Expired entries cleanup completed (Redis handles TTL automatically)")
 	return nil
 }
 
@@ -136,17 +146,17 @@ func (c *cacheInvalidationManager) SchedulePeriodicCleanup(ctx context.Context,
 		for {
 			select {
 			case <-ctx.Done():
-				log.Println("Cache cleanup scheduler stopped")
+				c.logger.Info("Cache cleanup scheduler stopped")
 				return
 			case <-ticker.C:
 				if err := c.InvalidateExpiredEntries(ctx); err != nil {
-					log.Printf("Error during periodic cache cleanup: %v", err)
+					c.logger.Error("Error during periodic cache cleanup", "error", err)
 				}
 			}
 		}
 	}()
 
-	log.Printf("Started periodic cache cleanup with interval: %v", interval)
+	c.logger.Info("Started periodic cache cleanup", "interval", interval)
 }
 
 // CacheWarmupStrategy defines cache warming policies
@@ -154,24 +164,27 @@ type CacheWarmupStrategy interface {
 	// WarmupUserData preloads frequently accessed user data
 	WarmupUserData(ctx context.Context, userID string) error
 
-	// WarmupLeaderboard preloads leaderboard data
-	WarmupLeaderboard(ctx context.Context) error
+	// WarmupLeaderboard preloads leaderboard data and returns the number of entries cached
+	WarmupLeaderboard(ctx context.Context) (int, error)
 
-	// WarmupActiveRooms preloads active room data
-	WarmupActiveRooms(ctx context.Context) error
+	// WarmupActiveRooms preloads active room data and returns the number of rooms cached
+	WarmupActiveRooms(ctx context.Context) (int, error)
 }
 
 // cacheWarmupManager implements cache warming strategies
 type cacheWarmupManager struct {
 	cache      Cache
 	repository Repository
+	logger     *slog.Logger
 }
 
-// NewCacheWarmupStrategy creates a new cache warmup manager
-func NewCacheWarmupStrategy(cache Cache, repository Repository) CacheWarmupStrategy {
+// NewCacheWarmupStrategy creates a new cache warmup manager. A nil logger uses
+// the default logger.
+func NewCacheWarmupStrategy(cache Cache, repository Repository, logger *slog.Logger) CacheWarmupStrategy {
 	return &cacheWarmupManager{
 		cache:      cache,
 		repository: repository,
+		logger:     logging.OrDefault(logger),
 	}
 }
 
@@ -193,63 +206,40 @@ func (c *cacheWarmupManager) WarmupUserData(ctx context.Context, userID string)
 		return fmt.Errorf("failed to cache user session: %w", err)
 	}
 
-	log.Printf("Warmed up cache for user: %s", userID)
+	c.logger.Debug("Warmed up cache for user", "user_id", userID)
 	return nil
 }
 
 // WarmupLeaderboard preloads leaderboard data from database
-func (c *cacheWarmupManager) WarmupLeaderboard(ctx context.Context) error {
-	// Get leaderboard from database
-	stats, err := c.repository.GetLeaderboard(ctx, 100) // Top 100 players
+func (c *cacheWarmupManager) WarmupLeaderboard(ctx context.Context) (int, error) {
+	leaderboard, err := buildLeaderboard(ctx, c.repository)
 	if err != nil {
-		return fmt.Errorf("failed to get leaderboard from database: %w", err)
-	}
-
-	// Convert to cached format
-	var entries []LeaderboardEntry
-	for _, stat := range stats {
-		winRate := 0.0
-		if stat.GamesPlayed > 0 {
-			winRate = float64(stat.GamesWon) / float64(stat.GamesPlayed)
-		}
-
-		entries = append(entries, LeaderboardEntry{
-			UserID:      stat.UserID,
-			Name:        stat.User.Name,
-			Avatar:      stat.User.Avatar,
-			GamesWon:    stat.GamesWon,
-			GamesPlayed: stat.GamesPlayed,
-			WinRate:     winRate,
-		})
-	}
-
-	leaderboard := CachedLeaderboard{
-		Players:   entries,
-		UpdatedAt: time.Now(),
+		return 0, err
 	}
 
-	if err := c.cache.SetLeaderboard(ctx, leaderboard, DefaultLeaderboardTTL); err != nil {
-		return fmt.Errorf("failed to cache leaderboard: %w", err)
+	if err := c.cache.SetLeaderboard(ctx, *leaderboard, DefaultLeaderboardTTL); err != nil {
+		return 0, fmt.Errorf("failed to cache leaderboard: %w", err)
 	}
 
-	log.Printf("Warmed up leaderboard cache with %d entries", len(entries))
-	return nil
+	c.logger.Info("Warmed up leaderboard cache", "entries", len(leaderboard.Players))
+	return len(leaderboard.Players), nil
 }
 
 // WarmupActiveRooms preloads active room data
-func (c *cacheWarmupManager) WarmupActiveRooms(ctx context.Context) error {
+func (c *cacheWarmupManager) WarmupActiveRooms(ctx context.Context) (int, error) {
 	// Get active rooms from database
 	rooms, err := c.repository.GetRoomsByStatus(ctx, "waiting", 50, 0)
 	if err != nil {
-		return fmt.Errorf("failed to get active rooms from database: %w", err)
+		return 0, fmt.Errorf("failed to get active rooms from database: %w", err)
 	}
 
 	// Cache each room
+	cached := 0
 	for _, room := range rooms {
 		// Get participants
 		participants, err := c.repository.GetRoomParticipants(ctx, room.ID)
 		if err != nil {
-			log.Printf("Warning: failed to get participants for room %s: %v", room.ID, err)
+			c.logger.Warn("Failed to get participants for room", "room_id", room.ID, "error", err)
 			continue
 		}
 
@@ -270,11 +260,12 @@ func (c *cacheWarmupManager) WarmupActiveRooms(ctx context.Context) error {
 		}
 
 		if err := c.cache.SetRoomState(ctx, room.ID, roomState, DefaultRoomStateTTL); err != nil {
-			log.Printf("Warning: failed to cache room %s: %v", room.ID, err)
+			c.logger.Warn("Failed to cache room", "room_id", room.ID, "error", err)
 			continue
 		}
+		cached++
 	}
 
-	log.Printf("Warmed up cache for %d active rooms", len(rooms))
-	return nil
+	c.logger.Info("Warmed up cache for active rooms", "rooms", cached)
+	return cached, nil
 }
\ No newline at end of file
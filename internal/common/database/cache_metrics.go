@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_hits_total",
+		Help: "Total number of cache reads that found the key, by key category.",
+	}, []string{"category"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_misses_total",
+		Help: "Total number of cache reads that did not find the key, by key category.",
+	}, []string{"category"})
+)
+
+// Cache key categories used as metric labels
+const (
+	cacheCategoryUser        = "user"
+	cacheCategoryRoom        = "room"
+	cacheCategoryGame        = "game"
+	cacheCategoryLeaderboard = "leaderboard"
+	cacheCategoryOther       = "other"
+)
+
+// cacheCategoryPrefixes maps key prefixes to their category
+var cacheCategoryPrefixes = []struct {
+	prefix   string
+	category string
+}{
+	{UserSessionKeyPrefix, cacheCategoryUser},
+	{UserProfileKeyPrefix, cacheCategoryUser},
+	{WSConnectionKeyPrefix, cacheCategoryUser},
+	{RoomStateKeyPrefix, cacheCategoryRoom},
+	{ChatKeyPrefix, cacheCategoryRoom},
+	{GameStateKeyPrefix, cacheCategoryGame},
+	{FullGameStateKeyPrefix, cacheCategoryGame},
+	{GameLockKeyPrefix, cacheCategoryGame},
+	{LeaderboardKey, cacheCategoryLeaderboard},
+	{LeaderboardRankingKeyPrefix, cacheCategoryLeaderboard},
+}
+
+// cacheCategory returns the category of key, or "other" for keys such as
+// idempotency results that belong to none
+func cacheCategory(key string) string {
+	for _, p := range cacheCategoryPrefixes {
+		if strings.HasPrefix(key, p.prefix) {
+			return p.category
+		}
+	}
+	return cacheCategoryOther
+}
+
+// instrumentedCache counts hits and misses of the reads made through the
+// Cache it wraps. Writes and other operations are passed through unchanged.
+type instrumentedCache struct {
+	Cache
+}
+
+// NewInstrumentedCache wraps cache so that its reads are counted in the
+// cache_hits_total and cache_misses_total metrics
+func NewInstrumentedCache(cache Cache) Cache {
+	return &instrumentedCache{Cache: cache}
+}
+
+// observe counts a read of key by its result. Errors other than a miss, such
+// as a lost connection, are neither a hit nor a miss.
+func observe(key string, result string, err error) (string, error) {
+	switch {
+	case err == nil:
+		cacheHits.WithLabelValues(cacheCategory(key)).Inc()
+	case errors.Is(err, ErrCacheMiss):
+		cacheMisses.WithLabelValues(cacheCategory(key)).Inc()
+	}
+	return result, err
+}
+
+func (c *instrumentedCache) GetUserSession(ctx context.Context, userID string) (string, error) {
+	result, err := c.Cache.GetUserSession(ctx, userID)
+	return observe(UserSessionKeyPrefix+userID, result, err)
+}
+
+func (c *instrumentedCache) GetUserProfile(ctx context.Context, userID string) (string, error) {
+	result, err := c.Cache.GetUserProfile(ctx, userID)
+	return observe(UserProfileKeyPrefix+userID, result, err)
+}
+
+func (c *instrumentedCache) GetRoomState(ctx context.Context, roomID string) (string, error) {
+	result, err := c.Cache.GetRoomState(ctx, roomID)
+	return observe(RoomStateKeyPrefix+roomID, result, err)
+}
+
+func (c *instrumentedCache) GetGameState(ctx context.Context, gameID string) (string, error) {
+	result, err := c.Cache.GetGameState(ctx, gameID)
+	return observe(GameStateKeyPrefix+gameID, result, err)
+}
+
+func (c *instrumentedCache) GetFullGameState(ctx context.Context, gameID string) (string, error) {
+	result, err := c.Cache.GetFullGameState(ctx, gameID)
+	return observe(FullGameStateKeyPrefix+gameID, result, err)
+}
+
+func (c *instrumentedCache) GetIdempotentResult(ctx context.Context, key string) (string, error) {
+	result, err := c.Cache.GetIdempotentResult(ctx, key)
+	return observe(IdempotencyKeyPrefix+key, result, err)
+}
+
+func (c *instrumentedCache) GetLeaderboard(ctx context.Context) (string, error) {
+	result, err := c.Cache.GetLeaderboard(ctx)
+	return observe(LeaderboardKey, result, err)
+}
+
+func (c *instrumentedCache) GetWSConnection(ctx context.Context, userID string) (string, error) {
+	result, err := c.Cache.GetWSConnection(ctx, userID)
+	return observe(WSConnectionKeyPrefix+userID, result, err)
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string) (string, error) {
+	result, err := c.Cache.Get(ctx, key)
+	return observe(key, result, err)
+}
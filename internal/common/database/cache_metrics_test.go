@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatheredCount reads a counter with the given category label from the
+// default registry, as served on the metrics endpoint
+func gatheredCount(t *testing.T, name, category string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "category" && label.GetValue() == category {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestInstrumentedCache_CountsMissThenHit(t *testing.T) {
+	cache := NewInstrumentedCache(NewInMemoryCache())
+	ctx := context.Background()
+
+	hits := gatheredCount(t, "cache_hits_total", cacheCategoryUser)
+	misses := gatheredCount(t, "cache_misses_total", cacheCategoryUser)
+
+	_, err := cache.GetUserProfile(ctx, "metrics-user")
+	assert.ErrorIs(t, err, ErrCacheMiss)
+	assert.Equal(t, misses+1, gatheredCount(t, "cache_misses_total", cacheCategoryUser))
+	assert.Equal(t, hits, gatheredCount(t, "cache_hits_total", cacheCategoryUser))
+
+	require.NoError(t, cache.SetUserProfile(ctx, "metrics-user", map[string]string{"name": "Alice"}, DefaultUserProfileTTL))
+	_, err = cache.GetUserProfile(ctx, "metrics-user")
+	assert.NoError(t, err)
+	assert.Equal(t, misses+1, gatheredCount(t, "cache_misses_total", cacheCategoryUser))
+	assert.Equal(t, hits+1, gatheredCount(t, "cache_hits_total", cacheCategoryUser))
+}
+
+func TestInstrumentedCache_CategorisesGenericKeys(t *testing.T) {
+	cache := NewInstrumentedCache(NewInMemoryCache())
+	ctx := context.Background()
+
+	before := testutil.ToFloat64(cacheMisses.WithLabelValues(cacheCategoryRoom))
+	_, err := cache.Get(ctx, RoomStateKeyPrefix+"missing-room")
+	assert.ErrorIs(t, err, ErrCacheMiss)
+	assert.Equal(t, before+1, testutil.ToFloat64(cacheMisses.WithLabelValues(cacheCategoryRoom)))
+
+	assert.Equal(t, cacheCategoryLeaderboard, cacheCategory(LeaderboardKey))
+	assert.Equal(t, cacheCategoryGame, cacheCategory(FullGameStateKeyPrefix+"g1"))
+	assert.Equal(t, cacheCategoryOther, cacheCategory(IdempotencyKeyPrefix+"k1"))
+}
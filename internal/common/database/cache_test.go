@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -153,6 +154,110 @@ func TestRedisCache_GameState(t *testing.T) {
 	})
 }
 
+func TestRedisCache_FullGameState(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	cache := NewRedisCache(client)
+	ctx := context.Background()
+
+	gameID := "test-game-456"
+
+	t.Run("SetFullGameState", func(t *testing.T) {
+		err := cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 1, "phase": "bidding"}, 0, DefaultGameStateTTL)
+		assert.NoError(t, err)
+
+		err = cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 2, "phase": "playing"}, 1, DefaultGameStateTTL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RejectsStaleVersion", func(t *testing.T) {
+		err := cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 2, "phase": "ended"}, 1, DefaultGameStateTTL)
+		assert.ErrorIs(t, err, ErrStaleGameState)
+	})
+
+	t.Run("GetFullGameState", func(t *testing.T) {
+		result, err := cache.GetFullGameState(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "playing")
+	})
+
+	t.Run("MissingGameState", func(t *testing.T) {
+		_, err := cache.GetFullGameState(ctx, "missing-game")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
+func TestRedisCache_GameLock(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	cache := NewRedisCache(client)
+	ctx := context.Background()
+
+	t.Run("MutualExclusion", func(t *testing.T) {
+		var mu sync.Mutex
+		holders, maxHolders := 0, 0
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := cache.AcquireGameLock(ctx, "lock-game", time.Second)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				holders++
+				if holders > maxHolders {
+					maxHolders = holders
+				}
+				mu.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+				release()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, maxHolders)
+	})
+
+	t.Run("HeldLockTimesOut", func(t *testing.T) {
+		release, err := cache.AcquireGameLock(ctx, "held-game", time.Second)
+		assert.NoError(t, err)
+		defer release()
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = cache.AcquireGameLock(waitCtx, "held-game", time.Second)
+		assert.ErrorIs(t, err, ErrLockNotAcquired)
+	})
+
+	t.Run("ReleaseOnlyDeletesOwnLock", func(t *testing.T) {
+		// The first holder's lock expires and a second caller takes over
+		releaseExpired, err := cache.AcquireGameLock(ctx, "expiring-game", 50*time.Millisecond)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		releaseCurrent, err := cache.AcquireGameLock(ctx, "expiring-game", time.Second)
+		assert.NoError(t, err)
+		defer releaseCurrent()
+
+		releaseExpired()
+
+		exists, err := cache.Exists(ctx, GameLockKeyPrefix+"expiring-game")
+		assert.NoError(t, err)
+		assert.True(t, exists, "expired holder must not release the new holder's lock")
+	})
+}
+
 func TestRedisCache_Leaderboard(t *testing.T) {
 	client := setupTestRedis(t)
 	defer client.Close()
@@ -380,4 +485,33 @@ func TestRedisCache_TTLExpiration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.False(t, exists)
 	})
-}
\ No newline at end of file
+}
+func TestRedisCache_Chat(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	cache := NewRedisCache(client)
+	ctx := context.Background()
+	roomID := "test-room-chat"
+
+	for i := 1; i <= 5; i++ {
+		err := cache.AddChatMessage(ctx, roomID, map[string]int{"n": i}, 3)
+		assert.NoError(t, err)
+	}
+
+	t.Run("KeepsOnlyRecentMessagesInOrder", func(t *testing.T) {
+		messages, err := cache.GetChatMessages(ctx, roomID, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`{"n":3}`, `{"n":4}`, `{"n":5}`}, messages)
+	})
+
+	t.Run("Paginates", func(t *testing.T) {
+		messages, err := cache.GetChatMessages(ctx, roomID, 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`{"n":4}`}, messages)
+
+		messages, err = cache.GetChatMessages(ctx, roomID, 5, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+}
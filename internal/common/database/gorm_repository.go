@@ -2,8 +2,11 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"chinese-bridge-game/internal/game/domain"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -18,6 +21,13 @@ func NewGormRepository(db *gorm.DB) Repository {
 	return &gormRepository{db: db}
 }
 
+// WithTransaction runs fn against a repository bound to a single transaction
+func (r *gormRepository) WithTransaction(ctx context.Context, fn func(tx Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&gormRepository{db: tx})
+	})
+}
+
 // User operations
 func (r *gormRepository) CreateUser(ctx context.Context, user *User) error {
 	if user.ID == "" {
@@ -59,14 +69,81 @@ func (r *gormRepository) GetUserByEmail(ctx context.Context, email string) (*Use
 	return &user, nil
 }
 
+// GetUsersByIDs loads the users with the given IDs in a single query. IDs that
+// do not match a user are skipped.
+func (r *gormRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]User, error) {
+	var users []User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Find(&users).Error
+	return users, err
+}
+
 func (r *gormRepository) UpdateUser(ctx context.Context, user *User) error {
 	return r.db.WithContext(ctx).Save(user).Error
 }
 
+// editableUserFields are the user columns UpdateUserFields may change
+var editableUserFields = map[string]bool{
+	"name":   true,
+	"avatar": true,
+}
+
+// UpdateUserFields changes only the given columns of a user, leaving the rest
+// untouched, and bumps updated_at. Only the name and avatar are editable.
+func (r *gormRepository) UpdateUserFields(ctx context.Context, id string, fields map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(fields)+1)
+	for column, value := range fields {
+		if !editableUserFields[column] {
+			return fmt.Errorf("%w: %s", ErrFieldNotEditable, column)
+		}
+		updates[column] = value
+	}
+	updates["updated_at"] = time.Now()
+
+	result := r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteUser soft-deletes a user, leaving the rows that reference them in place
 func (r *gormRepository) DeleteUser(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Delete(&User{}, "id = ?", id).Error
 }
 
+// AnonymizeUser scrubs a user's personal data, purges their sessions and
+// soft-deletes them. Game participation and stats are kept so that historical
+// results stay consistent for the other players.
+func (r *gormRepository) AnonymizeUser(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"google_id": "deleted:" + id,
+			"email":     "deleted+" + id + "@anonymized.invalid",
+			"name":      "Deleted User",
+			"avatar":    "",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Delete(&Session{}, "user_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&User{}, "id = ?", id).Error
+	})
+}
+
 // Room operations
 func (r *gormRepository) CreateRoom(ctx context.Context, room *Room) error {
 	if room.ID == "" {
@@ -101,10 +178,51 @@ func (r *gormRepository) GetRoomsByStatus(ctx context.Context, status string, li
 	return rooms, err
 }
 
+// GetIdleRooms returns rooms in the given status that have not been updated since idleSince
+func (r *gormRepository) GetIdleRooms(ctx context.Context, status string, idleSince time.Time, limit int) ([]Room, error) {
+	var rooms []Room
+	err := r.db.WithContext(ctx).
+		Preload("Participants").
+		Where("status = ? AND updated_at < ?", status, idleSince).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&rooms).Error
+	return rooms, err
+}
+
 func (r *gormRepository) UpdateRoom(ctx context.Context, room *Room) error {
 	return r.db.WithContext(ctx).Save(room).Error
 }
 
+// UpdateRoomStatus changes only a room's status, leaving its associations untouched
+func (r *gormRepository) UpdateRoomStatus(ctx context.Context, id, status string) error {
+	return r.db.WithContext(ctx).
+		Model(&Room{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
+
+// TransitionRoomStatus moves a room from one status to another, reporting
+// whether this call moved it. It leaves a room in any other status untouched.
+func (r *gormRepository) TransitionRoomStatus(ctx context.Context, id, from, to string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Room{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateRoomPlayerCount changes only a room's count of seated players
+func (r *gormRepository) UpdateRoomPlayerCount(ctx context.Context, id string, count int) error {
+	return r.db.WithContext(ctx).
+		Model(&Room{}).
+		Where("id = ?", id).
+		Update("current_players", count).Error
+}
+
 func (r *gormRepository) DeleteRoom(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Delete(&Room{}, "id = ?", id).Error
 }
@@ -169,6 +287,19 @@ func (r *gormRepository) UpdateGame(ctx context.Context, game *Game) error {
 	return r.db.WithContext(ctx).Save(game).Error
 }
 
+// LinkRematchRoom records roomID as the game's rematch room unless one is
+// already linked, reporting whether this call linked it
+func (r *gormRepository) LinkRematchRoom(ctx context.Context, gameID, roomID string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Game{}).
+		Where("id = ? AND rematch_room_id IS NULL", gameID).
+		Update("rematch_room_id", roomID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *gormRepository) DeleteGame(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Delete(&Game{}, "id = ?", id).Error
 }
@@ -193,6 +324,10 @@ func (r *gormRepository) AddGameParticipant(ctx context.Context, participant *Ga
 	return r.db.WithContext(ctx).Create(participant).Error
 }
 
+func (r *gormRepository) UpdateGameParticipant(ctx context.Context, participant *GameParticipant) error {
+	return r.db.WithContext(ctx).Save(participant).Error
+}
+
 func (r *gormRepository) GetGameParticipants(ctx context.Context, gameID string) ([]GameParticipant, error) {
 	var participants []GameParticipant
 	err := r.db.WithContext(ctx).
@@ -238,9 +373,11 @@ func (r *gormRepository) DeleteSession(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Delete(&Session{}, "id = ?", id).Error
 }
 
-func (r *gormRepository) DeleteExpiredSessions(ctx context.Context) error {
-	return r.db.WithContext(ctx).
-		Delete(&Session{}, "expires_at <= ?", time.Now()).Error
+// DeleteExpiredSessions removes every session past its expiry and returns how many were deleted
+func (r *gormRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Delete(&Session{}, "expires_at <= ?", time.Now())
+	return result.RowsAffected, result.Error
 }
 
 // Statistics operations
@@ -291,4 +428,117 @@ func (r *gormRepository) GetTopPlayersByDeclarerWins(ctx context.Context, limit
 		Limit(limit).
 		Find(&stats).Error
 	return stats, err
-}
\ No newline at end of file
+}
+
+func (r *gormRepository) GetTopPlayersByPoints(ctx context.Context, limit int) ([]UserStats, error) {
+	var stats []UserStats
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Order("total_points DESC").
+		Limit(limit).
+		Find(&stats).Error
+	return stats, err
+}
+
+func (r *gormRepository) GetPlayerRating(ctx context.Context, userID string) (int, error) {
+	var stats UserStats
+	err := r.db.WithContext(ctx).
+		Select("rating").
+		First(&stats, "user_id = ?", userID).Error
+	if err != nil {
+		return 0, err
+	}
+	return stats.Rating, nil
+}
+
+// GetHeadToHead tallies the games two players have played together. Teams are
+// taken from the players' recorded roles, as partners need not sit opposite
+// each other when the partner is called by card. Games whose roles are not yet
+// recorded count towards neither team tally.
+func (r *gormRepository) GetHeadToHead(ctx context.Context, userA, userB string) (HeadToHead, error) {
+	var rows []struct {
+		RoleA      string
+		RoleB      string
+		WinnerTeam *string
+	}
+	err := r.db.WithContext(ctx).
+		Table("game_participants AS a").
+		Select("a.role AS role_a, b.role AS role_b, games.winner_team").
+		Joins("JOIN game_participants AS b ON b.game_id = a.game_id").
+		Joins("JOIN games ON games.id = a.game_id").
+		Where("a.user_id = ? AND b.user_id = ?", userA, userB).
+		Scan(&rows).Error
+	if err != nil {
+		return HeadToHead{}, err
+	}
+
+	record := HeadToHead{UserA: userA, UserB: userB}
+	for _, row := range rows {
+		record.Games++
+		if !isTeamRole(row.RoleA) || !isTeamRole(row.RoleB) {
+			continue
+		}
+		aOnDeclarerTeam := isDeclarerTeamRole(row.RoleA)
+		if aOnDeclarerTeam == isDeclarerTeamRole(row.RoleB) {
+			record.SameTeam++
+			continue
+		}
+
+		record.Opposing++
+		if row.WinnerTeam == nil {
+			continue
+		}
+		if aOnDeclarerTeam == (*row.WinnerTeam == domain.TeamDeclarer) {
+			record.UserAWins++
+		} else {
+			record.UserBWins++
+		}
+	}
+	return record, nil
+}
+
+// isTeamRole checks if a participant's role places them on a team
+func isTeamRole(role string) bool {
+	return role == domain.RoleDeclarer || role == domain.RolePartner || role == domain.RoleDefender
+}
+
+// isDeclarerTeamRole checks if a participant's role places them on the declarer's team
+func isDeclarerTeamRole(role string) bool {
+	return role == domain.RoleDeclarer || role == domain.RolePartner
+}
+
+// GetUserGameAggregates sums the player's participations in finished games
+func (r *gormRepository) GetUserGameAggregates(ctx context.Context, userID string) (GameAggregates, error) {
+	var row struct {
+		GamesCompleted        int
+		GamesWon              int
+		GamesAsDeclarer       int
+		GamesAsPartner        int
+		TotalPointsCaptured   int
+		AveragePointsCaptured float64
+	}
+	err := r.db.WithContext(ctx).
+		Table("game_participants").
+		Select(`COUNT(*) AS games_completed,
+			COALESCE(SUM(CASE WHEN (game_participants.role IN ('declarer', 'partner')) = (games.winner_team = 'declarer') THEN 1 ELSE 0 END), 0) AS games_won,
+			COALESCE(SUM(CASE WHEN game_participants.role = 'declarer' THEN 1 ELSE 0 END), 0) AS games_as_declarer,
+			COALESCE(SUM(CASE WHEN game_participants.role = 'partner' THEN 1 ELSE 0 END), 0) AS games_as_partner,
+			COALESCE(SUM(game_participants.points_captured), 0) AS total_points_captured,
+			COALESCE(AVG(game_participants.points_captured), 0) AS average_points_captured`).
+		Joins("JOIN games ON games.id = game_participants.game_id").
+		Where("game_participants.user_id = ? AND games.ended_at IS NOT NULL AND games.aborted = ? AND games.winner_team IS NOT NULL", userID, false).
+		Scan(&row).Error
+	if err != nil {
+		return GameAggregates{}, err
+	}
+
+	return GameAggregates{
+		UserID:                userID,
+		GamesCompleted:        row.GamesCompleted,
+		GamesWon:              row.GamesWon,
+		GamesAsDeclarer:       row.GamesAsDeclarer,
+		GamesAsPartner:        row.GamesAsPartner,
+		TotalPointsCaptured:   row.TotalPointsCaptured,
+		AveragePointsCaptured: row.AveragePointsCaptured,
+	}, nil
+}
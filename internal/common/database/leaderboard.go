@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chinese-bridge-game/pkg/logging"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// LeaderboardSize is the number of top players kept on the leaderboard
+const LeaderboardSize = 100
+
+// RankingLoader reads up to limit players' statistics in ranked order from the database
+type RankingLoader func(ctx context.Context, limit int) ([]UserStats, error)
+
+// LeaderboardReader serves the leaderboard
+type LeaderboardReader interface {
+	// GetLeaderboard returns the cached leaderboard, rebuilding it from the
+	// database when the cache has expired
+	GetLeaderboard(ctx context.Context) (*CachedLeaderboard, error)
+	// GetRanking returns up to limit players' statistics ranked by criteria,
+	// cached separately for each criteria and limit. load reads the ranking
+	// from the database when the cache has expired.
+	GetRanking(ctx context.Context, criteria string, limit int, load RankingLoader) ([]UserStats, error)
+}
+
+// RankingKey returns the cache key of the ranking by criteria of the top limit players
+func RankingKey(criteria string, limit int) string {
+	return fmt.Sprintf("%s%s:%d", LeaderboardRankingKeyPrefix, criteria, limit)
+}
+
+// leaderboardReader reads the leaderboard through the cache. Concurrent
+// misses share a single database read so an expired entry cannot cause a
+// stampede of identical queries.
+type leaderboardReader struct {
+	cache      Cache
+	repository Repository
+	group      singleflight.Group
+	logger     *slog.Logger
+}
+
+// NewLeaderboardReader creates a leaderboard reader. A nil logger uses the
+// default logger.
+func NewLeaderboardReader(cache Cache, repository Repository, logger *slog.Logger) LeaderboardReader {
+	return &leaderboardReader{
+		cache:      cache,
+		repository: repository,
+		logger:     logging.OrDefault(logger),
+	}
+}
+
+// GetLeaderboard returns the cached leaderboard or rebuilds and caches it
+func (r *leaderboardReader) GetLeaderboard(ctx context.Context) (*CachedLeaderboard, error) {
+	if leaderboard, ok := r.cached(ctx); ok {
+		return leaderboard, nil
+	}
+
+	result, err, _ := r.group.Do(LeaderboardKey, func() (interface{}, error) {
+		// A flight that just finished may have repopulated the cache
+		if leaderboard, ok := r.cached(ctx); ok {
+			return leaderboard, nil
+		}
+
+		leaderboard, err := buildLeaderboard(ctx, r.repository)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.cache.SetLeaderboard(ctx, *leaderboard, DefaultLeaderboardTTL); err != nil {
+			r.logger.Warn("Failed to cache leaderboard", "error", err)
+		}
+		return leaderboard, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers sharing a flight each get their own copy
+	leaderboard := *result.(*CachedLeaderboard)
+	leaderboard.Players = append([]LeaderboardEntry(nil), leaderboard.Players...)
+	return &leaderboard, nil
+}
+
+// GetRanking returns the cached ranking or loads and caches it. Like the
+// leaderboard, concurrent misses of the same ranking share one query.
+func (r *leaderboardReader) GetRanking(ctx context.Context, criteria string, limit int, load RankingLoader) ([]UserStats, error) {
+	key := RankingKey(criteria, limit)
+	if ranking, ok := r.cachedRanking(ctx, key); ok {
+		return ranking, nil
+	}
+
+	result, err, _ := r.group.Do(key, func() (interface{}, error) {
+		if ranking, ok := r.cachedRanking(ctx, key); ok {
+			return ranking, nil
+		}
+
+		ranking, err := load(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.cache.Set(ctx, key, ranking, DefaultLeaderboardTTL); err != nil {
+			r.logger.Warn("Failed to cache ranking", "key", key, "error", err)
+		}
+		return ranking, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers sharing a flight each get their own copy
+	return append([]UserStats(nil), result.([]UserStats)...), nil
+}
+
+// cachedRanking returns the ranking stored under key if the cache holds a readable one
+func (r *leaderboardReader) cachedRanking(ctx context.Context, key string) ([]UserStats, bool) {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			r.logger.Warn("Failed to read cached ranking", "key", key, "error", err)
+		}
+		return nil, false
+	}
+
+	var ranking []UserStats
+	if err := json.Unmarshal([]byte(data), &ranking); err != nil {
+		r.logger.Warn("Failed to decode cached ranking", "key", key, "error", err)
+		return nil, false
+	}
+	return ranking, true
+}
+
+// cached returns the leaderboard from the cache if it holds a readable one
+func (r *leaderboardReader) cached(ctx context.Context) (*CachedLeaderboard, bool) {
+	data, err := r.cache.GetLeaderboard(ctx)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			r.logger.Warn("Failed to read cached leaderboard", "error", err)
+		}
+		return nil, false
+	}
+
+	var leaderboard CachedLeaderboard
+	if err := json.Unmarshal([]byte(data), &leaderboard); err != nil {
+		r.logger.Warn("Failed to decode cached leaderboard", "error", err)
+		return nil, false
+	}
+	return &leaderboard, true
+}
+
+// buildLeaderboard loads the top players' statistics from the database
+func buildLeaderboard(ctx context.Context, repository Repository) (*CachedLeaderboard, error) {
+	stats, err := repository.GetLeaderboard(ctx, LeaderboardSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard from database: %w", err)
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(stats))
+	for _, stat := range stats {
+		winRate := 0.0
+		if stat.GamesPlayed > 0 {
+			winRate = float64(stat.GamesWon) / float64(stat.GamesPlayed)
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			UserID:      stat.UserID,
+			Name:        stat.User.Name,
+			Avatar:      stat.User.Avatar,
+			GamesWon:    stat.GamesWon,
+			GamesPlayed: stat.GamesPlayed,
+			WinRate:     winRate,
+			Rating:      stat.Rating,
+		})
+	}
+
+	return &CachedLeaderboard{
+		Players:   entries,
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLeaderboardCache holds the leaderboard in memory. Other cache methods
+// are not used by the leaderboard reader and panic if called.
+type memoryLeaderboardCache struct {
+	Cache
+	mu   sync.Mutex
+	data string
+}
+
+func (c *memoryLeaderboardCache) SetLeaderboard(ctx context.Context, leaderboardData interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(leaderboardData)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = string(data)
+	return nil
+}
+
+func (c *memoryLeaderboardCache) GetLeaderboard(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data == "" {
+		return "", ErrCacheMiss
+	}
+	return c.data, nil
+}
+
+// slowLeaderboardRepository counts leaderboard queries, each of which blocks
+// until release is closed
+type slowLeaderboardRepository struct {
+	Repository
+	fetches int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *slowLeaderboardRepository) GetLeaderboard(ctx context.Context, limit int) ([]UserStats, error) {
+	if atomic.AddInt32(&r.fetches, 1) == 1 {
+		close(r.started)
+	}
+	<-r.release
+	return []UserStats{{UserID: "alice", GamesPlayed: 4, GamesWon: 3, Rating: 1600, User: User{Name: "Alice"}}}, nil
+}
+
+func TestLeaderboardReader_ConcurrentMissesShareOneQuery(t *testing.T) {
+	cache := &memoryLeaderboardCache{}
+	repo := &slowLeaderboardRepository{started: make(chan struct{}), release: make(chan struct{})}
+	reader := NewLeaderboardReader(cache, repo, nil)
+	ctx := context.Background()
+
+	const readers = 20
+	results := make([]*CachedLeaderboard, readers)
+	errs := make([]error, readers)
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = reader.GetLeaderboard(ctx)
+		}(i)
+	}
+
+	// Hold the first query open so the other readers miss the cache too
+	<-repo.started
+	time.Sleep(20 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&repo.fetches))
+	for i := 0; i < readers; i++ {
+		require.NoError(t, errs[i])
+		require.Len(t, results[i].Players, 1)
+		assert.Equal(t, "Alice", results[i].Players[0].Name)
+		assert.InDelta(t, 0.75, results[i].Players[0].WinRate, 0.001)
+	}
+
+	// Later reads are served from the repopulated cache
+	leaderboard, err := reader.GetLeaderboard(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1600, leaderboard.Players[0].Rating)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&repo.fetches))
+}
+
+func TestLeaderboardReader_RankingsCachedPerCriteriaAndLimit(t *testing.T) {
+	reader := NewLeaderboardReader(NewInMemoryCache(), nil, nil)
+	ctx := context.Background()
+
+	var fetches int32
+	load := func(ctx context.Context, limit int) ([]UserStats, error) {
+		atomic.AddInt32(&fetches, 1)
+		return []UserStats{{UserID: "alice", GamesWon: 3, User: User{Name: "Alice"}}}, nil
+	}
+
+	const readers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ranking, err := reader.GetRanking(ctx, "wins", 10, load)
+			assert.NoError(t, err)
+			assert.Len(t, ranking, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches), "repeated reads are served from the cache")
+
+	ranking, err := reader.GetRanking(ctx, "wins", 10, load)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", ranking[0].User.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	_, err = reader.GetRanking(ctx, "wins", 5, load)
+	require.NoError(t, err)
+	_, err = reader.GetRanking(ctx, "points", 10, load)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fetches), "each criteria and limit is cached separately")
+}
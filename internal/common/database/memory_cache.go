@@ -0,0 +1,389 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryCache implements the Cache interface in process memory so that code
+// depending on Cache can be tested without Redis. Keys expire like Redis keys,
+// but are only removed when they are next read.
+type InMemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+var _ Cache = (*InMemoryCache)(nil)
+
+// memoryEntry is a single key. Plain values use value, versioned game states
+// use value and version, chat uses list and the matchmaking queue uses queue.
+type memoryEntry struct {
+	value     string
+	version   int
+	list      []string
+	queue     []memoryQueueMember
+	expiresAt time.Time // Zero if the key never expires
+}
+
+// memoryQueueMember is a member of the matchmaking queue, ordered like a
+// Redis sorted set by score and then by member
+type memoryQueueMember struct {
+	score  float64
+	member string
+}
+
+// NewInMemoryCache creates an empty in-memory cache
+func NewInMemoryCache() *InMemoryCache {
+	return &InMemoryCache{entries: make(map[string]*memoryEntry)}
+}
+
+// expiry returns when a key written now with ttl expires. A ttl of zero or
+// less never expires, as with Redis SET.
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// lookup returns the live entry for key, dropping it if it has expired.
+// The caller must hold c.mu.
+func (c *InMemoryCache) lookup(key string) (*memoryEntry, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// User session operations
+func (c *InMemoryCache) SetUserSession(ctx context.Context, userID string, sessionData interface{}, ttl time.Duration) error {
+	return c.Set(ctx, UserSessionKeyPrefix+userID, sessionData, ttl)
+}
+
+func (c *InMemoryCache) GetUserSession(ctx context.Context, userID string) (string, error) {
+	return c.Get(ctx, UserSessionKeyPrefix+userID)
+}
+
+func (c *InMemoryCache) DeleteUserSession(ctx context.Context, userID string) error {
+	return c.Delete(ctx, UserSessionKeyPrefix+userID)
+}
+
+// User profile operations
+func (c *InMemoryCache) SetUserProfile(ctx context.Context, userID string, profile interface{}, ttl time.Duration) error {
+	return c.Set(ctx, UserProfileKeyPrefix+userID, profile, ttl)
+}
+
+func (c *InMemoryCache) GetUserProfile(ctx context.Context, userID string) (string, error) {
+	return c.Get(ctx, UserProfileKeyPrefix+userID)
+}
+
+func (c *InMemoryCache) DeleteUserProfile(ctx context.Context, userID string) error {
+	return c.Delete(ctx, UserProfileKeyPrefix+userID)
+}
+
+// Room state operations
+func (c *InMemoryCache) SetRoomState(ctx context.Context, roomID string, roomState interface{}, ttl time.Duration) error {
+	return c.Set(ctx, RoomStateKeyPrefix+roomID, roomState, ttl)
+}
+
+func (c *InMemoryCache) GetRoomState(ctx context.Context, roomID string) (string, error) {
+	return c.Get(ctx, RoomStateKeyPrefix+roomID)
+}
+
+func (c *InMemoryCache) DeleteRoomState(ctx context.Context, roomID string) error {
+	return c.Delete(ctx, RoomStateKeyPrefix+roomID)
+}
+
+// Game state operations
+func (c *InMemoryCache) SetGameState(ctx context.Context, gameID string, gameState interface{}, ttl time.Duration) error {
+	return c.Set(ctx, GameStateKeyPrefix+gameID, gameState, ttl)
+}
+
+func (c *InMemoryCache) GetGameState(ctx context.Context, gameID string) (string, error) {
+	return c.Get(ctx, GameStateKeyPrefix+gameID)
+}
+
+func (c *InMemoryCache) DeleteGameState(ctx context.Context, gameID string) error {
+	return c.Delete(ctx, GameStateKeyPrefix+gameID)
+}
+
+// Full game state operations
+func (c *InMemoryCache) SetFullGameState(ctx context.Context, gameID string, gameState interface{}, expectedVersion int, ttl time.Duration) error {
+	data, err := json.Marshal(gameState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game state: %w", err)
+	}
+
+	key := FullGameStateKeyPrefix + gameID
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := 0
+	if entry, ok := c.lookup(key); ok {
+		current = entry.version
+	}
+	if current != expectedVersion {
+		return ErrStaleGameState
+	}
+	c.entries[key] = &memoryEntry{value: string(data), version: expectedVersion + 1, expiresAt: expiry(ttl)}
+	return nil
+}
+
+func (c *InMemoryCache) GetFullGameState(ctx context.Context, gameID string) (string, error) {
+	return c.Get(ctx, FullGameStateKeyPrefix+gameID)
+}
+
+// Game lock operations
+func (c *InMemoryCache) AcquireGameLock(ctx context.Context, gameID string, ttl time.Duration) (func(), error) {
+	key := GameLockKeyPrefix + gameID
+	token := uuid.New().String()
+
+	for !c.setIfAbsent(key, token, ttl) {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s", ErrLockNotAcquired, key)
+		case <-time.After(gameLockRetryInterval):
+		}
+	}
+
+	release := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if entry, ok := c.lookup(key); ok && entry.value == token {
+			delete(c.entries, key)
+		}
+	}
+	return release, nil
+}
+
+// setIfAbsent stores value under key unless a live key exists, like SETNX
+func (c *InMemoryCache) setIfAbsent(key, value string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.lookup(key); ok {
+		return false
+	}
+	c.entries[key] = &memoryEntry{value: value, expiresAt: expiry(ttl)}
+	return true
+}
+
+// Idempotency operations
+func (c *InMemoryCache) SetIdempotentResult(ctx context.Context, key string, result interface{}, ttl time.Duration) error {
+	return c.Set(ctx, IdempotencyKeyPrefix+key, result, ttl)
+}
+
+func (c *InMemoryCache) GetIdempotentResult(ctx context.Context, key string) (string, error) {
+	return c.Get(ctx, IdempotencyKeyPrefix+key)
+}
+
+// Chat operations
+func (c *InMemoryCache) AddChatMessage(ctx context.Context, roomID string, message interface{}, maxMessages int) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	key := ChatKeyPrefix + roomID
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok {
+		entry = &memoryEntry{}
+		c.entries[key] = entry
+	}
+	entry.list = append(entry.list, string(data))
+	if len(entry.list) > maxMessages {
+		entry.list = entry.list[len(entry.list)-maxMessages:]
+	}
+	entry.expiresAt = expiry(DefaultChatTTL)
+	return nil
+}
+
+// GetChatMessages returns up to limit messages in the order they were sent,
+// skipping the offset most recent ones
+func (c *InMemoryCache) GetChatMessages(ctx context.Context, roomID string, offset, limit int) ([]string, error) {
+	if limit <= 0 {
+		return []string{}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(ChatKeyPrefix + roomID)
+	if !ok {
+		return []string{}, nil
+	}
+	stop := len(entry.list) - offset
+	if stop <= 0 {
+		return []string{}, nil
+	}
+	start := stop - limit
+	if start < 0 {
+		start = 0
+	}
+	return append([]string{}, entry.list[start:stop]...), nil
+}
+
+// Leaderboard operations
+func (c *InMemoryCache) SetLeaderboard(ctx context.Context, leaderboardData interface{}, ttl time.Duration) error {
+	return c.Set(ctx, LeaderboardKey, leaderboardData, ttl)
+}
+
+func (c *InMemoryCache) GetLeaderboard(ctx context.Context) (string, error) {
+	return c.Get(ctx, LeaderboardKey)
+}
+
+func (c *InMemoryCache) DeleteLeaderboard(ctx context.Context) error {
+	return c.Delete(ctx, LeaderboardKey)
+}
+
+// WebSocket connection operations
+func (c *InMemoryCache) SetWSConnection(ctx context.Context, userID string, connectionID string, ttl time.Duration) error {
+	return c.Set(ctx, WSConnectionKeyPrefix+userID, connectionID, ttl)
+}
+
+func (c *InMemoryCache) GetWSConnection(ctx context.Context, userID string) (string, error) {
+	return c.Get(ctx, WSConnectionKeyPrefix+userID)
+}
+
+func (c *InMemoryCache) DeleteWSConnection(ctx context.Context, userID string) error {
+	return c.Delete(ctx, WSConnectionKeyPrefix+userID)
+}
+
+// Matchmaking queue operations
+func (c *InMemoryCache) AddToMatchmakingQueue(ctx context.Context, userID string, userData interface{}) error {
+	data, err := json.Marshal(userData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user data: %w", err)
+	}
+	member := memoryQueueMember{score: float64(time.Now().Unix()), member: userID + ":" + string(data)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(MatchmakingQueueKey)
+	if !ok {
+		entry = &memoryEntry{}
+		c.entries[MatchmakingQueueKey] = entry
+	}
+	for i, existing := range entry.queue {
+		if existing.member == member.member {
+			entry.queue[i] = member
+			return nil
+		}
+	}
+	entry.queue = append(entry.queue, member)
+	return nil
+}
+
+func (c *InMemoryCache) RemoveFromMatchmakingQueue(ctx context.Context, userID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(MatchmakingQueueKey)
+	if !ok {
+		return nil
+	}
+	remaining := entry.queue[:0]
+	for _, member := range entry.queue {
+		if !strings.HasPrefix(member.member, userID+":") {
+			remaining = append(remaining, member)
+		}
+	}
+	entry.queue = remaining
+	return nil
+}
+
+func (c *InMemoryCache) GetMatchmakingQueue(ctx context.Context, limit int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(MatchmakingQueueKey)
+	if !ok || limit <= 0 {
+		return []string{}, nil
+	}
+	sort.SliceStable(entry.queue, func(i, j int) bool {
+		if entry.queue[i].score != entry.queue[j].score {
+			return entry.queue[i].score < entry.queue[j].score
+		}
+		return entry.queue[i].member < entry.queue[j].member
+	})
+
+	members := make([]string, 0, limit)
+	for _, member := range entry.queue {
+		if len(members) == limit {
+			break
+		}
+		members = append(members, member.member)
+	}
+	return members, nil
+}
+
+// Generic operations
+func (c *InMemoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &memoryEntry{value: string(data), expiresAt: expiry(ttl)}
+	return nil
+}
+
+func (c *InMemoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrCacheMiss, key)
+	}
+	return entry.value, nil
+}
+
+func (c *InMemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+func (c *InMemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.lookup(key)
+	return ok, nil
+}
+
+// SetTTL changes when an existing key expires. As with Redis EXPIRE, a ttl of
+// zero or less deletes the key and a missing key is left missing.
+func (c *InMemoryCache) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok {
+		return nil
+	}
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return nil
+	}
+	entry.expiresAt = expiry(ttl)
+	return nil
+}
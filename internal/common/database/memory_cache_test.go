@@ -0,0 +1,265 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryCache_UserSession(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	userID := "test-user-123"
+	sessionData := CachedUserSession{
+		UserID:    userID,
+		Token:     "test-token-456",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("SetUserSession", func(t *testing.T) {
+		err := cache.SetUserSession(ctx, userID, sessionData, DefaultUserSessionTTL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetUserSession", func(t *testing.T) {
+		result, err := cache.GetUserSession(ctx, userID)
+		assert.NoError(t, err)
+		assert.Contains(t, result, sessionData.Token)
+		assert.Contains(t, result, sessionData.UserID)
+	})
+
+	t.Run("DeleteUserSession", func(t *testing.T) {
+		err := cache.DeleteUserSession(ctx, userID)
+		assert.NoError(t, err)
+
+		_, err = cache.GetUserSession(ctx, userID)
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
+func TestInMemoryCache_FullGameState(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	gameID := "test-game-456"
+
+	t.Run("SetFullGameState", func(t *testing.T) {
+		err := cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 1, "phase": "bidding"}, 0, DefaultGameStateTTL)
+		assert.NoError(t, err)
+
+		err = cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 2, "phase": "playing"}, 1, DefaultGameStateTTL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RejectsStaleVersion", func(t *testing.T) {
+		err := cache.SetFullGameState(ctx, gameID, map[string]interface{}{"version": 2, "phase": "ended"}, 1, DefaultGameStateTTL)
+		assert.ErrorIs(t, err, ErrStaleGameState)
+	})
+
+	t.Run("GetFullGameState", func(t *testing.T) {
+		result, err := cache.GetFullGameState(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "playing")
+	})
+
+	t.Run("MissingGameState", func(t *testing.T) {
+		_, err := cache.GetFullGameState(ctx, "missing-game")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
+func TestInMemoryCache_GameLock(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	t.Run("MutualExclusion", func(t *testing.T) {
+		var mu sync.Mutex
+		holders, maxHolders := 0, 0
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := cache.AcquireGameLock(ctx, "lock-game", time.Second)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				holders++
+				if holders > maxHolders {
+					maxHolders = holders
+				}
+				mu.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+				release()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, maxHolders)
+	})
+
+	t.Run("HeldLockTimesOut", func(t *testing.T) {
+		release, err := cache.AcquireGameLock(ctx, "held-game", time.Second)
+		assert.NoError(t, err)
+		defer release()
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = cache.AcquireGameLock(waitCtx, "held-game", time.Second)
+		assert.ErrorIs(t, err, ErrLockNotAcquired)
+	})
+
+	t.Run("ReleaseOnlyDeletesOwnLock", func(t *testing.T) {
+		releaseExpired, err := cache.AcquireGameLock(ctx, "expiring-game", 50*time.Millisecond)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		releaseCurrent, err := cache.AcquireGameLock(ctx, "expiring-game", time.Second)
+		assert.NoError(t, err)
+		defer releaseCurrent()
+
+		releaseExpired()
+
+		exists, err := cache.Exists(ctx, GameLockKeyPrefix+"expiring-game")
+		assert.NoError(t, err)
+		assert.True(t, exists, "expired holder must not release the new holder's lock")
+	})
+}
+
+func TestInMemoryCache_MatchmakingQueue(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	assert.NoError(t, cache.AddToMatchmakingQueue(ctx, "user1", CachedMatchmakingUser{UserID: "user1", Name: "User One"}))
+	assert.NoError(t, cache.AddToMatchmakingQueue(ctx, "user2", CachedMatchmakingUser{UserID: "user2", Name: "User Two"}))
+
+	queue, err := cache.GetMatchmakingQueue(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, queue, 2)
+
+	assert.NoError(t, cache.RemoveFromMatchmakingQueue(ctx, "user1"))
+
+	queue, err = cache.GetMatchmakingQueue(ctx, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, queue, 1) {
+		assert.True(t, strings.HasPrefix(queue[0], "user2:"))
+	}
+}
+
+func TestInMemoryCache_GenericOperations(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	key := "test-key"
+	value := map[string]interface{}{
+		"name":  "test",
+		"value": 123,
+		"flag":  true,
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		err := cache.Set(ctx, key, value, 1*time.Hour)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		result, err := cache.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"test","value":123,"flag":true}`, result)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		exists, err := cache.Exists(ctx, key)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = cache.Exists(ctx, "non-existent-key")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := cache.Delete(ctx, key)
+		assert.NoError(t, err)
+
+		exists, err := cache.Exists(ctx, key)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = cache.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
+func TestInMemoryCache_TTLExpiration(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+
+	t.Run("ShortTTL", func(t *testing.T) {
+		err := cache.Set(ctx, "ttl-test-key", "test-value", 100*time.Millisecond)
+		assert.NoError(t, err)
+
+		exists, err := cache.Exists(ctx, "ttl-test-key")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		time.Sleep(150 * time.Millisecond)
+
+		_, err = cache.Get(ctx, "ttl-test-key")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("SetTTL", func(t *testing.T) {
+		err := cache.Set(ctx, "no-ttl-key", "test-value", 0)
+		assert.NoError(t, err)
+
+		err = cache.SetTTL(ctx, "no-ttl-key", 50*time.Millisecond)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		exists, err := cache.Exists(ctx, "no-ttl-key")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestInMemoryCache_Chat(t *testing.T) {
+	cache := NewInMemoryCache()
+	ctx := context.Background()
+	roomID := "test-room-chat"
+
+	for i := 1; i <= 5; i++ {
+		err := cache.AddChatMessage(ctx, roomID, map[string]int{"n": i}, 3)
+		assert.NoError(t, err)
+	}
+
+	t.Run("KeepsOnlyRecentMessagesInOrder", func(t *testing.T) {
+		messages, err := cache.GetChatMessages(ctx, roomID, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`{"n":3}`, `{"n":4}`, `{"n":5}`}, messages)
+	})
+
+	t.Run("Paginates", func(t *testing.T) {
+		messages, err := cache.GetChatMessages(ctx, roomID, 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`{"n":4}`}, messages)
+
+		messages, err = cache.GetChatMessages(ctx, roomID, 5, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+}
@@ -3,60 +3,176 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
+	"time"
+
+	"chinese-bridge-game/pkg/logging"
 
 	"gorm.io/gorm"
 )
 
+// Migration is a numbered schema change. Each migration is applied once, in
+// version order, and recorded in the schema_migrations table. Changes to the
+// models after version 2 need a new migration to reach existing databases.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *gorm.DB) error
+}
+
+// SchemaMigration records a migration that has been applied
+type SchemaMigration struct {
+	Version     int       `gorm:"primaryKey;autoIncrement:false"`
+	Description string    `gorm:"type:varchar(255)"`
+	AppliedAt   time.Time `gorm:"not null"`
+}
+
+// TableName keeps the conventional name for the migrations table
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
 // MigrationManager handles database migrations
 type MigrationManager struct {
-	db *gorm.DB
+	db         *gorm.DB
+	logger     *slog.Logger
+	migrations []Migration
 }
 
-// NewMigrationManager creates a new migration manager
-func NewMigrationManager(db *gorm.DB) *MigrationManager {
-	return &MigrationManager{db: db}
+// NewMigrationManager creates a new migration manager. A nil logger uses the
+// default logger.
+func NewMigrationManager(db *gorm.DB, logger *slog.Logger) *MigrationManager {
+	m := &MigrationManager{db: db, logger: logging.OrDefault(logger)}
+	m.migrations = []Migration{
+		{Version: 1, Description: "enable UUID extension", Up: m.enableUUIDExtension},
+		{Version: 2, Description: "create tables", Up: m.migrateModels},
+		{Version: 3, Description: "create indexes", Up: m.createIndexes},
+		{Version: 4, Description: "add games.aborted", Up: addColumns(&Game{}, "Aborted")},
+		{Version: 5, Description: "add user_stats streaks", Up: addColumns(&UserStats{}, "CurrentStreak", "BestStreak")},
+		{Version: 6, Description: "add games.deal_seed", Up: addColumns(&Game{}, "DealSeed")},
+		{Version: 7, Description: "add rooms.rules", Up: addColumns(&Room{}, "Rules")},
+		{Version: 8, Description: "add games.rematch_room_id", Up: addColumns(&Game{}, "RematchRoomID")},
+		{Version: 9, Description: "add sessions device and access token columns", Up: m.addSessionDeviceColumns},
+	}
+	return m
 }
 
-// RunMigrations executes all database migrations
+// RunMigrations applies every migration that has not been applied yet, in
+// version order, so running it again is a no-op
 func (m *MigrationManager) RunMigrations(ctx context.Context) error {
-	log.Println("Starting database migrations...")
+	m.logger.Info("Starting database migrations")
 
-	// Enable UUID extension for PostgreSQL
-	if err := m.enableUUIDExtension(ctx); err != nil {
-		return fmt.Errorf("failed to enable UUID extension: %w", err)
+	if err := m.db.WithContext(ctx).AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	// Auto-migrate all models
-	models := GetAllModels()
-	for _, model := range models {
-		if err := m.db.WithContext(ctx).AutoMigrate(model); err != nil {
+	applied, err := m.AppliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrations := append([]Migration(nil), m.migrations...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	for i, migration := range migrations {
+		if i > 0 && migration.Version == migrations[i-1].Version {
+			return fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+		if applied[migration.Version] {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db.WithContext(ctx)); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+
+		record := &SchemaMigration{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		}
+		if err := m.db.WithContext(ctx).Create(record).Error; err != nil {
+			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		m.logger.Info("Applied migration", "version", migration.Version, "description", migration.Description)
+	}
+
+	m.logger.Info("Database migrations completed successfully")
+	return nil
+}
+
+// AppliedVersions returns the versions of the migrations already applied
+func (m *MigrationManager) AppliedVersions(ctx context.Context) (map[int]bool, error) {
+	var records []SchemaMigration
+	if err := m.db.WithContext(ctx).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	applied := make(map[int]bool, len(records))
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+	return applied, nil
+}
+
+// migrateModels creates the tables for all models
+func (m *MigrationManager) migrateModels(ctx context.Context, db *gorm.DB) error {
+	for _, model := range GetAllModels() {
+		if err := db.AutoMigrate(model); err != nil {
 			return fmt.Errorf("failed to migrate model %T: %w", model, err)
 		}
-		log.Printf("Migrated model: %T", model)
+		m.logger.Debug("Migrated model", "model", fmt.Sprintf("%T", model))
 	}
+	return nil
+}
 
-	// Create indexes for better performance
-	if err := m.createIndexes(ctx); err != nil {
-		return fmt.Errorf("failed to create indexes: %w", err)
+// addColumns returns a migration adding columns for new fields of a model.
+// Databases created after the field was added already have the column from
+// the create tables migration, so existing columns are skipped.
+func addColumns(model interface{}, fields ...string) func(ctx context.Context, db *gorm.DB) error {
+	return func(ctx context.Context, db *gorm.DB) error {
+		for _, field := range fields {
+			if db.Migrator().HasColumn(model, field) {
+				continue
+			}
+			if err := db.Migrator().AddColumn(model, field); err != nil {
+				return fmt.Errorf("failed to add column %s to %T: %w", field, model, err)
+			}
+		}
+		return nil
 	}
+}
 
-	log.Println("Database migrations completed successfully")
+// addSessionDeviceColumns adds the columns sessions gained for listing and
+// revoking devices, with the index used to look a session up by access token
+func (m *MigrationManager) addSessionDeviceColumns(ctx context.Context, db *gorm.DB) error {
+	if err := addColumns(&Session{}, "DeviceLabel", "AccessTokenID")(ctx, db); err != nil {
+		return err
+	}
+	if db.Migrator().HasIndex(&Session{}, "AccessTokenID") {
+		return nil
+	}
+	if err := db.Migrator().CreateIndex(&Session{}, "AccessTokenID"); err != nil {
+		return fmt.Errorf("failed to index sessions.access_token_id: %w", err)
+	}
 	return nil
 }
 
 // enableUUIDExtension enables the UUID extension in PostgreSQL
-func (m *MigrationManager) enableUUIDExtension(ctx context.Context) error {
+func (m *MigrationManager) enableUUIDExtension(ctx context.Context, db *gorm.DB) error {
 	// Check if we're using PostgreSQL
-	if m.db.Dialector.Name() == "postgres" {
-		return m.db.WithContext(ctx).Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"").Error
+	if db.Dialector.Name() == "postgres" {
+		return db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"").Error
 	}
 	// For other databases (like SQLite), skip UUID extension
 	return nil
 }
 
 // createIndexes creates additional indexes for performance optimization
-func (m *MigrationManager) createIndexes(ctx context.Context) error {
+func (m *MigrationManager) createIndexes(ctx context.Context, db *gorm.DB) error {
 	indexes := []string{
 		// User indexes
 		"CREATE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id)",
@@ -83,6 +199,7 @@ func (m *MigrationManager) createIndexes(ctx context.Context) error {
 		"CREATE INDEX IF NOT EXISTS idx_user_stats_games_won ON user_stats(games_won)",
 		"CREATE INDEX IF NOT EXISTS idx_user_stats_declarer_wins ON user_stats(declarer_wins)",
 		"CREATE INDEX IF NOT EXISTS idx_user_stats_games_played ON user_stats(games_played)",
+		"CREATE INDEX IF NOT EXISTS idx_user_stats_rating ON user_stats(rating)",
 
 		// Junction table indexes
 		"CREATE INDEX IF NOT EXISTS idx_room_participants_room_id ON room_participants(room_id)",
@@ -92,8 +209,8 @@ func (m *MigrationManager) createIndexes(ctx context.Context) error {
 	}
 
 	for _, indexSQL := range indexes {
-		if err := m.db.WithContext(ctx).Exec(indexSQL).Error; err != nil {
-			log.Printf("Warning: Failed to create index: %s, Error: %v", indexSQL, err)
+		if err := db.Exec(indexSQL).Error; err != nil {
+			m.logger.Warn("Failed to create index", "sql", indexSQL, "error", err)
 			// Continue with other indexes even if one fails
 		}
 	}
@@ -103,7 +220,7 @@ func (m *MigrationManager) createIndexes(ctx context.Context) error {
 
 // SeedData populates the database with initial test data
 func (m *MigrationManager) SeedData(ctx context.Context) error {
-	log.Println("Starting database seeding...")
+	m.logger.Info("Starting database seeding")
 
 	// Check if data already exists
 	var userCount int64
@@ -112,7 +229,7 @@ func (m *MigrationManager) SeedData(ctx context.Context) error {
 	}
 
 	if userCount > 0 {
-		log.Println("Database already contains data, skipping seeding")
+		m.logger.Info("Database already contains data, skipping seeding")
 		return nil
 	}
 
@@ -165,7 +282,7 @@ func (m *MigrationManager) SeedData(ctx context.Context) error {
 			return fmt.Errorf("failed to create stats for user %s: %w", user.Email, err)
 		}
 
-		log.Printf("Created test user: %s", user.Email)
+		m.logger.Debug("Created test user", "email", user.Email)
 	}
 
 	// Create a test room
@@ -193,31 +310,35 @@ func (m *MigrationManager) SeedData(ctx context.Context) error {
 			return fmt.Errorf("failed to add room participant: %w", err)
 		}
 
-		log.Printf("Created test room: %s", testRoom.Name)
+		m.logger.Debug("Created test room", "name", testRoom.Name)
 	}
 
-	log.Println("Database seeding completed successfully")
+	m.logger.Info("Database seeding completed successfully")
 	return nil
 }
 
 // DropAllTables drops all tables (useful for testing)
 func (m *MigrationManager) DropAllTables(ctx context.Context) error {
-	log.Println("Dropping all tables...")
+	m.logger.Info("Dropping all tables")
 
 	models := GetAllModels()
 	// Reverse order to handle foreign key constraints
 	for i := len(models) - 1; i >= 0; i-- {
 		if err := m.db.WithContext(ctx).Migrator().DropTable(models[i]); err != nil {
-			log.Printf("Warning: Failed to drop table for model %T: %v", models[i], err)
+			m.logger.Warn("Failed to drop table", "model", fmt.Sprintf("%T", models[i]), "error", err)
 		}
 	}
 
-	log.Println("All tables dropped successfully")
+	if err := m.db.WithContext(ctx).Migrator().DropTable(&SchemaMigration{}); err != nil {
+		m.logger.Warn("Failed to drop migrations table", "error", err)
+	}
+
+	m.logger.Info("All tables dropped successfully")
 	return nil
 }
 
 // RunMigrations is a convenience function to run migrations
 func RunMigrations(db *gorm.DB) error {
-	manager := NewMigrationManager(db)
+	manager := NewMigrationManager(db, nil)
 	return manager.RunMigrations(context.Background())
 }
\ No newline at end of file
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"chinese-bridge-game/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrationManager_RunTwiceIsNoOp(t *testing.T) {
+	db, _ := setupTestDB(t)
+	ctx := context.Background()
+	manager := NewMigrationManager(db, nil)
+
+	var before []SchemaMigration
+	require.NoError(t, db.Order("version").Find(&before).Error)
+	require.Len(t, before, len(manager.migrations))
+
+	require.NoError(t, manager.RunMigrations(ctx))
+
+	var after []SchemaMigration
+	require.NoError(t, db.Order("version").Find(&after).Error)
+	require.Len(t, after, len(manager.migrations))
+	for i := range before {
+		assert.Equal(t, before[i].Version, after[i].Version)
+		assert.True(t, before[i].AppliedAt.Equal(after[i].AppliedAt), "migration %d was applied again", after[i].Version)
+	}
+}
+
+func TestMigrationManager_AppliesNewMigrationInOrder(t *testing.T) {
+	db, _ := setupTestDB(t)
+	ctx := context.Background()
+	manager := NewMigrationManager(db, nil)
+
+	var order []int
+	step := func(version int) Migration {
+		return Migration{
+			Version:     version,
+			Description: "test step",
+			Up: func(ctx context.Context, db *gorm.DB) error {
+				order = append(order, version)
+				return nil
+			},
+		}
+	}
+	manager.migrations = append(manager.migrations, step(101), step(100))
+
+	require.NoError(t, manager.RunMigrations(ctx))
+	assert.Equal(t, []int{100, 101}, order)
+
+	applied, err := manager.AppliedVersions(ctx)
+	require.NoError(t, err)
+	for _, migration := range manager.migrations {
+		assert.True(t, applied[migration.Version], "migration %d not recorded", migration.Version)
+	}
+
+	// Recorded steps are not applied again
+	require.NoError(t, manager.RunMigrations(ctx))
+	assert.Equal(t, []int{100, 101}, order)
+}
+
+func TestMigrationManager_DuplicateVersion(t *testing.T) {
+	db, _ := setupTestDB(t)
+	manager := NewMigrationManager(db, nil)
+	manager.migrations = append(manager.migrations, Migration{
+		Version: 3,
+		Up:      func(ctx context.Context, db *gorm.DB) error { return nil },
+	})
+
+	assert.ErrorContains(t, manager.RunMigrations(context.Background()), "duplicate migration version 3")
+}
+
+func TestMigrationManager_AddColumnsToExistingTable(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// A table created before the aborted field existed
+	require.NoError(t, db.Exec("CREATE TABLE games (id varchar(36) PRIMARY KEY, room_id varchar(36))").Error)
+	require.False(t, db.Migrator().HasColumn(&Game{}, "Aborted"))
+
+	addAborted := addColumns(&Game{}, "Aborted")
+	require.NoError(t, addAborted(ctx, db))
+	assert.True(t, db.Migrator().HasColumn(&Game{}, "Aborted"))
+
+	// Running it again leaves the existing column alone
+	require.NoError(t, addAborted(ctx, db))
+}
+
+func TestMigrationManager_AddSessionDeviceColumns(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// A sessions table created before devices were listed
+	require.NoError(t, db.Exec("CREATE TABLE sessions (id varchar(36) PRIMARY KEY, user_id varchar(36), token text, expires_at datetime, created_at datetime)").Error)
+
+	m := NewMigrationManager(db, nil)
+	require.NoError(t, m.addSessionDeviceColumns(ctx, db))
+	assert.True(t, db.Migrator().HasColumn(&Session{}, "DeviceLabel"))
+	assert.True(t, db.Migrator().HasColumn(&Session{}, "AccessTokenID"))
+	assert.True(t, db.Migrator().HasIndex(&Session{}, "AccessTokenID"))
+
+	// Running it again leaves the existing columns and index alone
+	require.NoError(t, m.addSessionDeviceColumns(ctx, db))
+}
+
+func TestMigrationManager_LogsAtConfiguredLevel(t *testing.T) {
+	for _, level := range []slog.Level{slog.LevelInfo, slog.LevelDebug} {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, NewMigrationManager(db, logging.New(&buf, level)).RunMigrations(context.Background()))
+
+		assert.Contains(t, buf.String(), "Applied migration")
+		// Each migrated model is only logged at debug level
+		assert.Equal(t, level == slog.LevelDebug, strings.Contains(buf.String(), "Migrated model"), "level %s", level)
+	}
+}
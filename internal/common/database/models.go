@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // User model with GORM tags
@@ -13,8 +14,10 @@ type User struct {
 	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
 	Name      string    `json:"name" gorm:"not null"`
 	Avatar    string    `json:"avatar"`
+	Role      string    `json:"role" gorm:"type:varchar(20);not null;default:player"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete keeps game history intact
 
 	// Associations
 	Stats             *UserStats          `json:"stats,omitempty" gorm:"foreignKey:UserID"`
@@ -23,6 +26,12 @@ type User struct {
 	GameParticipants  []GameParticipant  `json:"game_participants,omitempty" gorm:"foreignKey:UserID"`
 }
 
+// User roles
+const (
+	RolePlayer = "player"
+	RoleAdmin  = "admin"
+)
+
 // UserStats model for tracking player statistics
 type UserStats struct {
 	UserID          string  `json:"user_id" gorm:"type:varchar(36);primaryKey"`
@@ -32,6 +41,9 @@ type UserStats struct {
 	DeclarerWins    int     `json:"declarer_wins" gorm:"default:0"`
 	TotalPoints     int     `json:"total_points" gorm:"default:0"`
 	AverageBid      float64 `json:"average_bid" gorm:"type:decimal(5,2);default:0"`
+	Rating          int     `json:"rating" gorm:"default:1500"`
+	CurrentStreak   int     `json:"current_streak" gorm:"default:0"` // Consecutive wins when positive, consecutive losses when negative
+	BestStreak      int     `json:"best_streak" gorm:"default:0"`    // Most consecutive wins
 	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -39,6 +51,14 @@ type UserStats struct {
 	User User `json:"user" gorm:"foreignKey:UserID"`
 }
 
+// Room statuses
+const (
+	RoomStatusWaiting  = "waiting"
+	RoomStatusPlaying  = "playing"
+	RoomStatusFinished = "finished"
+	RoomStatusClosed   = "closed" // Abandoned before a game started
+)
+
 // Room model for game rooms
 type Room struct {
 	ID             string    `json:"id" gorm:"type:varchar(36);primaryKey"`
@@ -47,6 +67,7 @@ type Room struct {
 	MaxPlayers     int       `json:"max_players" gorm:"default:4"`
 	CurrentPlayers int       `json:"current_players" gorm:"default:0"`
 	Status         string    `json:"status" gorm:"default:'waiting'"`
+	Rules          datatypes.JSON `json:"rules,omitempty" gorm:"type:jsonb"` // House rules chosen at creation; games use the server's rules if empty
 	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -80,6 +101,9 @@ type Game struct {
 	GameData    datatypes.JSON `json:"game_data" gorm:"type:jsonb"` // Complete game state
 	StartedAt   *time.Time `json:"started_at"`
 	EndedAt     *time.Time `json:"ended_at"`
+	Aborted     bool       `json:"aborted" gorm:"default:false"` // Terminated by an admin; not counted in stats
+	DealSeed    *int64     `json:"-"`                            // Seed the deck was shuffled with, kept from players so it cannot reveal hands
+	RematchRoomID *string  `json:"rematch_room_id,omitempty" gorm:"type:varchar(36)"` // Room opened for a rematch once the game ended
 	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -94,7 +118,7 @@ type GameParticipant struct {
 	GameID         string `json:"game_id" gorm:"type:varchar(36);primaryKey"`
 	UserID         string `json:"user_id" gorm:"type:varchar(36);primaryKey"`
 	Position       int    `json:"position"` // 0-3 for game position
-	Role           string `json:"role"`     // 'declarer' or 'defender'
+	Role           string `json:"role"`     // 'declarer', 'partner' or 'defender'
 	PointsCaptured int    `json:"points_captured" gorm:"default:0"`
 
 	// Associations
@@ -104,11 +128,13 @@ type GameParticipant struct {
 
 // Session model for user authentication sessions
 type Session struct {
-	ID        string    `json:"id" gorm:"type:varchar(36);primaryKey"`
-	UserID    string    `json:"user_id" gorm:"type:varchar(36);not null;index"`
-	Token     string    `json:"token" gorm:"not null;index"`
-	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID            string    `json:"id" gorm:"type:varchar(36);primaryKey"`
+	UserID        string    `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	Token         string    `json:"token" gorm:"not null;index"`
+	DeviceLabel   string    `json:"device_label" gorm:"type:varchar(255)"`         // User agent of the client that logged in
+	AccessTokenID string    `json:"access_token_id" gorm:"type:varchar(36);index"` // jti of the latest access token issued for the session
+	ExpiresAt     time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
 	
 	// Association
 	User User `json:"user" gorm:"foreignKey:UserID"`
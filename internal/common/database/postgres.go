@@ -1,19 +1,120 @@
 package database
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-func NewPostgresConnection(databaseURL string) (*gorm.DB, error) {
+// PoolOptions sizes the database connection pool. Zero values keep the
+// database/sql defaults: unlimited open connections, two idle ones and no
+// connection lifetime limits.
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// validate checks that none of the options are negative
+func (o PoolOptions) validate() error {
+	if o.MaxOpenConns < 0 || o.MaxIdleConns < 0 {
+		return fmt.Errorf("database open and idle connections cannot be negative")
+	}
+	if o.MaxIdleConns > o.MaxOpenConns && o.MaxOpenConns > 0 {
+		return fmt.Errorf("database idle connections %d exceed open connections %d", o.MaxIdleConns, o.MaxOpenConns)
+	}
+	if o.ConnMaxLifetime < 0 || o.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("database connection lifetimes cannot be negative")
+	}
+	return nil
+}
+
+// NewPostgresConnection connects to the database at databaseURL with the
+// given pool settings
+func NewPostgresConnection(databaseURL string, options PoolOptions) (*gorm.DB, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
 	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := ConfigurePool(db, options); err != nil {
+		return nil, err
+	}
 
 	// Auto-migrate tables will be handled by individual services
 	return db, nil
-}
\ No newline at end of file
+}
+
+// ConfigurePool applies the pool settings to the connection pool under db
+func ConfigurePool(db *gorm.DB, options PoolOptions) error {
+	if err := options.validate(); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+	if options.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(options.MaxOpenConns)
+	}
+	if options.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(options.MaxIdleConns)
+	}
+	if options.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(options.ConnMaxLifetime)
+	}
+	if options.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(options.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+// PoolStats is a snapshot of the connection pool for health endpoints
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"` // 0 for unlimited
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`    // Connections waited for since startup
+	WaitDuration       time.Duration `json:"wait_duration"` // Total time spent waiting for them
+}
+
+// GetPoolStats returns the current state of the connection pool under db
+func GetPoolStats(db *gorm.DB) (*PoolStats, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection pool: %w", err)
+	}
+
+	stats := sqlDB.Stats()
+	return &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration,
+	}, nil
+}
+
+// RegisterPoolMetrics exports the connection pool's statistics as Prometheus
+// metrics labelled with dbName
+func RegisterPoolMetrics(db *gorm.DB, dbName string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+	return prometheus.Register(collectors.NewDBStatsCollector(sqlDB, dbName))
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestConfigurePool_AppliesLimits(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	options := PoolOptions{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: time.Minute}
+	require.NoError(t, ConfigurePool(db, options))
+
+	stats, err := GetPoolStats(db)
+	require.NoError(t, err)
+	assert.Equal(t, 7, stats.MaxOpenConnections)
+}
+
+func TestConfigurePool_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PoolOptions
+	}{
+		{"Negative open connections", PoolOptions{MaxOpenConns: -1}},
+		{"Idle connections above open connections", PoolOptions{MaxOpenConns: 2, MaxIdleConns: 5}},
+		{"Negative lifetime", PoolOptions{ConnMaxLifetime: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			require.NoError(t, err)
+
+			assert.Error(t, ConfigurePool(db, tt.options))
+			stats, err := GetPoolStats(db)
+			require.NoError(t, err)
+			assert.Equal(t, 0, stats.MaxOpenConnections)
+		})
+	}
+}
@@ -2,32 +2,110 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
-func NewRedisClient(redisURL string) *redis.Client {
+// RedisOptions tunes the Redis connection pool. Zero values keep the
+// go-redis defaults.
+type RedisOptions struct {
+	DB           int // Database index, negative to use the one in the URL
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	// ConnectRetries is how many more times to try reaching the server after
+	// the first attempt fails, waiting ConnectBackoff before the first retry
+	// and doubling the wait for each one after, up to maxRedisConnectBackoff
+	ConnectRetries int
+	ConnectBackoff time.Duration
+}
+
+// Connection retry waits used when the options leave them unset or too long
+const (
+	defaultRedisConnectBackoff = 500 * time.Millisecond
+	maxRedisConnectBackoff     = 10 * time.Second
+)
+
+// validate checks that none of the options are negative
+func (o RedisOptions) validate() error {
+	if o.PoolSize < 0 || o.MinIdleConns < 0 {
+		return fmt.Errorf("redis pool size and idle connections cannot be negative")
+	}
+	if o.MinIdleConns > o.PoolSize && o.PoolSize > 0 {
+		return fmt.Errorf("redis idle connections %d exceed pool size %d", o.MinIdleConns, o.PoolSize)
+	}
+	if o.DialTimeout < 0 || o.ReadTimeout < 0 || o.WriteTimeout < 0 {
+		return fmt.Errorf("redis timeouts cannot be negative")
+	}
+	if o.ConnectRetries < 0 || o.ConnectBackoff < 0 {
+		return fmt.Errorf("redis connection retries and backoff cannot be negative")
+	}
+	return nil
+}
+
+// NewRedisClient connects to the Redis server at redisURL with the given pool
+// settings and checks that it responds, retrying with exponential backoff as
+// the options allow so a server that comes up shortly after us is waited for
+func NewRedisClient(redisURL string, options RedisOptions) (*redis.Client, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
 	opt, err := redis.ParseURL(redisURL)
 	if err != nil {
-		// Fallback to default configuration
-		opt = &redis.Options{
-			Addr:     "localhost:6379",
-			Password: "",
-			DB:       0,
-		}
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if options.DB >= 0 {
+		opt.DB = options.DB
 	}
+	opt.PoolSize = options.PoolSize
+	opt.MinIdleConns = options.MinIdleConns
+	opt.DialTimeout = options.DialTimeout
+	opt.ReadTimeout = options.ReadTimeout
+	opt.WriteTimeout = options.WriteTimeout
 
 	client := redis.NewClient(opt)
+	if err := pingWithRetry(client, options); err != nil {
+		client.Close()
+		return nil, err
+	}
 
-	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	return client, nil
+}
 
-	_, err = client.Ping(ctx).Result()
-	if err != nil {
-		panic("Failed to connect to Redis: " + err.Error())
+// pingWithRetry pings the server until it responds or the retries run out
+func pingWithRetry(client *redis.Client, options RedisOptions) error {
+	backoff := options.ConnectBackoff
+	if backoff == 0 {
+		backoff = defaultRedisConnectBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := ping(client)
+		if err == nil {
+			return nil
+		}
+		if attempt == options.ConnectRetries {
+			return fmt.Errorf("failed to connect to redis: %w", err)
+		}
+
+		slog.Warn("Redis is not reachable yet, retrying", "attempt", attempt+1, "retry_in", backoff, "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxRedisConnectBackoff)
 	}
+}
+
+// ping checks that the server responds
+func ping(client *redis.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	return client
-}
\ No newline at end of file
+	_, err := client.Ping(ctx).Result()
+	return err
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisClient_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		redisURL string
+		options  RedisOptions
+	}{
+		{"Invalid URL", "not-a-redis-url", RedisOptions{DB: -1}},
+		{"Negative pool size", "redis://localhost:6379", RedisOptions{DB: -1, PoolSize: -1}},
+		{"Idle connections above pool size", "redis://localhost:6379", RedisOptions{DB: -1, PoolSize: 2, MinIdleConns: 5}},
+		{"Negative timeout", "redis://localhost:6379", RedisOptions{DB: -1, ReadTimeout: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				client, err := NewRedisClient(tt.redisURL, tt.options)
+				assert.Error(t, err)
+				assert.Nil(t, client)
+			})
+		})
+	}
+}
+
+func TestNewRedisClient_UnreachableServer(t *testing.T) {
+	// Nothing listens on port 1, so the connection is refused straight away
+	options := RedisOptions{DB: -1, DialTimeout: 500 * time.Millisecond}
+
+	assert.NotPanics(t, func() {
+		client, err := NewRedisClient("redis://127.0.0.1:1", options)
+		assert.ErrorContains(t, err, "failed to connect to redis")
+		assert.Nil(t, client)
+	})
+}
+
+// serveRedisPings answers every command on the listener with PONG, which is
+// all the client needs to consider the server up
+func serveRedisPings(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			reader := bufio.NewReader(conn)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				// Commands arrive as arrays of bulk strings, so PING shows up
+				// as a line of its own
+				if strings.EqualFold(strings.TrimSpace(line), "PING") {
+					if _, err := conn.Write([]byte("+PONG\r\n")); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+}
+
+func TestNewRedisClient_RetriesUntilServerIsUp(t *testing.T) {
+	// Reserve a free port, then leave it closed until the server comes up
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := reserved.Addr().String()
+	require.NoError(t, reserved.Close())
+
+	started := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			close(started)
+			return
+		}
+		started <- listener
+		serveRedisPings(listener)
+	}()
+	t.Cleanup(func() {
+		if listener, ok := <-started; ok {
+			listener.Close()
+		}
+	})
+
+	options := RedisOptions{DB: -1, DialTimeout: 200 * time.Millisecond, ConnectRetries: 6, ConnectBackoff: 50 * time.Millisecond}
+	client, err := NewRedisClient("redis://"+address, options)
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.Ping(context.Background()).Err())
+}
+
+func TestNewRedisClient_GivesUpAfterRetries(t *testing.T) {
+	options := RedisOptions{DB: -1, DialTimeout: 100 * time.Millisecond, ConnectRetries: 2, ConnectBackoff: 10 * time.Millisecond}
+
+	started := time.Now()
+	client, err := NewRedisClient("redis://127.0.0.1:1", options)
+	assert.ErrorContains(t, err, "failed to connect to redis")
+	assert.Nil(t, client)
+	// Two retries wait 10ms and then 20ms
+	assert.GreaterOrEqual(t, time.Since(started), 30*time.Millisecond)
+}
@@ -2,8 +2,14 @@ package database
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrFieldNotEditable is returned when a partial update names a column that
+// cannot be changed that way
+var ErrFieldNotEditable = errors.New("field cannot be edited")
+
 // Repository interface defines all database operations
 type Repository interface {
 	UserRepository
@@ -11,6 +17,10 @@ type Repository interface {
 	GameRepository
 	SessionRepository
 	StatsRepository
+
+	// WithTransaction runs fn against a repository bound to a single
+	// transaction, committing if fn returns nil and rolling back otherwise
+	WithTransaction(ctx context.Context, fn func(tx Repository) error) error
 }
 
 // UserRepository interface for user operations
@@ -19,8 +29,11 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, id string) (*User, error)
 	GetUserByGoogleID(ctx context.Context, googleID string) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]User, error)
 	UpdateUser(ctx context.Context, user *User) error
+	UpdateUserFields(ctx context.Context, id string, fields map[string]interface{}) error
 	DeleteUser(ctx context.Context, id string) error
+	AnonymizeUser(ctx context.Context, id string) error
 }
 
 // RoomRepository interface for room operations
@@ -28,7 +41,11 @@ type RoomRepository interface {
 	CreateRoom(ctx context.Context, room *Room) error
 	GetRoomByID(ctx context.Context, id string) (*Room, error)
 	GetRoomsByStatus(ctx context.Context, status string, limit, offset int) ([]Room, error)
+	GetIdleRooms(ctx context.Context, status string, idleSince time.Time, limit int) ([]Room, error)
 	UpdateRoom(ctx context.Context, room *Room) error
+	UpdateRoomStatus(ctx context.Context, id, status string) error
+	TransitionRoomStatus(ctx context.Context, id, from, to string) (bool, error)
+	UpdateRoomPlayerCount(ctx context.Context, id string, count int) error
 	DeleteRoom(ctx context.Context, id string) error
 	AddRoomParticipant(ctx context.Context, participant *RoomParticipant) error
 	RemoveRoomParticipant(ctx context.Context, roomID, userID string) error
@@ -41,9 +58,11 @@ type GameRepository interface {
 	GetGameByID(ctx context.Context, id string) (*Game, error)
 	GetGameByRoomID(ctx context.Context, roomID string) (*Game, error)
 	UpdateGame(ctx context.Context, game *Game) error
+	LinkRematchRoom(ctx context.Context, gameID, roomID string) (bool, error)
 	DeleteGame(ctx context.Context, id string) error
 	GetUserGameHistory(ctx context.Context, userID string, limit, offset int) ([]Game, error)
 	AddGameParticipant(ctx context.Context, participant *GameParticipant) error
+	UpdateGameParticipant(ctx context.Context, participant *GameParticipant) error
 	GetGameParticipants(ctx context.Context, gameID string) ([]GameParticipant, error)
 }
 
@@ -54,7 +73,7 @@ type SessionRepository interface {
 	GetSessionsByUserID(ctx context.Context, userID string) ([]Session, error)
 	UpdateSession(ctx context.Context, session *Session) error
 	DeleteSession(ctx context.Context, id string) error
-	DeleteExpiredSessions(ctx context.Context) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
 }
 
 // StatsRepository interface for statistics operations
@@ -65,4 +84,32 @@ type StatsRepository interface {
 	GetLeaderboard(ctx context.Context, limit int) ([]UserStats, error)
 	GetTopPlayersByWins(ctx context.Context, limit int) ([]UserStats, error)
 	GetTopPlayersByDeclarerWins(ctx context.Context, limit int) ([]UserStats, error)
-}
\ No newline at end of file
+	GetTopPlayersByPoints(ctx context.Context, limit int) ([]UserStats, error)
+	GetPlayerRating(ctx context.Context, userID string) (int, error)
+	GetHeadToHead(ctx context.Context, userA, userB string) (HeadToHead, error)
+	GetUserGameAggregates(ctx context.Context, userID string) (GameAggregates, error)
+}
+
+// HeadToHead is the record of the games two players have played together
+type HeadToHead struct {
+	UserA     string `json:"user_a"`
+	UserB     string `json:"user_b"`
+	Games     int    `json:"games"`      // Games where both players took part
+	SameTeam  int    `json:"same_team"`  // Games played as partners
+	Opposing  int    `json:"opposing"`   // Games played on opposite teams
+	UserAWins int    `json:"user_a_wins"` // Finished opposing games won by UserA
+	UserBWins int    `json:"user_b_wins"` // Finished opposing games won by UserB
+}
+
+// GameAggregates totals a player's completed games straight from their game
+// participations. Unlike UserStats it is computed on every read, so it is
+// always in line with the recorded games.
+type GameAggregates struct {
+	UserID                string  `json:"user_id"`
+	GamesCompleted        int     `json:"games_completed"`   // Finished games, leaving out aborted ones
+	GamesWon              int     `json:"games_won"`
+	GamesAsDeclarer       int     `json:"games_as_declarer"`
+	GamesAsPartner        int     `json:"games_as_partner"`
+	TotalPointsCaptured   int     `json:"total_points_captured"`
+	AveragePointsCaptured float64 `json:"average_points_captured"` // Per completed game
+}
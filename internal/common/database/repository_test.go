@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -20,7 +21,7 @@ func setupTestDB(t *testing.T) (*gorm.DB, Repository) {
 	require.NoError(t, err)
 
 	// Run migrations
-	migrationManager := NewMigrationManager(db)
+	migrationManager := NewMigrationManager(db, nil)
 	err = migrationManager.RunMigrations(context.Background())
 	require.NoError(t, err)
 
@@ -97,6 +98,167 @@ func TestUserRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "Updated Name", retrieved.Name)
 	})
+
+	t.Run("GetUsersByIDs", func(t *testing.T) {
+		first := &User{GoogleID: "batch_google_id_1", Email: "batch1@example.com", Name: "Batch User 1"}
+		second := &User{GoogleID: "batch_google_id_2", Email: "batch2@example.com", Name: "Batch User 2"}
+		require.NoError(t, repo.CreateUser(ctx, first))
+		require.NoError(t, repo.CreateUser(ctx, second))
+
+		users, err := repo.GetUsersByIDs(ctx, []string{first.ID, second.ID, "missing-user"})
+		assert.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, []string{users[0].ID, users[1].ID})
+
+		users, err = repo.GetUsersByIDs(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+	})
+}
+
+func TestUserRepository_UpdateUserFields(t *testing.T) {
+	_, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	user := &User{
+		GoogleID: "partial_google_id",
+		Email:    "partial@example.com",
+		Name:     "Old Name",
+		Avatar:   "https://example.com/avatar.jpg",
+		Role:     RoleAdmin,
+	}
+	require.NoError(t, repo.CreateUser(ctx, user))
+	before, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, repo.UpdateUserFields(ctx, user.ID, map[string]interface{}{"name": "New Name"}))
+
+	updated, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "New Name", updated.Name)
+	assert.Equal(t, "https://example.com/avatar.jpg", updated.Avatar)
+	assert.Equal(t, RoleAdmin, updated.Role)
+	assert.True(t, updated.UpdatedAt.After(before.UpdatedAt))
+
+	err = repo.UpdateUserFields(ctx, user.ID, map[string]interface{}{"role": RolePlayer})
+	assert.ErrorIs(t, err, ErrFieldNotEditable)
+
+	err = repo.UpdateUserFields(ctx, "missing-user", map[string]interface{}{"name": "Nobody"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUserRepository_AnonymizeUser(t *testing.T) {
+	db, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	user := &User{
+		GoogleID: "anon_google_id",
+		Email:    "anon@example.com",
+		Name:     "Soon Anonymous",
+		Avatar:   "https://example.com/anon.jpg",
+	}
+	require.NoError(t, repo.CreateUser(ctx, user))
+
+	room := &Room{Name: "History Room", HostID: user.ID, Status: "finished"}
+	require.NoError(t, repo.CreateRoom(ctx, room))
+	game := &Game{RoomID: room.ID, Contract: 120}
+	require.NoError(t, repo.CreateGame(ctx, game))
+	require.NoError(t, repo.AddGameParticipant(ctx, &GameParticipant{
+		GameID:         game.ID,
+		UserID:         user.ID,
+		Role:           "declarer",
+		PointsCaptured: 35,
+	}))
+	require.NoError(t, repo.CreateSession(ctx, &Session{
+		UserID:    user.ID,
+		Token:     "anon_token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	t.Run("AnonymizeUser", func(t *testing.T) {
+		err := repo.AnonymizeUser(ctx, user.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("LookupsNoLongerFindUser", func(t *testing.T) {
+		_, err := repo.GetUserByEmail(ctx, "anon@example.com")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		_, err = repo.GetUserByGoogleID(ctx, "anon_google_id")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		_, err = repo.GetUserByID(ctx, user.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("PersonalDataScrubbed", func(t *testing.T) {
+		var stored User
+		err := db.Unscoped().First(&stored, "id = ?", user.ID).Error
+		require.NoError(t, err)
+		assert.True(t, stored.DeletedAt.Valid)
+		assert.NotContains(t, stored.Email, "anon@example.com")
+		assert.NotEqual(t, "anon_google_id", stored.GoogleID)
+		assert.Equal(t, "Deleted User", stored.Name)
+		assert.Empty(t, stored.Avatar)
+	})
+
+	t.Run("SessionsPurged", func(t *testing.T) {
+		sessions, err := repo.GetSessionsByUserID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("GameParticipationKept", func(t *testing.T) {
+		participants, err := repo.GetGameParticipants(ctx, game.ID)
+		assert.NoError(t, err)
+		require.Len(t, participants, 1)
+		assert.Equal(t, user.ID, participants[0].UserID)
+		assert.Equal(t, 35, participants[0].PointsCaptured)
+	})
+
+	t.Run("EmailCanBeReused", func(t *testing.T) {
+		err := repo.CreateUser(ctx, &User{
+			GoogleID: "anon_google_id",
+			Email:    "anon@example.com",
+			Name:     "New Account",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		err := repo.AnonymizeUser(ctx, "missing-user")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestRepository_WithTransaction(t *testing.T) {
+	_, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	t.Run("CommitsOnSuccess", func(t *testing.T) {
+		err := repo.WithTransaction(ctx, func(tx Repository) error {
+			return tx.CreateUser(ctx, &User{GoogleID: "tx-google-1", Email: "committed@example.com", Name: "Committed"})
+		})
+		require.NoError(t, err)
+
+		_, err = repo.GetUserByEmail(ctx, "committed@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("RollsBackOnError", func(t *testing.T) {
+		failure := fmt.Errorf("second write failed")
+		err := repo.WithTransaction(ctx, func(tx Repository) error {
+			if err := tx.CreateUser(ctx, &User{GoogleID: "tx-google-2", Email: "rolledback@example.com", Name: "Rolled Back"}); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		_, err = repo.GetUserByEmail(ctx, "rolledback@example.com")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
 }
 
 func TestRoomRepository(t *testing.T) {
@@ -171,6 +333,30 @@ func TestRoomRepository(t *testing.T) {
 		assert.Len(t, participants, 1)
 		assert.Equal(t, user.ID, participants[0].UserID)
 	})
+
+	t.Run("TransitionRoomStatus", func(t *testing.T) {
+		room := &Room{
+			Name:           "Test Room 4",
+			HostID:         user.ID,
+			MaxPlayers:     4,
+			CurrentPlayers: 4,
+			Status:         RoomStatusWaiting,
+		}
+		require.NoError(t, repo.CreateRoom(ctx, room))
+
+		moved, err := repo.TransitionRoomStatus(ctx, room.ID, RoomStatusWaiting, RoomStatusPlaying)
+		require.NoError(t, err)
+		assert.True(t, moved)
+
+		// A second start finds the room already playing
+		moved, err = repo.TransitionRoomStatus(ctx, room.ID, RoomStatusWaiting, RoomStatusPlaying)
+		require.NoError(t, err)
+		assert.False(t, moved)
+
+		retrieved, err := repo.GetRoomByID(ctx, room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, RoomStatusPlaying, retrieved.Status)
+	})
 }
 
 func TestGameRepository(t *testing.T) {
@@ -258,6 +444,53 @@ func TestGameRepository(t *testing.T) {
 		assert.Len(t, participants, 1)
 		assert.Equal(t, "declarer", participants[0].Role)
 	})
+
+	t.Run("UpdateGameParticipant", func(t *testing.T) {
+		game := &Game{
+			RoomID:   room.ID,
+			Contract: 105,
+		}
+		err := repo.CreateGame(ctx, game)
+		require.NoError(t, err)
+
+		participant := &GameParticipant{
+			GameID:   game.ID,
+			UserID:   user.ID,
+			Position: 1,
+			Role:     "defender",
+		}
+		err = repo.AddGameParticipant(ctx, participant)
+		require.NoError(t, err)
+
+		participant.PointsCaptured = 45
+		err = repo.UpdateGameParticipant(ctx, participant)
+		assert.NoError(t, err)
+
+		participants, err := repo.GetGameParticipants(ctx, game.ID)
+		assert.NoError(t, err)
+		assert.Len(t, participants, 1)
+		assert.Equal(t, 45, participants[0].PointsCaptured)
+		assert.Equal(t, "defender", participants[0].Role)
+	})
+
+	t.Run("LinkRematchRoom", func(t *testing.T) {
+		game := &Game{RoomID: room.ID}
+		require.NoError(t, repo.CreateGame(ctx, game))
+
+		linked, err := repo.LinkRematchRoom(ctx, game.ID, "rematch-1")
+		require.NoError(t, err)
+		assert.True(t, linked)
+
+		// A second room cannot replace the one already linked
+		linked, err = repo.LinkRematchRoom(ctx, game.ID, "rematch-2")
+		require.NoError(t, err)
+		assert.False(t, linked)
+
+		retrieved, err := repo.GetGameByID(ctx, game.ID)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved.RematchRoomID)
+		assert.Equal(t, "rematch-1", *retrieved.RematchRoomID)
+	})
 }
 
 func TestSessionRepository(t *testing.T) {
@@ -321,8 +554,9 @@ func TestSessionRepository(t *testing.T) {
 		require.NoError(t, err)
 
 		// Delete expired sessions
-		err = repo.DeleteExpiredSessions(ctx)
+		deleted, err := repo.DeleteExpiredSessions(ctx)
 		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
 
 		// Verify expired session is gone
 		_, err = repo.GetSessionByToken(ctx, expiredSession.Token)
@@ -424,6 +658,172 @@ func TestStatsRepository(t *testing.T) {
 		assert.Equal(t, 25, retrieved.GamesPlayed)
 		assert.Equal(t, 15, retrieved.GamesWon)
 	})
+
+	t.Run("GetPlayerRating", func(t *testing.T) {
+		testUser := &User{
+			GoogleID: "stats_user_google_id_4",
+			Email:    "statsuser4@example.com",
+			Name:     "Stats User 4",
+		}
+		err := repo.CreateUser(ctx, testUser)
+		require.NoError(t, err)
+
+		err = repo.CreateUserStats(ctx, &UserStats{UserID: testUser.ID})
+		require.NoError(t, err)
+
+		rating, err := repo.GetPlayerRating(ctx, testUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1500, rating)
+
+		_, err = repo.GetPlayerRating(ctx, "missing-user")
+		assert.Error(t, err)
+	})
+}
+
+func TestStatsRepository_GetTopPlayersByPoints(t *testing.T) {
+	_, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	for i, points := range []int{800, 2400, 1500} {
+		user := &User{
+			GoogleID: fmt.Sprintf("points_google_id_%d", i),
+			Email:    fmt.Sprintf("points%d@example.com", i),
+			Name:     fmt.Sprintf("Points %d", points),
+		}
+		require.NoError(t, repo.CreateUser(ctx, user))
+		require.NoError(t, repo.CreateUserStats(ctx, &UserStats{UserID: user.ID, GamesPlayed: 5, TotalPoints: points}))
+	}
+
+	top, err := repo.GetTopPlayersByPoints(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, 2400, top[0].TotalPoints)
+	assert.Equal(t, 1500, top[1].TotalPoints)
+	assert.Equal(t, "Points 2400", top[0].User.Name, "the user is preloaded")
+}
+
+func TestStatsRepository_GetHeadToHead(t *testing.T) {
+	_, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	alice := &User{GoogleID: "h2h_alice", Email: "alice@example.com", Name: "Alice"}
+	bob := &User{GoogleID: "h2h_bob", Email: "bob@example.com", Name: "Bob"}
+	require.NoError(t, repo.CreateUser(ctx, alice))
+	require.NoError(t, repo.CreateUser(ctx, bob))
+
+	room := &Room{Name: "Rivalry Room", HostID: alice.ID, Status: "waiting"}
+	require.NoError(t, repo.CreateRoom(ctx, room))
+
+	type seat struct {
+		position int
+		role     string
+	}
+	seedGame := func(winnerTeam *string, aliceSeat, bobSeat *seat) {
+		game := &Game{RoomID: room.ID, Contract: 120, WinnerTeam: winnerTeam}
+		require.NoError(t, repo.CreateGame(ctx, game))
+		if aliceSeat != nil {
+			require.NoError(t, repo.AddGameParticipant(ctx, &GameParticipant{
+				GameID: game.ID, UserID: alice.ID, Position: aliceSeat.position, Role: aliceSeat.role,
+			}))
+		}
+		if bobSeat != nil {
+			require.NoError(t, repo.AddGameParticipant(ctx, &GameParticipant{
+				GameID: game.ID, UserID: bob.ID, Position: bobSeat.position, Role: bobSeat.role,
+			}))
+		}
+	}
+
+	// Partners: Alice declares with Bob sitting opposite
+	seedGame(stringPtr("declarer"), &seat{0, "declarer"}, &seat{2, "partner"})
+	// Opponents: Alice declares and makes the contract
+	seedGame(stringPtr("declarer"), &seat{0, "declarer"}, &seat{1, "defender"})
+	// Opponents: Bob declares and makes the contract
+	seedGame(stringPtr("declarer"), &seat{1, "defender"}, &seat{0, "declarer"})
+	// Opponents: Alice defends and sets Bob's side
+	seedGame(stringPtr("defenders"), &seat{3, "defender"}, &seat{2, "partner"})
+	// Partners: Alice calls Bob, sitting beside her, as her partner
+	seedGame(stringPtr("defenders"), &seat{0, "declarer"}, &seat{1, "partner"})
+	// A game whose roles have not been recorded yet
+	seedGame(nil, &seat{0, ""}, &seat{3, ""})
+	// Alice without Bob
+	seedGame(stringPtr("declarer"), &seat{0, "declarer"}, nil)
+
+	t.Run("TalliesGamesTogether", func(t *testing.T) {
+		record, err := repo.GetHeadToHead(ctx, alice.ID, bob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, alice.ID, record.UserA)
+		assert.Equal(t, bob.ID, record.UserB)
+		assert.Equal(t, 6, record.Games)
+		assert.Equal(t, 2, record.SameTeam)
+		assert.Equal(t, 3, record.Opposing)
+		assert.Equal(t, 2, record.UserAWins)
+		assert.Equal(t, 1, record.UserBWins)
+	})
+
+	t.Run("Symmetric", func(t *testing.T) {
+		record, err := repo.GetHeadToHead(ctx, bob.ID, alice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, record.Games)
+		assert.Equal(t, 1, record.UserAWins)
+		assert.Equal(t, 2, record.UserBWins)
+	})
+
+	t.Run("NeverPlayedTogether", func(t *testing.T) {
+		record, err := repo.GetHeadToHead(ctx, alice.ID, "stranger")
+		assert.NoError(t, err)
+		assert.Zero(t, record.Games)
+	})
+}
+
+func TestStatsRepository_GetUserGameAggregates(t *testing.T) {
+	_, repo := setupTestDB(t)
+	ctx := context.Background()
+
+	alice := &User{GoogleID: "aggregates_alice", Email: "aggregates@example.com", Name: "Alice"}
+	require.NoError(t, repo.CreateUser(ctx, alice))
+	room := &Room{Name: "Aggregates Room", HostID: alice.ID, Status: "waiting"}
+	require.NoError(t, repo.CreateRoom(ctx, room))
+
+	endedAt := time.Now()
+	seedGame := func(winnerTeam *string, ended *time.Time, aborted bool, role string, points int) {
+		game := &Game{RoomID: room.ID, Contract: 120, WinnerTeam: winnerTeam, EndedAt: ended, Aborted: aborted}
+		require.NoError(t, repo.CreateGame(ctx, game))
+		require.NoError(t, repo.AddGameParticipant(ctx, &GameParticipant{
+			GameID: game.ID, UserID: alice.ID, Role: role, PointsCaptured: points,
+		}))
+	}
+
+	// Declared and made the contract
+	seedGame(stringPtr("declarer"), &endedAt, false, "declarer", 40)
+	// Partnered a failed contract
+	seedGame(stringPtr("defenders"), &endedAt, false, "partner", 10)
+	// Defended and set the contract
+	seedGame(stringPtr("defenders"), &endedAt, false, "defender", 70)
+	// Defended a made contract
+	seedGame(stringPtr("declarer"), &endedAt, false, "defender", 0)
+	// Games that did not complete are left out
+	seedGame(nil, nil, false, "declarer", 25)
+	seedGame(nil, &endedAt, true, "defender", 35)
+
+	t.Run("SumsCompletedGames", func(t *testing.T) {
+		aggregates, err := repo.GetUserGameAggregates(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, alice.ID, aggregates.UserID)
+		assert.Equal(t, 4, aggregates.GamesCompleted)
+		assert.Equal(t, 2, aggregates.GamesWon)
+		assert.Equal(t, 1, aggregates.GamesAsDeclarer)
+		assert.Equal(t, 1, aggregates.GamesAsPartner)
+		assert.Equal(t, 120, aggregates.TotalPointsCaptured)
+		assert.InDelta(t, 30.0, aggregates.AveragePointsCaptured, 0.001)
+	})
+
+	t.Run("NoGames", func(t *testing.T) {
+		aggregates, err := repo.GetUserGameAggregates(ctx, "stranger")
+		require.NoError(t, err)
+		assert.Equal(t, "stranger", aggregates.UserID)
+		assert.Zero(t, aggregates.GamesCompleted)
+		assert.Zero(t, aggregates.AveragePointsCaptured)
+	})
 }
 
 // Helper function to create string pointer
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"chinese-bridge-game/pkg/logging"
+)
+
+// cachedUserRepository reads users through the cache, falling back to the
+// wrapped repository on a miss, and drops a user's cached profile whenever
+// the user or their stats change. The cache is best effort: its failures are
+// logged and the database is used instead.
+type cachedUserRepository struct {
+	Repository
+	cache  Cache
+	logger *slog.Logger
+	// pending collects the users changed inside a transaction, whose profiles
+	// are invalidated once it has finished
+	pending *[]string
+}
+
+// NewCachedUserRepository wraps repository with a cache-aside layer for user
+// profile reads. A nil logger uses the default logger.
+func NewCachedUserRepository(repository Repository, cache Cache, logger *slog.Logger) Repository {
+	return &cachedUserRepository{
+		Repository: repository,
+		cache:      cache,
+		logger:     logging.OrDefault(logger),
+	}
+}
+
+// GetUserByID returns the cached user, loading and caching them on a miss
+func (r *cachedUserRepository) GetUserByID(ctx context.Context, id string) (*User, error) {
+	if user, ok := r.cached(ctx, id); ok {
+		return user, nil
+	}
+
+	user, err := r.Repository.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cache.SetUserProfile(ctx, id, user, DefaultUserProfileTTL); err != nil {
+		r.logger.Warn("Failed to cache user profile", "user_id", id, "error", err)
+	}
+	return user, nil
+}
+
+// cached returns the user's cached profile if the cache holds a readable one
+func (r *cachedUserRepository) cached(ctx context.Context, id string) (*User, bool) {
+	data, err := r.cache.GetUserProfile(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			r.logger.Warn("Failed to read cached user profile", "user_id", id, "error", err)
+		}
+		return nil, false
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		r.logger.Warn("Failed to decode cached user profile", "user_id", id, "error", err)
+		return nil, false
+	}
+	return &user, true
+}
+
+// UpdateUser saves the user and invalidates their cached profile
+func (r *cachedUserRepository) UpdateUser(ctx context.Context, user *User) error {
+	if err := r.Repository.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, user.ID)
+	return nil
+}
+
+// UpdateUserFields updates some of the user's columns and invalidates their
+// cached profile
+func (r *cachedUserRepository) UpdateUserFields(ctx context.Context, id string, fields map[string]interface{}) error {
+	if err := r.Repository.UpdateUserFields(ctx, id, fields); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// DeleteUser deletes the user and invalidates their cached profile
+func (r *cachedUserRepository) DeleteUser(ctx context.Context, id string) error {
+	if err := r.Repository.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// AnonymizeUser anonymizes the user and invalidates their cached profile
+func (r *cachedUserRepository) AnonymizeUser(ctx context.Context, id string) error {
+	if err := r.Repository.AnonymizeUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// UpdateUserStats saves the stats and invalidates the cached profile they are
+// loaded with
+func (r *cachedUserRepository) UpdateUserStats(ctx context.Context, stats *UserStats) error {
+	if err := r.Repository.UpdateUserStats(ctx, stats); err != nil {
+		return err
+	}
+	r.invalidate(ctx, stats.UserID)
+	return nil
+}
+
+// WithTransaction runs fn in a transaction and invalidates the profiles of
+// the users it changed once the transaction has finished, so that a profile
+// read before the commit cannot be left cached
+func (r *cachedUserRepository) WithTransaction(ctx context.Context, fn func(tx Repository) error) error {
+	// A nested transaction leaves the invalidations to the outermost one
+	pending := r.pending
+	if pending == nil {
+		pending = new([]string)
+	}
+
+	err := r.Repository.WithTransaction(ctx, func(tx Repository) error {
+		return fn(&cachedUserRepository{Repository: tx, cache: r.cache, logger: r.logger, pending: pending})
+	})
+	if r.pending == nil {
+		for _, userID := range *pending {
+			r.evict(ctx, userID)
+		}
+	}
+	return err
+}
+
+// invalidate drops a user's cached profile, or defers it to the end of the
+// enclosing transaction
+func (r *cachedUserRepository) invalidate(ctx context.Context, userID string) {
+	if r.pending != nil {
+		*r.pending = append(*r.pending, userID)
+		return
+	}
+	r.evict(ctx, userID)
+}
+
+// evict drops a user's cached profile
+func (r *cachedUserRepository) evict(ctx context.Context, userID string) {
+	if err := r.cache.DeleteUserProfile(ctx, userID); err != nil {
+		r.logger.Warn("Failed to invalidate cached user profile", "user_id", userID, "error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryProfileCache holds user profiles in memory. Other cache methods are
+// not used by the cached repository and panic if called.
+type memoryProfileCache struct {
+	Cache
+	mu       sync.Mutex
+	profiles map[string]string
+	ttls     map[string]time.Duration
+}
+
+func newMemoryProfileCache() *memoryProfileCache {
+	return &memoryProfileCache{profiles: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (c *memoryProfileCache) SetUserProfile(ctx context.Context, userID string, profile interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiles[userID] = string(data)
+	c.ttls[userID] = ttl
+	return nil
+}
+
+func (c *memoryProfileCache) GetUserProfile(ctx context.Context, userID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.profiles[userID]
+	if !ok {
+		return "", ErrCacheMiss
+	}
+	return data, nil
+}
+
+func (c *memoryProfileCache) DeleteUserProfile(ctx context.Context, userID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.profiles, userID)
+	return nil
+}
+
+// countingUserRepository counts the user reads that reach the database
+type countingUserRepository struct {
+	Repository
+	reads int
+}
+
+func (r *countingUserRepository) GetUserByID(ctx context.Context, id string) (*User, error) {
+	r.reads++
+	return r.Repository.GetUserByID(ctx, id)
+}
+
+func setupCachedUserRepository(t *testing.T) (Repository, *countingUserRepository, *memoryProfileCache, *User) {
+	_, repo := setupTestDB(t)
+	user := &User{GoogleID: "google-1", Email: "alice@example.com", Name: "Alice", Avatar: "https://example.com/alice.png"}
+	require.NoError(t, repo.CreateUser(context.Background(), user))
+
+	counting := &countingUserRepository{Repository: repo}
+	cache := newMemoryProfileCache()
+	return NewCachedUserRepository(counting, cache, nil), counting, cache, user
+}
+
+func TestCachedUserRepository_MissPopulatesCache(t *testing.T) {
+	repo, counting, cache, user := setupCachedUserRepository(t)
+	ctx := context.Background()
+
+	loaded, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", loaded.Name)
+	assert.Equal(t, 1, counting.reads)
+
+	data, err := cache.GetUserProfile(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, data, "alice@example.com")
+	assert.Equal(t, DefaultUserProfileTTL, cache.ttls[user.ID])
+}
+
+func TestCachedUserRepository_HitSkipsDatabase(t *testing.T) {
+	repo, counting, _, user := setupCachedUserRepository(t)
+	ctx := context.Background()
+
+	_, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	loaded, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, counting.reads)
+	assert.Equal(t, user.ID, loaded.ID)
+	assert.Equal(t, "https://example.com/alice.png", loaded.Avatar)
+}
+
+func TestCachedUserRepository_UpdateInvalidates(t *testing.T) {
+	repo, counting, cache, user := setupCachedUserRepository(t)
+	ctx := context.Background()
+
+	loaded, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	loaded.Name = "Alice Liddell"
+	require.NoError(t, repo.UpdateUser(ctx, loaded))
+	_, err = cache.GetUserProfile(ctx, user.ID)
+	assert.ErrorIs(t, err, ErrCacheMiss)
+
+	reloaded, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Liddell", reloaded.Name)
+	assert.Equal(t, 2, counting.reads)
+}
+
+func TestCachedUserRepository_TransactionInvalidatesAfterCommit(t *testing.T) {
+	repo, _, cache, user := setupCachedUserRepository(t)
+	ctx := context.Background()
+
+	err := repo.WithTransaction(ctx, func(tx Repository) error {
+		if err := tx.UpdateUserStats(ctx, &UserStats{UserID: user.ID, Rating: 1516}); err != nil {
+			return err
+		}
+		// A read inside the transaction caches the profile again
+		_, err := tx.GetUserByID(ctx, user.ID)
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = cache.GetUserProfile(ctx, user.ID)
+	assert.ErrorIs(t, err, ErrCacheMiss)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "fmt"
+
+// Action is a kind of move a player can make
+type Action string
+
+// Player actions
+const (
+	ActionBid           Action = "bid"
+	ActionPass          Action = "pass"
+	ActionUndoBid       Action = "undo_bid"
+	ActionDeclareTrump  Action = "declare_trump"
+	ActionCallPartner   Action = "call_partner"
+	ActionExchangeKitty Action = "exchange_kitty"
+	ActionPlay          Action = "play"
+)
+
+// AllowedActions returns the actions the player may take now, given the phase
+// and whose turn it is. Players waiting for their turn get none, except that
+// the last bidder may take back their bid when the rules allow it.
+func (gs *GameState) AllowedActions(playerID string) ([]Action, error) {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	actions := make([]Action, 0, 3)
+	switch gs.Phase {
+	case PhaseBidding:
+		if player.Position == gs.CurrentPlayerTurn && !player.HasPassed {
+			if len(gs.GetValidBids()) > 0 {
+				actions = append(actions, ActionBid)
+			}
+			actions = append(actions, ActionPass)
+		}
+		if gs.Rules.AllowBidUndo && len(gs.BidHistory) > 0 && gs.BidHistory[len(gs.BidHistory)-1].PlayerID == playerID {
+			actions = append(actions, ActionUndoBid)
+		}
+	case PhaseTrumpDeclaration, PhaseKittyExchange:
+		if gs.Declarer == nil || player.Position != *gs.Declarer {
+			break
+		}
+		mustCall := gs.Rules.Partnership == CalledCard && gs.CalledCard == nil
+		if gs.Phase == PhaseTrumpDeclaration {
+			actions = append(actions, ActionDeclareTrump)
+		}
+		if mustCall {
+			actions = append(actions, ActionCallPartner)
+		} else if gs.Phase == PhaseKittyExchange {
+			actions = append(actions, ActionExchangeKitty)
+		}
+	case PhasePlaying:
+		if player.Position == gs.CurrentPlayerTurn {
+			actions = append(actions, ActionPlay)
+		}
+	}
+	return actions, nil
+}
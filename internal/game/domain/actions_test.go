@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newAuctionWonByNorth deals a game with the given rules where North bids
+// 120 and everyone else passes, leaving North to declare trump
+func newAuctionWonByNorth(t *testing.T, rules GameRules) *GameState {
+	t.Helper()
+
+	gs := newTestGameStateWithRules(t, rules)
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+	return gs
+}
+
+func TestGameState_AllowedActions(t *testing.T) {
+	calledCard := DefaultRules()
+	calledCard.Partnership = CalledCard
+	undo := DefaultRules()
+	undo.AllowBidUndo = true
+
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T) *GameState
+		active   string
+		want     []Action
+		inactive map[string][]Action
+	}{
+		{
+			name:   "Waiting",
+			setup:  newTestGameState,
+			active: "north",
+			want:   []Action{},
+		},
+		{
+			name:     "Bidding",
+			setup:    func(t *testing.T) *GameState { return newTestGameStateWithRules(t, DefaultRules()) },
+			active:   "north",
+			want:     []Action{ActionBid, ActionPass},
+			inactive: map[string][]Action{"east": {}},
+		},
+		{
+			name: "Bidding at the minimum bid",
+			setup: func(t *testing.T) *GameState {
+				gs := newTestGameStateWithRules(t, DefaultRules())
+				gs.CurrentBid = gs.Rules.MinBid
+				return gs
+			},
+			active: "north",
+			want:   []Action{ActionPass},
+		},
+		{
+			name: "Bidding after a bid that can be undone",
+			setup: func(t *testing.T) *GameState {
+				gs := newTestGameStateWithRules(t, undo)
+				if err := gs.PlaceBid("north", 120); err != nil {
+					t.Fatalf("PlaceBid() error = %v", err)
+				}
+				return gs
+			},
+			active:   "east",
+			want:     []Action{ActionBid, ActionPass},
+			inactive: map[string][]Action{"north": {ActionUndoBid}, "south": {}},
+		},
+		{
+			name:     "Trump declaration",
+			setup:    func(t *testing.T) *GameState { return newAuctionWonByNorth(t, DefaultRules()) },
+			active:   "north",
+			want:     []Action{ActionDeclareTrump},
+			inactive: map[string][]Action{"east": {}},
+		},
+		{
+			name:   "Trump declaration with a card to call",
+			setup:  func(t *testing.T) *GameState { return newAuctionWonByNorth(t, calledCard) },
+			active: "north",
+			want:   []Action{ActionDeclareTrump, ActionCallPartner},
+		},
+		{
+			name: "Kitty exchange",
+			setup: func(t *testing.T) *GameState {
+				gs := newAuctionWonByNorth(t, DefaultRules())
+				if err := gs.DeclareTrump("north", Hearts); err != nil {
+					t.Fatalf("DeclareTrump() error = %v", err)
+				}
+				return gs
+			},
+			active:   "north",
+			want:     []Action{ActionExchangeKitty},
+			inactive: map[string][]Action{"south": {}},
+		},
+		{
+			name: "Kitty exchange before calling a card",
+			setup: func(t *testing.T) *GameState {
+				gs := newAuctionWonByNorth(t, calledCard)
+				if err := gs.DeclareTrump("north", Hearts); err != nil {
+					t.Fatalf("DeclareTrump() error = %v", err)
+				}
+				return gs
+			},
+			active: "north",
+			want:   []Action{ActionCallPartner},
+		},
+		{
+			name:     "Playing",
+			setup:    newPlayingGameState,
+			active:   "north",
+			want:     []Action{ActionPlay},
+			inactive: map[string][]Action{"east": {}, "west": {}},
+		},
+		{
+			name: "Ended",
+			setup: func(t *testing.T) *GameState {
+				gs := newPlayingGameState(t)
+				gs.Phase = PhaseEnded
+				return gs
+			},
+			active: "north",
+			want:   []Action{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := tt.setup(t)
+
+			got, err := gs.AllowedActions(tt.active)
+			if err != nil {
+				t.Fatalf("AllowedActions(%s) error = %v", tt.active, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllowedActions(%s) = %v, want %v", tt.active, got, tt.want)
+			}
+
+			for playerID, want := range tt.inactive {
+				got, err := gs.AllowedActions(playerID)
+				if err != nil {
+					t.Fatalf("AllowedActions(%s) error = %v", playerID, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("AllowedActions(%s) = %v, want %v", playerID, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGameState_AllowedActions_UnknownPlayer(t *testing.T) {
+	gs := newPlayingGameState(t)
+	if _, err := gs.AllowedActions("stranger"); err == nil {
+		t.Error("Expected error for a player not in the game")
+	}
+}
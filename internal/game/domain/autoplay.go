@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AutoAct performs a simple default action for the player whose turn it is.
+// It is used when a disconnected player's turns are taken over by the server:
+// bids are passed, the longest suit is declared trump, the lowest cards are
+// discarded to the kitty after calling an ace for a partner if needed, and
+// the lowest legal formation is played.
+func (gs *GameState) AutoAct() error {
+	player := gs.GetCurrentPlayer()
+	if player == nil {
+		return fmt.Errorf("no current player")
+	}
+
+	switch gs.Phase {
+	case PhaseBidding:
+		return gs.passBid(player.ID, true)
+	case PhaseTrumpDeclaration:
+		return gs.DeclareTrump(player.ID, longestSuit(player.Hand))
+	case PhaseKittyExchange:
+		if gs.Rules.Partnership == CalledCard && gs.CalledCard == nil {
+			if err := gs.CallPartnerCard(player.ID, chooseCalledCard(player.Hand, *gs.TrumpSuit)); err != nil {
+				return err
+			}
+		}
+		return gs.ExchangeKitty(player.ID, lowestCards(player.Hand, KittySize, *gs.TrumpSuit))
+	case PhasePlaying:
+		formation, err := gs.ChooseAutoPlay(player.ID)
+		if err != nil {
+			return err
+		}
+		_, err = gs.PlayCards(player.ID, formation)
+		return err
+	default:
+		return fmt.Errorf("no automatic action in %s phase", gs.Phase.String())
+	}
+}
+
+// ChooseAutoPlay picks the lowest formation the player can legally play in the
+// current trick. A follower holding no formation like the led one plays a mixed
+// formation of the led size instead.
+func (gs *GameState) ChooseAutoPlay(playerID string) (*Formation, error) {
+	if gs.Phase != PhasePlaying || gs.TrumpSuit == nil {
+		return nil, fmt.Errorf("%w: not in playing phase", ErrWrongPhase)
+	}
+
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player %s is not in this game", playerID)
+	}
+	if len(player.Hand) == 0 {
+		return nil, fmt.Errorf("player has no cards left")
+	}
+
+	trumpSuit := *gs.TrumpSuit
+	hand := sortedByStrength(player.Hand, trumpSuit)
+
+	// The leader opens with their lowest single
+	if gs.CurrentTrick == nil || len(gs.CurrentTrick.Plays) == 0 {
+		return NewSingle(hand[0]), nil
+	}
+
+	led := gs.CurrentTrick.Plays[gs.CurrentTrick.Leader]
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+
+	// Prefer cards of the led suit, falling back to the rest of the hand
+	candidates := make([]Card, 0, len(hand))
+	for _, card := range hand {
+		if effectiveSuit(card, trumpSuit) == ledSuit {
+			candidates = append(candidates, card)
+		}
+	}
+	for _, card := range hand {
+		if effectiveSuit(card, trumpSuit) != ledSuit {
+			candidates = append(candidates, card)
+		}
+	}
+
+	switch led.Type {
+	case Single:
+		return NewSingle(candidates[0]), nil
+	case Pair, Tractor:
+		if formation := lowestMatchingFormation(hand, led, trumpSuit); formation != nil {
+			return formation, nil
+		}
+		return mixedFollow(hand, len(led.Cards), ledSuit, trumpSuit), nil
+	default:
+		return nil, fmt.Errorf("cannot automatically follow a %s", led.Type.String())
+	}
+}
+
+// lowestMatchingFormation returns the weakest formation in the hand of the led
+// formation's type and size in the led suit or, when void in the led suit, in
+// any suit. It returns nil if there is none.
+func lowestMatchingFormation(hand []Card, led *Formation, trumpSuit Suit) *Formation {
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+
+	var lowest *Formation
+	for _, formation := range handFormations(hand, trumpSuit) {
+		if formation.Type != led.Type || len(formation.Cards) != len(led.Cards) {
+			continue
+		}
+		if formationSuit(formation, trumpSuit) == ledSuit {
+			return formation
+		}
+		if lowest == nil && countSuit(hand, ledSuit, trumpSuit) == 0 {
+			lowest = formation
+		}
+	}
+	return lowest
+}
+
+// mixedFollow builds a mixed formation of size cards for a follower holding no
+// formation like the led one. Cards of the led suit are taken first, then the
+// rest of the hand, each taking pairs before singles and weaker cards first.
+func mixedFollow(hand []Card, size int, ledSuit, trumpSuit Suit) *Formation {
+	following := make([]Card, 0, len(hand))
+	others := make([]Card, 0, len(hand))
+	for _, card := range sortedByFace(hand, trumpSuit) {
+		if effectiveSuit(card, trumpSuit) == ledSuit {
+			following = append(following, card)
+		} else {
+			others = append(others, card)
+		}
+	}
+
+	chosen := make([]Card, 0, size)
+	for _, cards := range [][]Card{following, others} {
+		cards = pairsFirst(cards)
+		chosen = append(chosen, cards[:min(len(cards), size-len(chosen))]...)
+	}
+	return NewMixed(chosen)
+}
+
+// pairsFirst reorders cards sorted by face so both copies of each pair come
+// before the unpaired cards, keeping the order within each group
+func pairsFirst(cards []Card) []Card {
+	paired := make([]Card, 0, len(cards))
+	unpaired := make([]Card, 0, len(cards))
+	for i := 0; i < len(cards); i++ {
+		if i+1 < len(cards) && cards[i].IsSameFace(cards[i+1]) {
+			paired = append(paired, cards[i], cards[i+1])
+			i++
+			continue
+		}
+		unpaired = append(unpaired, cards[i])
+	}
+	return append(paired, unpaired...)
+}
+
+// effectiveSuit returns the suit a card plays as, treating all trumps as the trump suit
+func effectiveSuit(card Card, trumpSuit Suit) Suit {
+	if card.GetTrumpHierarchy(trumpSuit) > 0 {
+		return trumpSuit
+	}
+	return card.Suit
+}
+
+// cardStrength orders cards from weakest to strongest, with every trump above every plain card
+func cardStrength(card Card, trumpSuit Suit) int {
+	if hierarchy := card.GetTrumpHierarchy(trumpSuit); hierarchy > 0 {
+		return hierarchy
+	}
+	return card.GetSuitHierarchy()
+}
+
+// sortedByStrength returns a copy of the cards ordered from weakest to strongest
+func sortedByStrength(cards []Card, trumpSuit Suit) []Card {
+	sorted := make([]Card, len(cards))
+	copy(sorted, cards)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return cardStrength(sorted[i], trumpSuit) < cardStrength(sorted[j], trumpSuit)
+	})
+	return sorted
+}
+
+// lowestCards returns the n weakest cards, keeping point cards back where possible
+func lowestCards(cards []Card, n int, trumpSuit Suit) []Card {
+	sorted := sortedByStrength(cards, trumpSuit)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetPointValue() < sorted[j].GetPointValue()
+	})
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	return sorted[:n]
+}
+
+// longestSuit returns the plain suit the hand holds the most cards of
+func longestSuit(cards []Card) Suit {
+	counts := make(map[Suit]int)
+	for _, card := range cards {
+		if !card.IsJoker && card.Rank != Two {
+			counts[card.Suit]++
+		}
+	}
+
+	longest := Spades
+	for suit := Spades; suit <= Diamonds; suit++ {
+		if counts[suit] > counts[longest] {
+			longest = suit
+		}
+	}
+	return longest
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+)
+
+// newPlayingGameState deals an unshuffled deck and completes bidding, trump
+// declaration and the kitty exchange with North as declarer and Hearts as trump
+func newPlayingGameState(t *testing.T) *GameState {
+	t.Helper()
+
+	gs := newTestGameState(t)
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump() error = %v", err)
+	}
+
+	discards := make([]Card, 8)
+	copy(discards, gs.Players[North].Hand[:8])
+	if err := gs.ExchangeKitty("north", discards); err != nil {
+		t.Fatalf("ExchangeKitty() error = %v", err)
+	}
+	return gs
+}
+
+func TestGameState_PlayCards(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	if _, err := gs.PlayCards("east", NewSingle(NewCard(Clubs, Three, 1))); err == nil {
+		t.Error("Expected error when playing out of turn")
+	}
+
+	plays := []struct {
+		playerID string
+		card     Card
+	}{
+		{"north", NewCard(Spades, Ten, 1)},
+		{"east", NewCard(Clubs, Three, 1)},
+		{"south", NewCard(Spades, Ten, 2)},
+		{"west", NewCard(Hearts, Queen, 2)},
+	}
+	for _, play := range plays {
+		if _, err := gs.PlayCards(play.playerID, NewSingle(play.card)); err != nil {
+			t.Fatalf("PlayCards(%s) error = %v", play.playerID, err)
+		}
+	}
+
+	if len(gs.Tricks) != 1 || gs.CurrentTrick != nil {
+		t.Fatalf("Expected one completed trick, got %d tricks", len(gs.Tricks))
+	}
+	if gs.Tricks[0].Winner != West.String() {
+		t.Errorf("Expected West to win the trick, got %s", gs.Tricks[0].Winner)
+	}
+	if gs.CurrentPlayerTurn != West {
+		t.Errorf("Expected trick winner West to lead, got %s", gs.CurrentPlayerTurn.String())
+	}
+	if gs.Players[North].GetHandSize() != 24 {
+		t.Errorf("Expected North to hold 24 cards, got %d", gs.Players[North].GetHandSize())
+	}
+}
+
+func TestGameState_AutoAct(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	// North leads with their weakest card
+	if err := gs.AutoAct(); err != nil {
+		t.Fatalf("AutoAct() error = %v", err)
+	}
+	led := gs.CurrentTrick.Plays[North]
+	if led == nil || !led.Cards[0].IsEqual(NewCard(Spades, Ten, 1)) {
+		t.Fatalf("Expected North to lead the 10 of Spades, got %v", led)
+	}
+
+	// East is void in Spades and discards their weakest plain card
+	if err := gs.AutoAct(); err != nil {
+		t.Fatalf("AutoAct() error = %v", err)
+	}
+	if followed := gs.CurrentTrick.Plays[East]; !followed.Cards[0].IsEqual(NewCard(Clubs, Three, 1)) {
+		t.Errorf("Expected East to play the 3 of Clubs, got %v", followed.Cards[0].String())
+	}
+
+	if gs.CurrentPlayerTurn != South {
+		t.Errorf("Expected South to play next, got %s", gs.CurrentPlayerTurn.String())
+	}
+}
+
+func TestGameState_AutoActFollowsTractor(t *testing.T) {
+	tests := []struct {
+		name string
+		hand []Card
+		want FormationType
+		play []Card
+	}{
+		{
+			name: "plays a tractor of the led size",
+			hand: []Card{
+				NewCard(Clubs, Three, 1), NewCard(Spades, Four, 1), NewCard(Spades, Four, 2),
+				NewCard(Spades, Three, 1), NewCard(Spades, Three, 2),
+			},
+			want: Tractor,
+			play: []Card{
+				NewCard(Spades, Three, 1), NewCard(Spades, Three, 2),
+				NewCard(Spades, Four, 1), NewCard(Spades, Four, 2),
+			},
+		},
+		{
+			name: "falls back to pairs then singles without a tractor",
+			hand: []Card{
+				NewCard(Clubs, Five, 1), NewCard(Clubs, Four, 1), NewCard(Clubs, Four, 2),
+				NewCard(Spades, Nine, 1), NewCard(Spades, Three, 1), NewCard(Spades, Three, 2),
+			},
+			want: Mixed,
+			play: []Card{
+				NewCard(Spades, Three, 1), NewCard(Spades, Three, 2),
+				NewCard(Spades, Nine, 1), NewCard(Clubs, Four, 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newPlayingGameState(t)
+			gs.Players[North].Hand = append(gs.Players[North].Hand,
+				NewCard(Spades, Jack, 1), NewCard(Spades, Jack, 2),
+				NewCard(Spades, Queen, 1), NewCard(Spades, Queen, 2))
+			led, err := NewTractor([][]Card{
+				{NewCard(Spades, Jack, 1), NewCard(Spades, Jack, 2)},
+				{NewCard(Spades, Queen, 1), NewCard(Spades, Queen, 2)},
+			}, Hearts)
+			if err != nil {
+				t.Fatalf("NewTractor() error = %v", err)
+			}
+			if _, err := gs.PlayCards("north", led); err != nil {
+				t.Fatalf("PlayCards() error = %v", err)
+			}
+			gs.Players[East].Hand = tt.hand
+
+			if err := gs.AutoAct(); err != nil {
+				t.Fatalf("AutoAct() error = %v", err)
+			}
+			followed := gs.CurrentTrick.Plays[East]
+			if followed == nil || followed.Type != tt.want {
+				t.Fatalf("Expected East to play a %s, got %v", tt.want.String(), followed)
+			}
+			if len(followed.Cards) != len(tt.play) {
+				t.Fatalf("Expected East to play %v, got %v", tt.play, followed.Cards)
+			}
+			for i, card := range tt.play {
+				if !followed.Cards[i].IsEqual(card) {
+					t.Errorf("Expected East to play %v, got %v", tt.play, followed.Cards)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestGameState_AutoActBidding(t *testing.T) {
+	gs := newTestGameState(t)
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+
+	if err := gs.AutoAct(); err != nil {
+		t.Fatalf("AutoAct() error = %v", err)
+	}
+	if !gs.Players[North].HasPassed {
+		t.Error("Expected an automatic bid to be a pass")
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+// AnnotatedBid is a bid or pass from the auction with the context needed to
+// review it: when it was made, from which seat and whether the server made it
+type AnnotatedBid struct {
+	Turn     int            `json:"turn"` // Position in the auction, starting at 1
+	PlayerID string         `json:"player_id"`
+	Position PlayerPosition `json:"position"`
+	Amount   int            `json:"amount,omitempty"`
+	IsPassed bool           `json:"is_passed"`
+	IsAuto   bool           `json:"is_auto"` // Passed for a bot, disconnected or timed-out player
+}
+
+// GetBidHistory returns the auction so far in the order the bids were made
+func (gs *GameState) GetBidHistory() []AnnotatedBid {
+	history := make([]AnnotatedBid, 0, len(gs.BidHistory))
+	for i, bid := range gs.BidHistory {
+		annotated := AnnotatedBid{
+			Turn:     i + 1,
+			PlayerID: bid.PlayerID,
+			Amount:   bid.Amount,
+			IsPassed: bid.IsPassed,
+			IsAuto:   bid.IsAuto,
+		}
+		if player := gs.GetPlayer(bid.PlayerID); player != nil {
+			annotated.Position = player.Position
+		}
+		history = append(history, annotated)
+	}
+	return history
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGameState_GetBidHistory(t *testing.T) {
+	gs := newTestGameStateWithRules(t, DefaultRules())
+
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid(north) error = %v", err)
+	}
+	if err := gs.PassBid("east"); err != nil {
+		t.Fatalf("PassBid(east) error = %v", err)
+	}
+	// South's turn is taken by the server, as for a disconnected or timed-out player
+	if err := gs.AutoAct(); err != nil {
+		t.Fatalf("AutoAct() error = %v", err)
+	}
+	if err := gs.PlaceBid("west", 110); err != nil {
+		t.Fatalf("PlaceBid(west) error = %v", err)
+	}
+
+	want := []AnnotatedBid{
+		{Turn: 1, PlayerID: "north", Position: North, Amount: 120},
+		{Turn: 2, PlayerID: "east", Position: East, IsPassed: true},
+		{Turn: 3, PlayerID: "south", Position: South, IsPassed: true, IsAuto: true},
+		{Turn: 4, PlayerID: "west", Position: West, Amount: 110},
+	}
+	if got := gs.GetBidHistory(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBidHistory() = %+v, want %+v", got, want)
+	}
+
+	if got := newTestGameState(t).GetBidHistory(); len(got) != 0 {
+		t.Errorf("Expected no bids before the auction, got %d", len(got))
+	}
+}
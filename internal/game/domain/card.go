@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"math/rand/v2"
 )
 
 // Suit represents the four card suits plus trump indicators
@@ -12,6 +13,7 @@ const (
 	Hearts
 	Clubs
 	Diamonds
+	NoTrump // Declared instead of a suit when only jokers and 2s are trumps
 )
 
 func (s Suit) String() string {
@@ -24,6 +26,8 @@ func (s Suit) String() string {
 		return "Clubs"
 	case Diamonds:
 		return "Diamonds"
+	case NoTrump:
+		return "No Trump"
 	default:
 		return "Unknown"
 	}
@@ -225,7 +229,7 @@ type Deck struct {
 // NewDeck creates a new deck with 2 standard 52-card decks plus 4 jokers
 func NewDeck() *Deck {
 	deck := &Deck{
-		Cards: make([]Card, 0, 108),
+		Cards: make([]Card, 0, DeckSize),
 	}
 
 	// Add two standard 52-card decks
@@ -247,10 +251,23 @@ func NewDeck() *Deck {
 	return deck
 }
 
+// NewDealSeed returns a random seed for shuffling a deck
+func NewDealSeed() int64 {
+	return rand.Int64()
+}
+
 // Shuffle randomizes the order of cards in the deck
 func (d *Deck) Shuffle() {
-	// Implementation would use crypto/rand for secure shuffling
-	// This is a placeholder for the actual shuffle algorithm
+	d.ShuffleWithSeed(NewDealSeed())
+}
+
+// ShuffleWithSeed puts the cards in the order the seed determines, so a new
+// deck shuffled with the same seed always deals the same hands
+func (d *Deck) ShuffleWithSeed(seed int64) {
+	random := rand.New(rand.NewPCG(uint64(seed), 0))
+	random.Shuffle(len(d.Cards), func(i, j int) {
+		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+	})
 }
 
 // Deal removes and returns the specified number of cards from the top of the deck
@@ -259,8 +276,9 @@ func (d *Deck) Deal(count int) ([]Card, error) {
 		return nil, fmt.Errorf("cannot deal %d cards, only %d cards remaining", count, len(d.Cards))
 	}
 
+	// Copied so that a hand never shares the deck's buffer
 	dealt := make([]Card, count)
-	copy(dealt, d.Cards[:count])
+	copy(dealt, d.Cards)
 	d.Cards = d.Cards[count:]
 
 	return dealt, nil
@@ -271,44 +289,67 @@ func (d *Deck) Remaining() int {
 	return len(d.Cards)
 }
 
-// ValidateDeckComposition ensures the deck has the correct composition
-func (d *Deck) ValidateDeckComposition() error {
-	if len(d.Cards) != 108 {
-		return fmt.Errorf("deck must have exactly 108 cards, found %d", len(d.Cards))
-	}
+// faceCount is the number of distinct card faces: 13 ranks in each of the 4
+// suits plus the two jokers
+const faceCount = 4*13 + 2
 
-	// Count cards by type
-	suitCounts := make(map[Suit]map[Rank]int)
-	jokerCounts := make(map[JokerType]int)
+// faceIndex returns the card's face in [0, faceCount), or false for a card
+// with an unknown suit, rank or joker type
+func faceIndex(card Card) (int, bool) {
+	if card.IsJoker {
+		if card.JokerType != BigJoker && card.JokerType != SmallJoker {
+			return 0, false
+		}
+		return 4*13 + int(card.JokerType), true
+	}
+	if card.Suit < Spades || card.Suit > Diamonds || card.Rank < Two || card.Rank > Ace {
+		return 0, false
+	}
+	return int(card.Suit)*13 + int(card.Rank-Two), true
+}
 
-	for suit := Spades; suit <= Diamonds; suit++ {
-		suitCounts[suit] = make(map[Rank]int)
+// ValidateDeckComposition ensures the deck has the correct composition. It
+// counts into fixed arrays rather than maps since it runs on every deal and
+// integrity check.
+func (d *Deck) ValidateDeckComposition() error {
+	if len(d.Cards) != DeckSize {
+		return fmt.Errorf("deck must have exactly %d cards, found %d", DeckSize, len(d.Cards))
 	}
 
+	var counts [faceCount]int
+	var seen [faceCount][2]bool
 	for _, card := range d.Cards {
-		if card.IsJoker {
-			jokerCounts[card.JokerType]++
-		} else {
-			suitCounts[card.Suit][card.Rank]++
+		face, ok := faceIndex(card)
+		if !ok || (card.DeckID != 1 && card.DeckID != 2) {
+			return fmt.Errorf("invalid card: %s", card.String())
+		}
+
+		// Each physical card appears once, so the two copies of a face must
+		// come from different decks
+		if seen[face][card.DeckID-1] {
+			return fmt.Errorf("duplicate card: %s", card.String())
 		}
+		seen[face][card.DeckID-1] = true
+		counts[face]++
 	}
 
 	// Validate each rank appears exactly twice in each suit
 	for suit := Spades; suit <= Diamonds; suit++ {
 		for rank := Two; rank <= Ace; rank++ {
-			if suitCounts[suit][rank] != 2 {
-				return fmt.Errorf("rank %s of %s must appear exactly twice, found %d", 
-					rank.String(), suit.String(), suitCounts[suit][rank])
+			count := counts[int(suit)*13+int(rank-Two)]
+			if count != 2 {
+				return fmt.Errorf("rank %s of %s must appear exactly twice, found %d",
+					rank.String(), suit.String(), count)
 			}
 		}
 	}
 
 	// Validate jokers
-	if jokerCounts[BigJoker] != 2 {
-		return fmt.Errorf("must have exactly 2 big jokers, found %d", jokerCounts[BigJoker])
+	if count := counts[4*13+int(BigJoker)]; count != 2 {
+		return fmt.Errorf("must have exactly 2 big jokers, found %d", count)
 	}
-	if jokerCounts[SmallJoker] != 2 {
-		return fmt.Errorf("must have exactly 2 small jokers, found %d", jokerCounts[SmallJoker])
+	if count := counts[4*13+int(SmallJoker)]; count != 2 {
+		return fmt.Errorf("must have exactly 2 small jokers, found %d", count)
 	}
 
 	return nil
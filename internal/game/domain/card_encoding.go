@@ -0,0 +1,82 @@
+package domain
+
+import "fmt"
+
+// Compact card codes are three characters: suit, rank and deck. Suits are
+// S, H, C and D, ranks are 2-9, T, J, Q, K and A, and jokers use BJ for the
+// Big Joker and LJ for the (little) Small Joker, so "HK1" is the King of
+// Hearts from deck 1 and "BJ2" the Big Joker from deck 2.
+
+var suitCodes = map[Suit]byte{Spades: 'S', Hearts: 'H', Clubs: 'C', Diamonds: 'D'}
+
+var rankCodes = map[Rank]byte{
+	Two: '2', Three: '3', Four: '4', Five: '5', Six: '6', Seven: '7', Eight: '8',
+	Nine: '9', Ten: 'T', Jack: 'J', Queen: 'Q', King: 'K', Ace: 'A',
+}
+
+var jokerCodes = map[JokerType]string{BigJoker: "BJ", SmallJoker: "LJ"}
+
+// Encode returns the card's compact code, such as "HK1" or "BJ2"
+func (c Card) Encode() string {
+	if c.IsJoker {
+		return fmt.Sprintf("%s%d", jokerCodes[c.JokerType], c.DeckID)
+	}
+	return fmt.Sprintf("%c%c%d", suitCodes[c.Suit], rankCodes[c.Rank], c.DeckID)
+}
+
+// DecodeCard parses a compact card code produced by Card.Encode
+func DecodeCard(code string) (Card, error) {
+	if len(code) != 3 || (code[2] != '1' && code[2] != '2') {
+		return Card{}, fmt.Errorf("invalid card code: %q", code)
+	}
+	deckID := int(code[2] - '0')
+
+	for jokerType, jokerCode := range jokerCodes {
+		if code[:2] == jokerCode {
+			return NewJoker(jokerType, deckID), nil
+		}
+	}
+
+	suit, ok := lookupCode(suitCodes, code[0])
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card code: %q", code)
+	}
+	rank, ok := lookupCode(rankCodes, code[1])
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card code: %q", code)
+	}
+	return NewCard(suit, rank, deckID), nil
+}
+
+// EncodeCards returns the compact codes of the cards, in order
+func EncodeCards(cards []Card) []string {
+	codes := make([]string, len(cards))
+	for i, card := range cards {
+		codes[i] = card.Encode()
+	}
+	return codes
+}
+
+// DecodeCards parses a list of compact card codes, such as a whole hand
+func DecodeCards(codes []string) ([]Card, error) {
+	cards := make([]Card, len(codes))
+	for i, code := range codes {
+		card, err := DecodeCard(code)
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+// lookupCode finds the value encoded by a single character code
+func lookupCode[T comparable](codes map[T]byte, code byte) (T, bool) {
+	for value, c := range codes {
+		if c == code {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
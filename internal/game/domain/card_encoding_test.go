@@ -0,0 +1,74 @@
+package domain
+
+import "testing"
+
+func TestCard_EncodeDecodeRoundTrip(t *testing.T) {
+	deck := NewDeck()
+	seen := make(map[string]bool, len(deck.Cards))
+	for _, card := range deck.Cards {
+		code := card.Encode()
+		if len(code) != 3 {
+			t.Errorf("Encode(%s) = %q, want 3 characters", card.String(), code)
+		}
+		if seen[code] {
+			t.Errorf("Encode(%s) = %q, which another card already uses", card.String(), code)
+		}
+		seen[code] = true
+
+		decoded, err := DecodeCard(code)
+		if err != nil {
+			t.Fatalf("DecodeCard(%q) error = %v", code, err)
+		}
+		if decoded != card {
+			t.Errorf("DecodeCard(%q) = %s, want %s", code, decoded.String(), card.String())
+		}
+	}
+
+	examples := map[string]Card{
+		"HK1": NewCard(Hearts, King, 1),
+		"ST2": NewCard(Spades, Ten, 2),
+		"D21": NewCard(Diamonds, Two, 1),
+		"CA2": NewCard(Clubs, Ace, 2),
+		"BJ2": NewJoker(BigJoker, 2),
+		"LJ1": NewJoker(SmallJoker, 1),
+	}
+	for code, card := range examples {
+		if got := card.Encode(); got != code {
+			t.Errorf("Encode(%s) = %q, want %q", card.String(), got, code)
+		}
+	}
+}
+
+func TestDecodeCard_Invalid(t *testing.T) {
+	for _, code := range []string{"", "H", "HK", "HK3", "HK0", "HK12", "XK1", "H11", "hk1", "BJ", "SJ"} {
+		if _, err := DecodeCard(code); err == nil {
+			t.Errorf("DecodeCard(%q) expected error", code)
+		}
+	}
+}
+
+func TestEncodeCards(t *testing.T) {
+	hand := []Card{NewCard(Hearts, King, 1), NewJoker(BigJoker, 2), NewCard(Clubs, Five, 1)}
+
+	codes := EncodeCards(hand)
+	want := []string{"HK1", "BJ2", "C51"}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("EncodeCards()[%d] = %q, want %q", i, codes[i], want[i])
+		}
+	}
+
+	decoded, err := DecodeCards(codes)
+	if err != nil {
+		t.Fatalf("DecodeCards() error = %v", err)
+	}
+	for i := range hand {
+		if decoded[i] != hand[i] {
+			t.Errorf("DecodeCards()[%d] = %s, want %s", i, decoded[i].String(), hand[i].String())
+		}
+	}
+
+	if _, err := DecodeCards([]string{"HK1", "ZZ9"}); err == nil {
+		t.Error("Expected error for a hand with an invalid code")
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "strings"
+
+// Suits and ranks have labels for display to players, alongside the English
+// String forms used in logs. Languages are given as tags such as "en",
+// "zh" or "zh-CN"; only the primary language is used and unknown languages
+// fall back to English.
+
+var suitSymbols = map[Suit]string{
+	Spades: "♠", Hearts: "♥", Clubs: "♣", Diamonds: "♦", NoTrump: "NT",
+}
+
+var suitLabels = map[string]map[Suit]string{
+	"zh": {Spades: "黑桃", Hearts: "红桃", Clubs: "梅花", Diamonds: "方块", NoTrump: "无主"},
+}
+
+var rankLabels = map[string]map[Rank]string{
+	"en": {Jack: "Jack", Queen: "Queen", King: "King", Ace: "Ace"},
+	"zh": {Jack: "勾", Queen: "圈", King: "凯", Ace: "尖"},
+}
+
+// primaryLanguage returns the lower-case primary subtag of a language tag,
+// so "zh-CN" and "zh_Hans" both give "zh"
+func primaryLanguage(lang string) string {
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return strings.ToLower(lang)
+}
+
+// Symbol returns the suit's card symbol, such as "♠", or "NT" for no trump
+func (s Suit) Symbol() string {
+	if symbol, ok := suitSymbols[s]; ok {
+		return symbol
+	}
+	return "?"
+}
+
+// Localized returns the suit's name in the given language, such as "黑桃"
+// for Spades in "zh"
+func (s Suit) Localized(lang string) string {
+	if label, ok := suitLabels[primaryLanguage(lang)][s]; ok {
+		return label
+	}
+	return s.String()
+}
+
+// Localized returns the rank's name in the given language. Number cards keep
+// their numbers, while court cards and aces are named, e.g. "Queen" in "en".
+func (r Rank) Localized(lang string) string {
+	labels, ok := rankLabels[primaryLanguage(lang)]
+	if !ok {
+		labels = rankLabels["en"]
+	}
+	if label, ok := labels[r]; ok {
+		return label
+	}
+	return r.String()
+}
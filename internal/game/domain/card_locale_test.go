@@ -0,0 +1,62 @@
+package domain
+
+import "testing"
+
+func TestSuit_Symbol(t *testing.T) {
+	tests := map[Suit]string{
+		Spades:   "♠",
+		Hearts:   "♥",
+		Clubs:    "♣",
+		Diamonds: "♦",
+		NoTrump:  "NT",
+		Suit(9):  "?",
+	}
+	for suit, want := range tests {
+		if got := suit.Symbol(); got != want {
+			t.Errorf("%s.Symbol() = %q, want %q", suit, got, want)
+		}
+	}
+}
+
+func TestSuit_Localized(t *testing.T) {
+	tests := []struct {
+		suit Suit
+		lang string
+		want string
+	}{
+		{Spades, "zh", "黑桃"},
+		{Hearts, "zh-CN", "红桃"},
+		{Clubs, "ZH_Hans", "梅花"},
+		{Diamonds, "zh", "方块"},
+		{NoTrump, "zh", "无主"},
+		{Spades, "en", "Spades"},
+		{Hearts, "fr", "Hearts"},
+		{Clubs, "", "Clubs"},
+	}
+	for _, tt := range tests {
+		if got := tt.suit.Localized(tt.lang); got != tt.want {
+			t.Errorf("%s.Localized(%q) = %q, want %q", tt.suit, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestRank_Localized(t *testing.T) {
+	tests := []struct {
+		rank Rank
+		lang string
+		want string
+	}{
+		{Ace, "zh", "尖"},
+		{King, "zh-TW", "凯"},
+		{Jack, "zh", "勾"},
+		{Ten, "zh", "10"},
+		{Queen, "en", "Queen"},
+		{Two, "en", "2"},
+		{Ace, "de", "Ace"},
+	}
+	for _, tt := range tests {
+		if got := tt.rank.Localized(tt.lang); got != tt.want {
+			t.Errorf("%s.Localized(%q) = %q, want %q", tt.rank, tt.lang, got, tt.want)
+		}
+	}
+}
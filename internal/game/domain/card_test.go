@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -173,6 +174,8 @@ func TestDeck_NewDeck(t *testing.T) {
 func TestDeck_Deal(t *testing.T) {
 	deck := NewDeck()
 	initialCount := len(deck.Cards)
+	undealt := deck.Cards
+	top := undealt[0]
 
 	// Deal 5 cards
 	dealt, err := deck.Deal(5)
@@ -188,6 +191,19 @@ func TestDeck_Deal(t *testing.T) {
 		t.Errorf("Expected %d remaining cards, got %d", initialCount-5, len(deck.Cards))
 	}
 
+	// Appending to a dealt hand must not overwrite the cards still in the deck
+	next := deck.Cards[0]
+	_ = append(dealt, NewJoker(BigJoker, 1))
+	if deck.Cards[0] != next {
+		t.Errorf("Expected the deck to keep %s after appending to a dealt hand, got %s", next.String(), deck.Cards[0].String())
+	}
+
+	// Changing a dealt hand must not change the cards the deck was dealt from
+	dealt[0] = NewJoker(BigJoker, 1)
+	if undealt[0] != top {
+		t.Errorf("Expected the deck's cards to keep %s after changing a dealt hand, got %s", top.String(), undealt[0].String())
+	}
+
 	// Try to deal more cards than available
 	_, err = deck.Deal(200)
 	if err == nil {
@@ -217,6 +233,26 @@ func TestDeck_ValidateDeckComposition(t *testing.T) {
 	if err := wrongCountDeck.ValidateDeckComposition(); err == nil {
 		t.Error("Expected error for deck with wrong card distribution")
 	}
+
+	// Test deck with cards of an unknown suit, rank or joker type
+	for _, card := range []Card{NewCard(Suit(9), Ace, 1), NewCard(Hearts, Rank(20), 1), NewJoker(JokerType(5), 1)} {
+		unknownDeck := NewDeck()
+		unknownDeck.Cards[0] = card
+		if err := unknownDeck.ValidateDeckComposition(); err == nil {
+			t.Errorf("Expected error for deck containing %s", card.String())
+		}
+	}
+
+	// Test deck where both copies of a face carry the same unknown deck ID
+	sameDeckIDs := NewDeck()
+	for i, card := range sameDeckIDs.Cards {
+		if card.Suit == Hearts && card.Rank == King && !card.IsJoker {
+			sameDeckIDs.Cards[i].DeckID = 0
+		}
+	}
+	if err := sameDeckIDs.ValidateDeckComposition(); err == nil {
+		t.Error("Expected error for deck with two identical cards of deck 0")
+	}
 }
 
 func TestRank_String(t *testing.T) {
@@ -278,4 +314,23 @@ func TestJokerType_String(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestDeck_ShuffleWithSeed(t *testing.T) {
+	first, second, other := NewDeck(), NewDeck(), NewDeck()
+	first.ShuffleWithSeed(42)
+	second.ShuffleWithSeed(42)
+	other.ShuffleWithSeed(43)
+
+	if !reflect.DeepEqual(first.Cards, second.Cards) {
+		t.Error("Expected the same seed to shuffle decks into the same order")
+	}
+	if reflect.DeepEqual(first.Cards, other.Cards) {
+		t.Error("Expected different seeds to shuffle decks differently")
+	}
+	if reflect.DeepEqual(first.Cards, NewDeck().Cards) {
+		t.Error("Expected a shuffled deck to differ from a new one")
+	}
+	if err := first.ValidateDeckComposition(); err != nil {
+		t.Errorf("Expected shuffling to keep every card, got %v", err)
+	}
+}
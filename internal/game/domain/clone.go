@@ -0,0 +1,103 @@
+package domain
+
+// Clone returns a deep copy of the game state that shares no memory with the
+// original, so bots and previews can simulate moves on it without touching
+// the live game
+func (gs *GameState) Clone() *GameState {
+	clone := *gs
+
+	clone.Rules.DeclarerTiers = cloneSlice(gs.Rules.DeclarerTiers)
+	for i, player := range gs.Players {
+		clone.Players[i] = player.clone()
+	}
+	clone.Declarer = clonePointer(gs.Declarer)
+	clone.TrumpSuit = clonePointer(gs.TrumpSuit)
+	clone.BidHistory = cloneSlice(gs.BidHistory)
+	clone.CurrentTrick = gs.CurrentTrick.clone()
+	if gs.Tricks != nil {
+		clone.Tricks = make([]Trick, len(gs.Tricks))
+		for i := range gs.Tricks {
+			clone.Tricks[i] = *gs.Tricks[i].clone()
+		}
+	}
+	clone.Kitty = cloneSlice(gs.Kitty)
+	if gs.Scores != nil {
+		clone.Scores = make(map[string]int, len(gs.Scores))
+		for playerID, score := range gs.Scores {
+			clone.Scores[playerID] = score
+		}
+	}
+	clone.WinnerTeam = clonePointer(gs.WinnerTeam)
+	if gs.ObservedVoids != nil {
+		clone.ObservedVoids = make(map[PlayerPosition][]Suit, len(gs.ObservedVoids))
+		for position, suits := range gs.ObservedVoids {
+			clone.ObservedVoids[position] = cloneSlice(suits)
+		}
+	}
+	clone.Reneges = cloneSlice(gs.Reneges)
+	clone.CalledCard = clonePointer(gs.CalledCard)
+	clone.CalledPartner = clonePointer(gs.CalledPartner)
+	clone.TurnDeadline = clonePointer(gs.TurnDeadline)
+	clone.Concessions = cloneSlice(gs.Concessions)
+	clone.ConcededBy = clonePointer(gs.ConcededBy)
+	clone.DealOrder = cloneSlice(gs.DealOrder)
+	return &clone
+}
+
+// clone returns a deep copy of the player, or nil for a nil player
+func (p *Player) clone() *Player {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	clone.Hand = cloneSlice(p.Hand)
+	clone.DisconnectedAt = clonePointer(p.DisconnectedAt)
+	clone.ReconnectDeadline = clonePointer(p.ReconnectDeadline)
+	return &clone
+}
+
+// clone returns a deep copy of the trick, or nil for a nil trick
+func (t *Trick) clone() *Trick {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	if t.Plays != nil {
+		clone.Plays = make(map[PlayerPosition]*Formation, len(t.Plays))
+		for position, formation := range t.Plays {
+			clone.Plays[position] = formation.clone()
+		}
+	}
+	clone.TrumpSuit = clonePointer(t.TrumpSuit)
+	clone.LedSuit = clonePointer(t.LedSuit)
+	clone.CompletedAt = clonePointer(t.CompletedAt)
+	return &clone
+}
+
+// clone returns a deep copy of the formation, or nil for a nil formation
+func (f *Formation) clone() *Formation {
+	if f == nil {
+		return nil
+	}
+	clone := *f
+	clone.Cards = cloneSlice(f.Cards)
+	return &clone
+}
+
+// clonePointer returns a pointer to a copy of the value, or nil for nil
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
+
+// cloneSlice copies a slice, keeping nil and empty slices apart so a clone
+// encodes to the same JSON as the original
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(values)), values...)
+}
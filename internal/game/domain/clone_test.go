@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGameState_CloneIsIndependent(t *testing.T) {
+	gs := newPlayingGameState(t)
+	playFirstCards(t, gs, North)
+	before, err := json.Marshal(gs)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	clone := gs.Clone()
+	if !reflect.DeepEqual(clone, gs) {
+		t.Fatal("Expected the clone to equal the original")
+	}
+
+	// Finish the trick on the clone and keep playing
+	for clone.CurrentTrick != nil || len(clone.Tricks) < 2 {
+		player := clone.GetCurrentPlayer()
+		moves, err := clone.LegalMoves(player.ID)
+		if err != nil || len(moves) == 0 {
+			t.Fatalf("LegalMoves(%s) = %v, %v", player.ID, moves, err)
+		}
+		if _, err := clone.PlayCards(player.ID, moves[0]); err != nil {
+			t.Fatalf("PlayCards(%s) error = %v", player.ID, err)
+		}
+	}
+	*clone.TrumpSuit = Spades
+	*clone.Declarer = West
+	clone.Scores["north"] = 99
+
+	after, err := json.Marshal(gs)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(after) != string(before) {
+		t.Error("Expected playing on the clone to leave the original unchanged")
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Concede records that the player gives up the game for their team. The game
+// ends once everyone on the team has conceded, or straight away if the rules
+// let one player concede for their team. Games can only be conceded during
+// play, once both teams are known.
+func (gs *GameState) Concede(playerID string) error {
+	position, team, err := gs.concedingTeam(playerID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(gs.Concessions, position) {
+		gs.Concessions = append(gs.Concessions, position)
+	}
+	if gs.Rules.ConcedeAlone || gs.teamHasConceded(team) {
+		gs.endByConcession(team)
+		return nil
+	}
+	gs.UpdatedAt = time.Now()
+	return nil
+}
+
+// ConcedeForTeam ends the game with the player's team conceding without
+// waiting for their teammates, e.g. when the room's host overrides them
+func (gs *GameState) ConcedeForTeam(playerID string) error {
+	_, team, err := gs.concedingTeam(playerID)
+	if err != nil {
+		return err
+	}
+	gs.endByConcession(team)
+	return nil
+}
+
+// concedingTeam returns the seat and team of a player who wants to concede
+func (gs *GameState) concedingTeam(playerID string) (PlayerPosition, string, error) {
+	if gs.Phase != PhasePlaying {
+		return 0, "", fmt.Errorf("%w: games can only be conceded during play", ErrWrongPhase)
+	}
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return 0, "", fmt.Errorf("player %s is not in this game", playerID)
+	}
+	if gs.Rules.Partnership == CalledCard && gs.CalledPartner == nil {
+		return 0, "", fmt.Errorf("%w: the teams are not known until the called partner is revealed", ErrWrongPhase)
+	}
+	return player.Position, gs.GetTeam(player.Position), nil
+}
+
+// teamHasConceded checks whether every player on the team has conceded
+func (gs *GameState) teamHasConceded(team string) bool {
+	for _, player := range gs.Players {
+		if gs.GetTeam(player.Position) == team && !slices.Contains(gs.Concessions, player.Position) {
+			return false
+		}
+	}
+	return true
+}
+
+// endByConcession ends the game with the other team winning, scored by
+// concessionResult, and the points each player captured so far
+func (gs *GameState) endByConcession(team string) {
+	gs.ConcededBy = &team
+	gs.CalculateFinalScore()
+	gs.refreshTurnDeadline()
+}
+
+// concessionResult scores a conceded game. The other team wins at the lowest
+// level and by no margin, however the points stood when the game ended.
+func (gs *GameState) concessionResult() *ScoreResult {
+	winner := TeamDeclarer
+	if *gs.ConcededBy == TeamDeclarer {
+		winner = TeamDefenders
+	}
+	return &ScoreResult{
+		WinnerTeam:      winner,
+		DefendersPoints: gs.GetDefendersPoints() + gs.GetRenegePenaltyPoints(),
+		Level:           1,
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameState_Concede(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	// East alone cannot concede for the defenders
+	if err := gs.Concede("east"); err != nil {
+		t.Fatalf("Concede(east) error = %v", err)
+	}
+	if gs.Phase != PhasePlaying {
+		t.Fatalf("Expected the game to go on until West agrees, got phase %v", gs.Phase)
+	}
+	if len(gs.Concessions) != 1 || gs.Concessions[0] != East {
+		t.Errorf("Expected East's concession to be recorded, got %v", gs.Concessions)
+	}
+	// West can see that East is waiting for them
+	view, err := gs.ViewFor("west")
+	if err != nil {
+		t.Fatalf("ViewFor(west) error = %v", err)
+	}
+	if len(view.Concessions) != 1 || view.Concessions[0] != East {
+		t.Errorf("Expected West's view to show East's concession, got %v", view.Concessions)
+	}
+
+	if err := gs.Concede("west"); err != nil {
+		t.Fatalf("Concede(west) error = %v", err)
+	}
+	if gs.Phase != PhaseEnded {
+		t.Fatalf("Expected the game to end, got phase %v", gs.Phase)
+	}
+	if gs.ConcededBy == nil || *gs.ConcededBy != TeamDefenders {
+		t.Errorf("Expected the defenders to have conceded, got %v", gs.ConcededBy)
+	}
+	if gs.WinnerTeam == nil || *gs.WinnerTeam != TeamDeclarer {
+		t.Fatalf("Expected the declarer's team to win, got %v", gs.WinnerTeam)
+	}
+
+	result := gs.GetScoreResult()
+	if result.WinnerTeam != TeamDeclarer || result.Level != 1 || result.Margin != 0 {
+		t.Errorf("Expected a level 1 win with no margin, got %+v", result)
+	}
+}
+
+func TestGameState_ConcedeAlone(t *testing.T) {
+	rules := DefaultRules()
+	rules.ConcedeAlone = true
+	gs := newTestGameStateWithRules(t, rules)
+	gs.Phase = PhasePlaying
+	trump := Hearts
+	gs.TrumpSuit = &trump
+	declarer := North
+	gs.Declarer = &declarer
+
+	if err := gs.Concede("south"); err != nil {
+		t.Fatalf("Concede(south) error = %v", err)
+	}
+	if gs.Phase != PhaseEnded || gs.WinnerTeam == nil || *gs.WinnerTeam != TeamDefenders {
+		t.Errorf("Expected the defenders to win when South concedes, got phase %v and winner %v", gs.Phase, gs.WinnerTeam)
+	}
+}
+
+func TestGameState_ConcedeForTeam(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	if err := gs.ConcedeForTeam("north"); err != nil {
+		t.Fatalf("ConcedeForTeam(north) error = %v", err)
+	}
+	if gs.Phase != PhaseEnded || gs.WinnerTeam == nil || *gs.WinnerTeam != TeamDefenders {
+		t.Errorf("Expected the defenders to win, got phase %v and winner %v", gs.Phase, gs.WinnerTeam)
+	}
+}
+
+func TestGameState_ConcedeOutsidePlay(t *testing.T) {
+	gs := newTestGameStateWithRules(t, DefaultRules())
+	if err := gs.Concede("north"); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Concede() during bidding error = %v, want ErrWrongPhase", err)
+	}
+
+	calledCard := DefaultRules()
+	calledCard.Partnership = CalledCard
+	gs = newTestGameStateWithRules(t, calledCard)
+	gs.Phase = PhasePlaying
+	declarer := North
+	gs.Declarer = &declarer
+	if err := gs.Concede("east"); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Concede() before the partner is revealed error = %v, want ErrWrongPhase", err)
+	}
+	if len(gs.Concessions) != 0 {
+		t.Errorf("Expected no concession to be recorded, got %v", gs.Concessions)
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "errors"
+
+// Errors returned by game actions, wrapped with details of what went wrong so
+// callers can match them with errors.Is
+var (
+	// ErrWrongPhase is returned when an action is not allowed in the current phase
+	ErrWrongPhase = errors.New("action not allowed in the current phase")
+	// ErrNotYourTurn is returned when a player acts out of turn or in another player's role
+	ErrNotYourTurn = errors.New("not player's turn")
+	// ErrInvalidBid is returned when a bid breaks the bidding rules
+	ErrInvalidBid = errors.New("invalid bid")
+	// ErrInvalidTrump is returned when a trump declaration breaks the rules
+	ErrInvalidTrump = errors.New("invalid trump")
+	// ErrMustFollow is returned when a player leaves the led suit although the
+	// rules require them to play a matching formation they hold in it
+	ErrMustFollow = errors.New("must follow the led formation")
+	// ErrInvalidPartnerCard is returned when the declarer calls a card that
+	// cannot reveal a partner
+	ErrInvalidPartnerCard = errors.New("invalid partner card")
+	// ErrCardNotHeld is returned when a player uses a card that is not in their hand
+	ErrCardNotHeld = errors.New("card not held")
+	// ErrCorruptTrick is returned when the current trick is inconsistent with
+	// itself or with whose turn it is, which only a bug can cause
+	ErrCorruptTrick = errors.New("current trick is inconsistent")
+)
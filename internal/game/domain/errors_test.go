@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameState_TypedErrors(t *testing.T) {
+	gs := newTestGameState(t)
+
+	if err := gs.PlaceBid("north", 120); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("PlaceBid() before dealing error = %v, want ErrWrongPhase", err)
+	}
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+	if err := gs.DealCards(NewDeck()); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("DealCards() twice error = %v, want ErrWrongPhase", err)
+	}
+
+	if err := gs.PlaceBid("east", 120); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("PlaceBid() out of turn error = %v, want ErrNotYourTurn", err)
+	}
+	if err := gs.PlaceBid("north", 121); !errors.Is(err, ErrInvalidBid) {
+		t.Errorf("PlaceBid(121) error = %v, want ErrInvalidBid", err)
+	}
+	if err := gs.DeclareTrump("north", Hearts); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("DeclareTrump() during bidding error = %v, want ErrWrongPhase", err)
+	}
+
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+	if err := gs.DeclareTrump("east", Hearts); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("DeclareTrump() by a defender error = %v, want ErrNotYourTurn", err)
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump() error = %v", err)
+	}
+
+	discards := make([]Card, KittySize)
+	copy(discards, gs.Players[East].Hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); !errors.Is(err, ErrCardNotHeld) {
+		t.Errorf("ExchangeKitty() with East's cards error = %v, want ErrCardNotHeld", err)
+	}
+	copy(discards, gs.Players[North].Hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); err != nil {
+		t.Fatalf("ExchangeKitty() error = %v", err)
+	}
+
+	if _, err := gs.PlayCards("north", NewSingle(gs.Players[East].Hand[0])); !errors.Is(err, ErrCardNotHeld) {
+		t.Errorf("PlayCards() with East's card error = %v, want ErrCardNotHeld", err)
+	}
+	if _, err := gs.PlayCards("east", NewSingle(gs.Players[East].Hand[0])); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("PlayCards() out of turn error = %v, want ErrNotYourTurn", err)
+	}
+}
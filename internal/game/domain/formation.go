@@ -12,6 +12,9 @@ const (
 	Single FormationType = iota
 	Pair
 	Tractor
+	// Mixed is any set of cards a follower plays when they hold no formation
+	// like the led one. It never wins the trick.
+	Mixed
 )
 
 func (f FormationType) String() string {
@@ -22,6 +25,8 @@ func (f FormationType) String() string {
 		return "Pair"
 	case Tractor:
 		return "Tractor"
+	case Mixed:
+		return "Mixed"
 	default:
 		return "Unknown"
 	}
@@ -54,6 +59,9 @@ func NewPair(card1, card2 Card) (*Formation, error) {
 	if !card1.IsSameFace(card2) {
 		return nil, fmt.Errorf("cards must have the same face value to form a pair")
 	}
+	if err := checkDistinctCards([]Card{card1, card2}); err != nil {
+		return nil, err
+	}
 
 	suit := card1.Suit
 	if card1.IsJoker {
@@ -95,6 +103,10 @@ func NewTractor(pairs [][]Card, trumpSuit Suit) (*Formation, error) {
 		pairRanks = append(pairRanks, pair[0].Rank)
 	}
 
+	if err := checkDistinctCards(allCards); err != nil {
+		return nil, err
+	}
+
 	// Check all pairs are from the same suit
 	firstSuit := pairs[0][0].Suit
 	for _, pair := range pairs {
@@ -121,6 +133,16 @@ func NewTractor(pairs [][]Card, trumpSuit Suit) (*Formation, error) {
 	}, nil
 }
 
+// NewMixed creates a mixed formation from cards that need not form a single,
+// pair or tractor
+func NewMixed(cards []Card) *Formation {
+	return &Formation{
+		Type:  Mixed,
+		Cards: cards,
+		Suit:  cards[0].Suit,
+	}
+}
+
 // IsValid checks if the formation is valid according to Chinese Bridge rules
 func (f *Formation) IsValid() error {
 	switch f.Type {
@@ -139,11 +161,22 @@ func (f *Formation) IsValid() error {
 		if len(f.Cards) < 4 || len(f.Cards)%2 != 0 {
 			return fmt.Errorf("tractor formation must have at least 4 cards in pairs")
 		}
-		// Additional tractor validation would be implemented here
+		pairs, err := groupPairs(f.Cards)
+		if err != nil {
+			return err
+		}
+		// Whether the pairs run consecutively does not depend on the trump suit
+		if _, err := NewTractor(pairs, NoTrump); err != nil {
+			return err
+		}
+	case Mixed:
+		if len(f.Cards) == 0 {
+			return fmt.Errorf("mixed formation must have at least 1 card")
+		}
 	default:
 		return fmt.Errorf("unknown formation type")
 	}
-	return nil
+	return checkDistinctCards(f.Cards)
 }
 
 // GetHighestCard returns the highest ranking card in the formation
@@ -201,6 +234,16 @@ func (f *Formation) IsTrump(trumpSuit Suit) bool {
 	return false
 }
 
+// followsSuit checks if every card in the formation plays as the suit
+func (f *Formation) followsSuit(suit Suit, trumpSuit Suit) bool {
+	for _, card := range f.Cards {
+		if effectiveSuit(card, trumpSuit) != suit {
+			return false
+		}
+	}
+	return true
+}
+
 // Compare compares two formations to determine which wins
 // Returns positive if f wins, negative if other wins, 0 if equal
 func (f *Formation) Compare(other *Formation, trumpSuit Suit, ledSuit Suit) int {
@@ -220,6 +263,22 @@ func (f *Formation) Compare(other *Formation, trumpSuit Suit, ledSuit Suit) int
 		return -1
 	}
 
+	// Only formations of the led suit or of trumps can win, so a formation of
+	// any other suit loses however high its cards are
+	if !fIsTrump {
+		fFollows := f.followsSuit(ledSuit, trumpSuit)
+		otherFollows := other.followsSuit(ledSuit, trumpSuit)
+		if fFollows != otherFollows {
+			if fFollows {
+				return 1
+			}
+			return -1
+		}
+		if !fFollows {
+			return 0
+		}
+	}
+
 	// Both trump or both non-trump, compare highest cards
 	fHighest := f.GetHighestCard(trumpSuit)
 	otherHighest := other.GetHighestCard(trumpSuit)
@@ -271,35 +330,28 @@ func ValidateFormation(cards []Card, formationType FormationType, trumpSuit Suit
 		if !cards[0].IsSameFace(cards[1]) {
 			return fmt.Errorf("pair formation requires two cards with the same face value")
 		}
+		if err := checkDistinctCards(cards); err != nil {
+			return err
+		}
 	case Tractor:
 		if len(cards) < 4 || len(cards)%2 != 0 {
 			return fmt.Errorf("tractor formation requires at least 4 cards in pairs")
 		}
 		
-		// Group cards into pairs
-		pairs := make([][]Card, 0, len(cards)/2)
-		cardMap := make(map[string][]Card)
-		
-		// Group cards by face value
-		for _, card := range cards {
-			key := fmt.Sprintf("%s_%s", card.Suit.String(), card.Rank.String())
-			if card.IsJoker {
-				key = fmt.Sprintf("joker_%s", card.JokerType.String())
-			}
-			cardMap[key] = append(cardMap[key], card)
-		}
-		
-		// Validate each group has exactly 2 cards
-		for _, group := range cardMap {
-			if len(group) != 2 {
-				return fmt.Errorf("tractor formation requires each rank to appear exactly twice")
-			}
-			pairs = append(pairs, group)
+		pairs, err := groupPairs(cards)
+		if err != nil {
+			return err
 		}
-		
+
 		// Validate tractor formation
-		_, err := NewTractor(pairs, trumpSuit)
-		if err != nil {
+		if _, err := NewTractor(pairs, trumpSuit); err != nil {
+			return err
+		}
+	case Mixed:
+		if len(cards) == 0 {
+			return fmt.Errorf("mixed formation requires at least 1 card")
+		}
+		if err := checkDistinctCards(cards); err != nil {
 			return err
 		}
 	default:
@@ -307,4 +359,107 @@ func ValidateFormation(cards []Card, formationType FormationType, trumpSuit Suit
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// BuildFormation constructs a formation of the claimed type from the cards,
+// so that a play labelled with the wrong type is rejected rather than trusted
+func BuildFormation(cards []Card, formationType FormationType, trumpSuit Suit) (*Formation, error) {
+	if err := checkDistinctCards(cards); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateFormation(cards, formationType, trumpSuit); err != nil {
+		return nil, err
+	}
+
+	switch formationType {
+	case Single:
+		return NewSingle(cards[0]), nil
+	case Pair:
+		return NewPair(cards[0], cards[1])
+	case Mixed:
+		return NewMixed(cards), nil
+	default:
+		pairs, err := groupPairs(cards)
+		if err != nil {
+			return nil, err
+		}
+		return NewTractor(pairs, trumpSuit)
+	}
+}
+
+// NormalizeFormation builds the formation of the claimed type from the cards
+// with its cards ordered from strongest to weakest and its suit set to the
+// suit it plays as, so the same cards always give the same formation whatever
+// order they were listed in
+func NormalizeFormation(cards []Card, formationType FormationType, trumpSuit Suit) (*Formation, error) {
+	if trumpSuit < Spades || trumpSuit > NoTrump {
+		return nil, fmt.Errorf("invalid trump suit")
+	}
+	for _, card := range cards {
+		if _, ok := faceIndex(card); !ok {
+			return nil, fmt.Errorf("invalid card: %s", card.String())
+		}
+	}
+
+	formation, err := BuildFormation(cards, formationType, trumpSuit)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]Card, len(formation.Cards))
+	copy(normalized, formation.Cards)
+	sort.SliceStable(normalized, func(i, j int) bool {
+		a, b := normalized[i], normalized[j]
+		if strengthA, strengthB := cardStrength(a, trumpSuit), cardStrength(b, trumpSuit); strengthA != strengthB {
+			return strengthA > strengthB
+		}
+		if a.Suit != b.Suit {
+			return a.Suit < b.Suit
+		}
+		return a.DeckID < b.DeckID
+	})
+
+	formation.Cards = normalized
+	formation.Suit = effectiveSuit(normalized[0], trumpSuit)
+	return formation, nil
+}
+
+// checkDistinctCards fails if the same physical card, of the same deck,
+// appears more than once
+func checkDistinctCards(cards []Card) error {
+	for i := range cards {
+		for _, other := range cards[i+1:] {
+			if cards[i].IsEqual(other) {
+				return fmt.Errorf("card %s submitted more than once", other.String())
+			}
+		}
+	}
+	return nil
+}
+
+// groupPairs groups cards of the same face into pairs, in the order each face
+// first appears, failing unless every face appears exactly twice
+func groupPairs(cards []Card) ([][]Card, error) {
+	pairs := make([][]Card, 0, len(cards)/2)
+	for _, card := range cards {
+		grouped := false
+		for i, pair := range pairs {
+			if pair[0].IsSameFace(card) {
+				pairs[i] = append(pair, card)
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			pairs = append(pairs, []Card{card})
+		}
+	}
+
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("tractor formation requires each rank to appear exactly twice, but %s appears %d times", pair[0].String(), len(pair))
+		}
+	}
+	return pairs, nil
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +64,11 @@ func TestFormation_NewPairInvalid(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for non-matching cards")
 	}
+
+	// The same physical card cannot pair with itself
+	if _, err := NewPair(card1, card1); err == nil {
+		t.Error("Expected error for a pair of the same card twice")
+	}
 }
 
 func TestFormation_NewTractor(t *testing.T) {
@@ -190,6 +197,15 @@ func TestFormation_IsValid(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "Invalid pair - same card twice",
+			formation: &Formation{
+				Type:  Pair,
+				Cards: []Card{NewCard(Hearts, King, 1), NewCard(Hearts, King, 1)},
+				Suit:  Hearts,
+			},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -333,6 +349,18 @@ func TestFormation_Compare(t *testing.T) {
 			other:     trumpFormation,
 			expected:  1,
 		},
+		{
+			name:      "Off-suit higher card loses to the led suit",
+			formation: &Formation{Type: Single, Cards: []Card{NewCard(Clubs, Ace, 1)}, Suit: Clubs},
+			other:     &Formation{Type: Single, Cards: []Card{NewCard(Spades, Three, 1)}, Suit: Spades},
+			expected:  -1,
+		},
+		{
+			name:      "Led suit beats off-suit higher card",
+			formation: &Formation{Type: Single, Cards: []Card{NewCard(Spades, Three, 1)}, Suit: Spades},
+			other:     &Formation{Type: Single, Cards: []Card{NewCard(Clubs, Ace, 1)}, Suit: Clubs},
+			expected:  1,
+		},
 		{
 			name:      "Different formation types return 0",
 			formation: &Formation{Type: Single, Cards: []Card{NewCard(Hearts, King, 1)}},
@@ -384,6 +412,12 @@ func TestValidateFormation(t *testing.T) {
 			formationType: Pair,
 			wantError:     true,
 		},
+		{
+			name:          "Invalid pair - same card twice",
+			cards:         []Card{NewCard(Hearts, King, 1), NewCard(Hearts, King, 1)},
+			formationType: Pair,
+			wantError:     true,
+		},
 		{
 			name: "Valid tractor",
 			cards: []Card{
@@ -399,6 +433,26 @@ func TestValidateFormation(t *testing.T) {
 			formationType: Tractor,
 			wantError:     true,
 		},
+		{
+			name: "Invalid tractor - two pairs and a single",
+			cards: []Card{
+				NewCard(Hearts, King, 1), NewCard(Hearts, King, 2),
+				NewCard(Hearts, Ace, 1), NewCard(Hearts, Ace, 2),
+				NewCard(Hearts, Queen, 1),
+			},
+			formationType: Tractor,
+			wantError:     true,
+		},
+		{
+			name: "Invalid tractor - two pairs and two unmatched cards",
+			cards: []Card{
+				NewCard(Hearts, King, 1), NewCard(Hearts, King, 2),
+				NewCard(Hearts, Ace, 1), NewCard(Hearts, Ace, 2),
+				NewCard(Hearts, Queen, 1), NewCard(Hearts, Jack, 1),
+			},
+			formationType: Tractor,
+			wantError:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -409,4 +463,102 @@ func TestValidateFormation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestValidateFormation_TractorLeftoverCard(t *testing.T) {
+	queen := NewCard(Hearts, Queen, 1)
+	cards := []Card{
+		NewCard(Hearts, King, 1), NewCard(Hearts, King, 2),
+		NewCard(Hearts, Ace, 1), NewCard(Hearts, Ace, 2),
+		queen, NewCard(Hearts, Jack, 1),
+	}
+
+	err := ValidateFormation(cards, Tractor, Spades)
+	if err == nil || !strings.Contains(err.Error(), queen.String()) {
+		t.Errorf("Expected an error naming the unpaired %s, got %v", queen.String(), err)
+	}
+}
+
+func TestBuildFormation(t *testing.T) {
+	kings := []Card{NewCard(Hearts, King, 1), NewCard(Hearts, King, 2)}
+	tractor := []Card{
+		NewCard(Clubs, Nine, 1), NewCard(Clubs, Ten, 1),
+		NewCard(Clubs, Nine, 2), NewCard(Clubs, Ten, 2),
+	}
+
+	formation, err := BuildFormation(kings, Pair, Spades)
+	if err != nil {
+		t.Fatalf("BuildFormation(pair) error = %v", err)
+	}
+	if formation.Type != Pair || formation.Suit != Hearts {
+		t.Errorf("Expected a pair of Hearts, got %s", formation)
+	}
+
+	formation, err = BuildFormation(tractor, Tractor, Spades)
+	if err != nil {
+		t.Fatalf("BuildFormation(tractor) error = %v", err)
+	}
+	if formation.Type != Tractor || formation.Suit != Clubs || len(formation.Cards) != 4 {
+		t.Errorf("Expected a four-card tractor of Clubs, got %s", formation)
+	}
+
+	mislabelled := []struct {
+		name          string
+		cards         []Card
+		formationType FormationType
+	}{
+		{"Pair claimed as tractor", kings, Tractor},
+		{"Pair claimed as single", kings, Single},
+		{"Tractor claimed as pair", tractor, Pair},
+		{"Unmatched cards claimed as pair", []Card{NewCard(Hearts, King, 1), NewCard(Hearts, Queen, 1)}, Pair},
+		{"Same card submitted twice", []Card{NewCard(Hearts, King, 1), NewCard(Hearts, King, 1)}, Pair},
+		{"Unknown type", kings, FormationType(7)},
+	}
+	for _, tt := range mislabelled {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BuildFormation(tt.cards, tt.formationType, Spades); err == nil {
+				t.Errorf("Expected error for %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestNormalizeFormation(t *testing.T) {
+	// The same tractor listed in two orders normalizes to the same cards
+	unordered := []Card{
+		NewCard(Clubs, Nine, 2), NewCard(Clubs, Ten, 1),
+		NewCard(Clubs, Nine, 1), NewCard(Clubs, Ten, 2),
+	}
+	ordered := []Card{
+		NewCard(Clubs, Ten, 1), NewCard(Clubs, Ten, 2),
+		NewCard(Clubs, Nine, 1), NewCard(Clubs, Nine, 2),
+	}
+	for _, cards := range [][]Card{unordered, ordered} {
+		formation, err := NormalizeFormation(cards, Tractor, Spades)
+		if err != nil {
+			t.Fatalf("NormalizeFormation() error = %v", err)
+		}
+		if !reflect.DeepEqual(formation.Cards, ordered) {
+			t.Errorf("Expected cards %v, got %v", ordered, formation.Cards)
+		}
+	}
+
+	// A pair of jokers plays as the trump suit
+	jokers, err := NormalizeFormation([]Card{NewJoker(SmallJoker, 2), NewJoker(SmallJoker, 1)}, Pair, Hearts)
+	if err != nil {
+		t.Fatalf("NormalizeFormation() error = %v", err)
+	}
+	if jokers.Suit != Hearts || jokers.Cards[0].DeckID != 1 {
+		t.Errorf("Expected a pair of Hearts trumps starting with deck 1, got %s", jokers)
+	}
+
+	if _, err := NormalizeFormation([]Card{NewCard(Hearts, King, 1), NewCard(Hearts, Queen, 1)}, Pair, Spades); err == nil {
+		t.Error("Expected error normalizing unmatched cards as a pair")
+	}
+	if _, err := NormalizeFormation([]Card{NewCard(Suit(9), King, 1)}, Single, Spades); err == nil {
+		t.Error("Expected error normalizing a card of an unknown suit")
+	}
+	if _, err := NormalizeFormation([]Card{NewCard(Hearts, King, 1)}, Single, Suit(9)); err == nil {
+		t.Error("Expected error normalizing under an unknown trump suit")
+	}
+}
@@ -5,6 +5,18 @@ import (
 	"time"
 )
 
+// Deal sizes for the four-player game played with two decks and four jokers
+const (
+	PlayerCount = 4
+	HandSize    = 25
+	KittySize   = 8
+	DeckSize    = PlayerCount*HandSize + KittySize
+	DeckPoints  = 200 // Two each of the 5, 10 and K of every suit
+)
+
+// DealToKitty marks a card dealt to the kitty in a game's DealOrder
+const DealToKitty = -1
+
 // GamePhase represents the current phase of the game
 type GamePhase int
 
@@ -16,6 +28,7 @@ const (
 	PhaseKittyExchange
 	PhasePlaying
 	PhaseEnded
+	PhaseAborted
 )
 
 func (p GamePhase) String() string {
@@ -34,6 +47,8 @@ func (p GamePhase) String() string {
 		return "Playing"
 	case PhaseEnded:
 		return "Ended"
+	case PhaseAborted:
+		return "Aborted"
 	default:
 		return "Unknown"
 	}
@@ -64,6 +79,16 @@ func (p PlayerPosition) String() string {
 	}
 }
 
+// ParsePlayerPosition converts a position name such as "North" back to a PlayerPosition
+func ParsePlayerPosition(name string) (PlayerPosition, error) {
+	for position := North; position <= West; position++ {
+		if position.String() == name {
+			return position, nil
+		}
+	}
+	return North, fmt.Errorf("unknown player position: %s", name)
+}
+
 // GetNextPosition returns the next position clockwise
 func (p PlayerPosition) GetNextPosition() PlayerPosition {
 	return PlayerPosition((int(p) + 1) % 4)
@@ -81,6 +106,9 @@ type Player struct {
 	Position PlayerPosition `json:"position"`
 	Hand     []Card         `json:"hand"`
 	HasPassed bool          `json:"has_passed"` // For bidding phase
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+	ReconnectDeadline *time.Time `json:"reconnect_deadline,omitempty"` // The seat is held for the player until then
+	IsBot    bool           `json:"is_bot"` // Seat taken over by a bot after a disconnect
 }
 
 // NewPlayer creates a new player
@@ -89,7 +117,7 @@ func NewPlayer(id, name string, position PlayerPosition) *Player {
 		ID:       id,
 		Name:     name,
 		Position: position,
-		Hand:     make([]Card, 0, 25),
+		Hand:     make([]Card, 0, HandSize),
 		HasPassed: false,
 	}
 }
@@ -112,7 +140,7 @@ func (p *Player) RemoveCard(card Card) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("card not found in player's hand")
+	return fmt.Errorf("%w: %s", ErrCardNotHeld, card.String())
 }
 
 // RemoveCards removes multiple cards from the player's hand
@@ -150,22 +178,61 @@ func (p *Player) GetHandSize() int {
 	return len(p.Hand)
 }
 
+// IsDisconnected checks if the player has lost their connection to the game
+func (p *Player) IsDisconnected() bool {
+	return p.DisconnectedAt != nil
+}
+
+// ConnectionStatus describes whether a player is at the table
+type ConnectionStatus string
+
+const (
+	// StatusConnected players are playing for themselves
+	StatusConnected ConnectionStatus = "connected"
+	// StatusReconnecting players have dropped but their seat is held until
+	// their reconnect deadline
+	StatusReconnecting ConnectionStatus = "reconnecting"
+	// StatusDisconnected players missed their deadline and have their turns
+	// played for them
+	StatusDisconnected ConnectionStatus = "disconnected"
+	// StatusReplacedByBot players have had their seat handed to a bot
+	StatusReplacedByBot ConnectionStatus = "replaced_by_bot"
+)
+
+// ConnectionStatus returns the player's connection status at the given time
+func (p *Player) ConnectionStatus(at time.Time) ConnectionStatus {
+	switch {
+	case p.IsBot:
+		return StatusReplacedByBot
+	case !p.IsDisconnected():
+		return StatusConnected
+	case p.ReconnectDeadline != nil && at.Before(*p.ReconnectDeadline):
+		return StatusReconnecting
+	default:
+		return StatusDisconnected
+	}
+}
+
 // BidInfo represents a bid made by a player
 type BidInfo struct {
 	PlayerID string `json:"player_id"`
 	Amount   int    `json:"amount"`
 	IsPassed bool   `json:"is_passed"`
+	IsAuto   bool   `json:"is_auto,omitempty"` // Passed by the server for a bot, disconnected or timed-out player
 }
 
 // GameState represents the complete state of a Chinese Bridge game
 type GameState struct {
 	ID                string            `json:"id"`
 	RoomID            string            `json:"room_id"`
+	Rules             GameRules         `json:"rules"`
 	Phase             GamePhase         `json:"phase"`
 	Players           [4]*Player        `json:"players"`
 	CurrentPlayerTurn PlayerPosition    `json:"current_player_turn"`
+	StartingPosition  PlayerPosition    `json:"starting_position"` // Seat that bid first, rotated between a room's games
 	Declarer          *PlayerPosition   `json:"declarer,omitempty"`
 	TrumpSuit         *Suit             `json:"trump_suit,omitempty"`
+	RejectedTrumps    int               `json:"rejected_trumps,omitempty"` // Declarations rejected in this trump declaration phase
 	Contract          int               `json:"contract"`
 	CurrentBid        int               `json:"current_bid"`
 	BidHistory        []BidInfo         `json:"bid_history"`
@@ -175,34 +242,60 @@ type GameState struct {
 	Kitty             []Card            `json:"kitty"`
 	Scores            map[string]int    `json:"scores"`
 	WinnerTeam        *string           `json:"winner_team,omitempty"` // "declarer" or "defenders"
+	ObservedVoids     map[PlayerPosition][]Suit `json:"observed_voids,omitempty"` // Suits each player has shown they are out of
+	Reneges           []Renege          `json:"reneges,omitempty"` // Plays that failed to follow suit when the player could
+	CalledCard        *Card             `json:"called_card,omitempty"` // Card naming the declarer's partner under called-card partnerships
+	CalledPartner     *PlayerPosition   `json:"called_partner,omitempty"` // Revealed when the called card is played
+	TurnDeadline      *time.Time        `json:"turn_deadline,omitempty"` // When the current player's time to act runs out
+	Concessions       []PlayerPosition  `json:"concessions,omitempty"` // Players who have agreed to concede for their team
+	ConcededBy        *string           `json:"conceded_by,omitempty"` // The team that conceded, if the game ended that way
+	DealOrder         []int             `json:"deal_order,omitempty"` // Position each card was dealt to, cleared once bidding starts
+	Version           int               `json:"version"` // Incremented on every save for optimistic concurrency
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 }
 
-// NewGameState creates a new game state
-func NewGameState(id, roomID string, playerIDs []string, playerNames []string) (*GameState, error) {
-	if len(playerIDs) != 4 || len(playerNames) != 4 {
-		return nil, fmt.Errorf("exactly 4 players required")
+// NewGameState creates a new game state played under the given rules
+func NewGameState(id, roomID string, playerIDs []string, playerNames []string, rules GameRules) (*GameState, error) {
+	if len(playerIDs) != PlayerCount || len(playerNames) != PlayerCount {
+		return nil, fmt.Errorf("exactly %d players required", PlayerCount)
+	}
+	// Players are looked up and scored by ID, so each needs a distinct one
+	for i, playerID := range playerIDs {
+		if playerID == "" {
+			return nil, fmt.Errorf("player %d has an empty ID", i+1)
+		}
+		for _, other := range playerIDs[:i] {
+			if other == playerID {
+				return nil, fmt.Errorf("player ID %s is used by more than one player", playerID)
+			}
+		}
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid game rules: %w", err)
 	}
 
 	gameState := &GameState{
 		ID:                id,
 		RoomID:            roomID,
+		Rules:             rules,
 		Phase:             PhaseWaiting,
 		CurrentPlayerTurn: North,
+		StartingPosition:  North,
 		Contract:          0,
-		CurrentBid:        125, // Starting bid
+		CurrentBid:        rules.StartingBid,
 		BidHistory:        make([]BidInfo, 0),
 		ConsecutivePasses: 0,
 		Tricks:            make([]Trick, 0),
-		Kitty:             make([]Card, 0, 8),
+		Kitty:             make([]Card, 0, KittySize),
 		Scores:            make(map[string]int),
 		CreatedAt:         time.Now(),
 		UpdatedAt:         time.Now(),
 	}
 
 	// Initialize players
-	for i := 0; i < 4; i++ {
+	for i := 0; i < PlayerCount; i++ {
 		gameState.Players[i] = NewPlayer(playerIDs[i], playerNames[i], PlayerPosition(i))
 		gameState.Scores[playerIDs[i]] = 0
 	}
@@ -233,35 +326,134 @@ func (gs *GameState) GetCurrentPlayer() *Player {
 	return gs.GetPlayerByPosition(gs.CurrentPlayerTurn)
 }
 
+// MarkDisconnected records that a player lost their connection at the given
+// time, holding their seat for the grace period
+func (gs *GameState) MarkDisconnected(playerID string, at time.Time, grace time.Duration) error {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	if !player.IsDisconnected() {
+		deadline := at.Add(grace)
+		player.DisconnectedAt = &at
+		player.ReconnectDeadline = &deadline
+		gs.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// MarkConnected clears a player's disconnected status when they return to the game
+func (gs *GameState) MarkConnected(playerID string) error {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	if player.IsDisconnected() {
+		player.DisconnectedAt = nil
+		player.ReconnectDeadline = nil
+		gs.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// ReplaceWithBot hands a player's seat to a bot for the rest of the game
+func (gs *GameState) ReplaceWithBot(playerID string) error {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	player.IsBot = true
+	gs.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetDisconnectedPlayers returns the IDs of players who are currently disconnected
+func (gs *GameState) GetDisconnectedPlayers() []string {
+	disconnected := make([]string, 0)
+	for _, player := range gs.Players {
+		if player.IsDisconnected() {
+			disconnected = append(disconnected, player.ID)
+		}
+	}
+	return disconnected
+}
+
 // NextTurn advances to the next player's turn
 func (gs *GameState) NextTurn() {
 	gs.CurrentPlayerTurn = gs.CurrentPlayerTurn.GetNextPosition()
+	gs.refreshTurnDeadline()
 	gs.UpdatedAt = time.Now()
 }
 
 // DealCards deals cards to all players and sets up the kitty
 func (gs *GameState) DealCards(deck *Deck) error {
+	return gs.deal(deck, false)
+}
+
+// DealCardsRecordingOrder deals like DealCards and also records who each card
+// went to in DealOrder, so clients can animate the deal
+func (gs *GameState) DealCardsRecordingOrder(deck *Deck) error {
+	return gs.deal(deck, true)
+}
+
+// DealCardsFromSeed deals a new deck shuffled with the seed, reproducing the
+// deal of a game started with the same seed
+func (gs *GameState) DealCardsFromSeed(seed int64) error {
+	deck := NewDeck()
+	deck.ShuffleWithSeed(seed)
+	return gs.DealCards(deck)
+}
+
+// deal hands out the deck, optionally recording the deal order
+func (gs *GameState) deal(deck *Deck, recordOrder bool) error {
 	if gs.Phase != PhaseWaiting {
-		return fmt.Errorf("can only deal cards in waiting phase")
+		return fmt.Errorf("%w: can only deal cards in waiting phase", ErrWrongPhase)
 	}
 
-	// Deal 25 cards to each player
-	for i := 0; i < 4; i++ {
-		cards, err := deck.Deal(25)
+	// A deck of the wrong size would leave hands short or cards undealt, and
+	// one with missing or duplicated cards, e.g. rebuilt from tampered state,
+	// would break the game's card accounting
+	if deck.Remaining() != DeckSize {
+		return fmt.Errorf("deck must have exactly %d cards to deal %d hands of %d and a kitty of %d, found %d",
+			DeckSize, PlayerCount, HandSize, KittySize, deck.Remaining())
+	}
+	if err := deck.ValidateDeckComposition(); err != nil {
+		return fmt.Errorf("invalid deck: %w", err)
+	}
+
+	var order []int
+	dealTo := func(target, count int) {
+		if recordOrder {
+			for j := 0; j < count; j++ {
+				order = append(order, target)
+			}
+		}
+	}
+
+	// Deal a full hand to each player
+	for i := 0; i < PlayerCount; i++ {
+		cards, err := deck.Deal(HandSize)
 		if err != nil {
 			return fmt.Errorf("failed to deal cards to player %d: %w", i, err)
 		}
 		gs.Players[i].AddCards(cards)
+		dealTo(i, len(cards))
 	}
 
-	// Remaining 8 cards go to kitty
-	kittyCards, err := deck.Deal(8)
+	// Remaining cards go to kitty
+	kittyCards, err := deck.Deal(KittySize)
 	if err != nil {
 		return fmt.Errorf("failed to deal kitty cards: %w", err)
 	}
 	gs.Kitty = kittyCards
+	dealTo(DealToKitty, len(kittyCards))
+	gs.DealOrder = order
 
 	gs.Phase = PhaseBidding
+	gs.refreshTurnDeadline()
 	gs.UpdatedAt = time.Now()
 	return nil
 }
@@ -269,29 +461,21 @@ func (gs *GameState) DealCards(deck *Deck) error {
 // PlaceBid places a bid for the current player
 func (gs *GameState) PlaceBid(playerID string, bidAmount int) error {
 	if gs.Phase != PhaseBidding {
-		return fmt.Errorf("not in bidding phase")
+		return fmt.Errorf("%w: not in bidding phase", ErrWrongPhase)
 	}
 
 	currentPlayer := gs.GetCurrentPlayer()
 	if currentPlayer.ID != playerID {
-		return fmt.Errorf("not player's turn")
+		return ErrNotYourTurn
 	}
 
 	if currentPlayer.HasPassed {
-		return fmt.Errorf("player has already passed and cannot bid")
+		return fmt.Errorf("%w: player has already passed and cannot bid", ErrInvalidBid)
 	}
 
 	// Validate bid amount
-	if bidAmount < 95 || bidAmount > 200 {
-		return fmt.Errorf("bid must be between 95 and 200")
-	}
-
-	if bidAmount >= gs.CurrentBid {
-		return fmt.Errorf("bid must be lower than current bid of %d", gs.CurrentBid)
-	}
-
-	if (gs.CurrentBid-bidAmount)%5 != 0 {
-		return fmt.Errorf("bid must decrease by increments of 5")
+	if err := gs.Rules.ValidateBid(bidAmount, gs.CurrentBid); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBid, err)
 	}
 
 	// Record the bid
@@ -303,24 +487,55 @@ func (gs *GameState) PlaceBid(playerID string, bidAmount int) error {
 
 	gs.CurrentBid = bidAmount
 	gs.ConsecutivePasses = 0
-	gs.NextTurn()
+	gs.DealOrder = nil
+
+	// A bid by the last player yet to pass wins the auction outright
+	if gs.remainingBidders() == 1 {
+		gs.setDeclarer(currentPlayer, bidAmount)
+	} else {
+		gs.nextBidder()
+	}
 
+	gs.UpdatedAt = time.Now()
 	return nil
 }
 
+// GetValidBids returns the amounts the next bid may be, highest first: below
+// the current bid by a multiple of the rules' increment and within their limits
+func (gs *GameState) GetValidBids() []int {
+	increment := gs.Rules.BidIncrement
+	if increment <= 0 {
+		return nil
+	}
+
+	var bids []int
+	for bid := gs.CurrentBid - increment; bid >= gs.Rules.MinBid; bid -= increment {
+		if gs.Rules.ValidateBid(bid, gs.CurrentBid) == nil {
+			bids = append(bids, bid)
+		}
+	}
+	return bids
+}
+
 // PassBid passes the current player's turn in bidding
 func (gs *GameState) PassBid(playerID string) error {
+	return gs.passBid(playerID, false)
+}
+
+// passBid passes the current player's turn, recording whether the server
+// passed for them
+func (gs *GameState) passBid(playerID string, auto bool) error {
 	if gs.Phase != PhaseBidding {
-		return fmt.Errorf("not in bidding phase")
+		return fmt.Errorf("%w: not in bidding phase", ErrWrongPhase)
 	}
 
 	currentPlayer := gs.GetCurrentPlayer()
 	if currentPlayer.ID != playerID {
-		return fmt.Errorf("not player's turn")
+		return ErrNotYourTurn
 	}
 
 	if currentPlayer.HasPassed {
-		return fmt.Errorf("player has already passed")
+		return fmt.Errorf("%w: player has already passed", ErrInvalidBid)
 	}
 
 	// Mark player as passed
@@ -329,77 +544,225 @@ func (gs *GameState) PassBid(playerID string) error {
 		PlayerID: playerID,
 		Amount:   0,
 		IsPassed: true,
+		IsAuto:   auto,
 	})
 
 	gs.ConsecutivePasses++
+	gs.DealOrder = nil
+
+	// A pass is final, so bidding ends once everyone but the lowest bidder
+	// has passed. Before anyone bids, the last player still gets a turn.
+	remaining := gs.remainingBidders()
+	lowest := gs.lowestBid()
+	switch {
+	case lowest != nil && remaining <= 1:
+		gs.setDeclarer(gs.GetPlayer(lowest.PlayerID), lowest.Amount)
+	case remaining == 0:
+		gs.handleAllPassed()
+	default:
+		gs.nextBidder()
+	}
 
-	// Check if bidding should end
-	if gs.ConsecutivePasses >= 3 {
-		// Find the declarer (last player to make a bid)
-		for i := len(gs.BidHistory) - 1; i >= 0; i-- {
-			if !gs.BidHistory[i].IsPassed {
-				declarerPlayer := gs.GetPlayer(gs.BidHistory[i].PlayerID)
-				if declarerPlayer != nil {
-					gs.Declarer = &declarerPlayer.Position
-					gs.Contract = gs.BidHistory[i].Amount
-					gs.Phase = PhaseTrumpDeclaration
-					gs.CurrentPlayerTurn = declarerPlayer.Position
-					break
-				}
-			}
+	gs.UpdatedAt = time.Now()
+	return nil
+}
+
+// UndoLastBid takes back the player's most recent bid or pass, if the rules
+// allow it and no one has acted since. The current bid, pass count and turn
+// are restored to what they were before the player acted.
+func (gs *GameState) UndoLastBid(playerID string) error {
+	if gs.Phase != PhaseBidding {
+		return fmt.Errorf("%w: not in bidding phase", ErrWrongPhase)
+	}
+
+	if !gs.Rules.AllowBidUndo {
+		return fmt.Errorf("%w: undoing bids is not allowed", ErrInvalidBid)
+	}
+
+	if len(gs.BidHistory) == 0 {
+		return fmt.Errorf("%w: no bid to undo", ErrInvalidBid)
+	}
+
+	last := gs.BidHistory[len(gs.BidHistory)-1]
+	if last.PlayerID != playerID {
+		return fmt.Errorf("%w: only the player who acted last can undo", ErrNotYourTurn)
+	}
+
+	player := gs.GetPlayer(playerID)
+	gs.BidHistory = gs.BidHistory[:len(gs.BidHistory)-1]
+	if last.IsPassed {
+		player.HasPassed = false
+	}
+
+	// The current bid is the lowest remaining bid, and the pass count the
+	// passes made since it
+	gs.CurrentBid = gs.Rules.StartingBid
+	gs.ConsecutivePasses = 0
+	for _, bid := range gs.BidHistory {
+		if bid.IsPassed {
+			gs.ConsecutivePasses++
+			continue
 		}
-	} else {
-		gs.NextTurn()
+		gs.CurrentBid = bid.Amount
+		gs.ConsecutivePasses = 0
 	}
 
+	gs.CurrentPlayerTurn = player.Position
+	gs.refreshTurnDeadline()
 	gs.UpdatedAt = time.Now()
 	return nil
 }
 
+// remainingBidders counts the players who have not passed
+func (gs *GameState) remainingBidders() int {
+	remaining := 0
+	for _, player := range gs.Players {
+		if !player.HasPassed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// lowestBid returns the standing bid, which is the latest since every bid
+// must be lower than the one before, or nil if no one has bid
+func (gs *GameState) lowestBid() *BidInfo {
+	for i := len(gs.BidHistory) - 1; i >= 0; i-- {
+		if !gs.BidHistory[i].IsPassed {
+			return &gs.BidHistory[i]
+		}
+	}
+	return nil
+}
+
+// nextBidder passes the turn to the next player who has not passed
+func (gs *GameState) nextBidder() {
+	for i := 0; i < len(gs.Players); i++ {
+		gs.NextTurn()
+		if !gs.GetCurrentPlayer().HasPassed {
+			return
+		}
+	}
+}
+
+// setDeclarer ends bidding with the given player as declarer
+func (gs *GameState) setDeclarer(player *Player, contract int) {
+	position := player.Position
+	gs.Declarer = &position
+	gs.Contract = contract
+	gs.Phase = PhaseTrumpDeclaration
+	gs.CurrentPlayerTurn = position
+	gs.refreshTurnDeadline()
+}
+
+// handleAllPassed resolves a bidding round in which every player passed:
+// either the hand is thrown in for a redeal, or the first bidder is forced
+// to take the starting bid as the contract
+func (gs *GameState) handleAllPassed() {
+	if gs.Rules.RedealOnAllPass {
+		gs.resetForRedeal()
+		return
+	}
+
+	firstBidder := gs.GetPlayer(gs.BidHistory[0].PlayerID)
+	gs.setDeclarer(firstBidder, gs.Rules.StartingBid)
+}
+
+// resetForRedeal returns the game to the waiting phase with empty hands so it can be dealt again
+func (gs *GameState) resetForRedeal() {
+	for _, player := range gs.Players {
+		player.Hand = make([]Card, 0, HandSize)
+		player.HasPassed = false
+	}
+	gs.Kitty = make([]Card, 0, KittySize)
+	gs.BidHistory = make([]BidInfo, 0)
+	gs.ConsecutivePasses = 0
+	gs.CurrentBid = gs.Rules.StartingBid
+	gs.Phase = PhaseWaiting
+	gs.refreshTurnDeadline()
+}
+
 // DeclareTrump declares the trump suit
 func (gs *GameState) DeclareTrump(playerID string, trumpSuit Suit) error {
 	if gs.Phase != PhaseTrumpDeclaration {
-		return fmt.Errorf("not in trump declaration phase")
+		return fmt.Errorf("%w: not in trump declaration phase", ErrWrongPhase)
 	}
 
 	if gs.Declarer == nil {
-		return fmt.Errorf("no declarer set")
+		return fmt.Errorf("%w: no declarer set", ErrWrongPhase)
 	}
 
 	declarer := gs.GetPlayerByPosition(*gs.Declarer)
 	if declarer.ID != playerID {
-		return fmt.Errorf("only the declarer can declare trump")
+		return fmt.Errorf("%w: only the declarer can declare trump", ErrNotYourTurn)
+	}
+
+	if err := gs.Rules.ValidateTrump(trumpSuit, declarer.Hand); err != nil {
+		// The declarer may try again until the rules' attempts run out, after
+		// which a trump is chosen for them
+		gs.RejectedTrumps++
+		if gs.Rules.TrumpAttempts == 0 || gs.RejectedTrumps < gs.Rules.TrumpAttempts {
+			gs.UpdatedAt = time.Now()
+			return fmt.Errorf("%w: %v", ErrInvalidTrump, err)
+		}
+		trumpSuit = gs.fallbackTrump(declarer.Hand)
 	}
 
 	gs.TrumpSuit = &trumpSuit
 	gs.Phase = PhaseKittyExchange
+	gs.refreshTurnDeadline()
 	gs.UpdatedAt = time.Now()
 
 	return nil
 }
 
+// fallbackTrump is the trump forced on a declarer who has run out of
+// attempts: no-trump where allowed, otherwise their longest suit
+func (gs *GameState) fallbackTrump(hand []Card) Suit {
+	if gs.Rules.AllowNoTrump {
+		return NoTrump
+	}
+	return longestSuit(hand)
+}
+
 // ExchangeKitty allows the declarer to exchange cards with the kitty
 func (gs *GameState) ExchangeKitty(playerID string, cardsToDiscard []Card) error {
 	if gs.Phase != PhaseKittyExchange {
-		return fmt.Errorf("not in kitty exchange phase")
+		return fmt.Errorf("%w: not in kitty exchange phase", ErrWrongPhase)
 	}
 
 	if gs.Declarer == nil {
-		return fmt.Errorf("no declarer set")
+		return fmt.Errorf("%w: no declarer set", ErrWrongPhase)
 	}
 
 	declarer := gs.GetPlayerByPosition(*gs.Declarer)
 	if declarer.ID != playerID {
-		return fmt.Errorf("only the declarer can exchange kitty")
+		return fmt.Errorf("%w: only the declarer can exchange kitty", ErrNotYourTurn)
+	}
+
+	if gs.Rules.Partnership == CalledCard && gs.CalledCard == nil {
+		return fmt.Errorf("%w: the partner card must be called first", ErrWrongPhase)
 	}
 
-	if len(cardsToDiscard) != 8 {
-		return fmt.Errorf("must discard exactly 8 cards")
+	if len(cardsToDiscard) != KittySize {
+		return fmt.Errorf("must discard exactly %d cards", KittySize)
 	}
 
 	// Verify declarer has all cards to discard
 	if !declarer.HasCards(cardsToDiscard) {
-		return fmt.Errorf("player does not have all specified cards")
+		return fmt.Errorf("%w: player does not have all specified cards", ErrCardNotHeld)
+	}
+
+	if err := gs.Rules.ValidateDiscards(cardsToDiscard); err != nil {
+		return err
+	}
+
+	// Keep the hand and kitty so a swap that loses or duplicates cards can be undone
+	hand := append([]Card(nil), declarer.Hand...)
+	kitty := gs.Kitty
+	rollback := func() {
+		declarer.Hand = hand
+		gs.Kitty = kitty
 	}
 
 	// Add kitty cards to declarer's hand
@@ -407,13 +770,21 @@ func (gs *GameState) ExchangeKitty(playerID string, cardsToDiscard []Card) error
 
 	// Remove discarded cards from declarer's hand
 	if err := declarer.RemoveCards(cardsToDiscard); err != nil {
+		rollback()
 		return fmt.Errorf("failed to remove cards from hand: %w", err)
 	}
 
 	// Update kitty with discarded cards
 	gs.Kitty = cardsToDiscard
 
+	// Every card, and so all DeckPoints points, must still be in play
+	if err := gs.VerifyCardIntegrity(); err != nil {
+		rollback()
+		return fmt.Errorf("kitty exchange undone: %w", err)
+	}
+
 	gs.Phase = PhasePlaying
+	gs.refreshTurnDeadline()
 	gs.UpdatedAt = time.Now()
 
 	return nil
@@ -425,6 +796,99 @@ func (gs *GameState) StartNewTrick() {
 	gs.CurrentTrick = NewTrick(trickID, gs.CurrentPlayerTurn)
 }
 
+// PlayCards plays a formation for the current player and completes the trick
+// once all four players have played, returning the completed trick's result.
+// The result is nil while the trick is still being played.
+func (gs *GameState) PlayCards(playerID string, formation *Formation) (*TrickResult, error) {
+	if gs.Phase != PhasePlaying {
+		return nil, fmt.Errorf("%w: not in playing phase", ErrWrongPhase)
+	}
+
+	if gs.TrumpSuit == nil {
+		return nil, fmt.Errorf("%w: no trump suit declared", ErrWrongPhase)
+	}
+
+	currentPlayer := gs.GetCurrentPlayer()
+	if currentPlayer.ID != playerID {
+		return nil, ErrNotYourTurn
+	}
+
+	if gs.CurrentTrick == nil {
+		gs.StartNewTrick()
+	}
+	if err := gs.ValidateTrickInvariants(); err != nil {
+		return nil, err
+	}
+
+	renege, err := gs.checkPlay(currentPlayer, formation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gs.CurrentTrick.AddPlay(currentPlayer.Position, formation, *gs.TrumpSuit); err != nil {
+		return nil, err
+	}
+	if renege {
+		gs.recordRenege(currentPlayer.Position)
+	}
+	gs.recordObservedVoid(currentPlayer, formation)
+	gs.revealCalledPartner(currentPlayer.Position, formation)
+
+	if err := currentPlayer.RemoveCards(formation.Cards); err != nil {
+		return nil, fmt.Errorf("failed to remove cards from hand: %w", err)
+	}
+
+	if !gs.CurrentTrick.IsComplete {
+		gs.NextTurn()
+		return nil, nil
+	}
+
+	// The trick winner leads the next trick
+	winner := gs.GetTrickWinner(*gs.CurrentTrick)
+	gs.Tricks = append(gs.Tricks, *gs.CurrentTrick)
+	gs.CurrentTrick = nil
+	result := gs.GetTrickResult(len(gs.Tricks))
+	if winner != nil {
+		gs.CurrentPlayerTurn = winner.Position
+	}
+
+	if gs.IsGameComplete() {
+		gs.CalculateFinalScore()
+	}
+
+	gs.refreshTurnDeadline()
+	gs.UpdatedAt = time.Now()
+	return result, nil
+}
+
+// checkPlay checks the player may play the formation to the current trick,
+// reporting whether the play is a renege. Reneges are rejected unless the
+// rules penalize them instead, in which case they are recorded for scoring.
+// Under the matching combo rule, breaking up a pair or tractor of the led suit
+// matching the lead is always rejected.
+func (gs *GameState) checkPlay(player *Player, formation *Formation) (bool, error) {
+	trick := gs.CurrentTrick
+	if trick == nil {
+		trick = NewTrick("", gs.CurrentPlayerTurn)
+	}
+	if err := trick.ValidateFormationAgainstTrick(player.Position, formation, player.Hand, *gs.TrumpSuit); err != nil {
+		return false, err
+	}
+
+	led := trick.Plays[trick.Leader]
+	if led == nil {
+		return false, nil
+	}
+	if gs.Rules.MustPlayMatchingCombo && breaksLedSuitFormation(player.Hand, led, formation, *gs.TrumpSuit) {
+		return false, fmt.Errorf("%w: must play the matching formation held in the led suit", ErrMustFollow)
+	}
+	renege := gs.isRenege(player, formation)
+	if renege && !gs.Rules.PenalizeReneges {
+		return false, fmt.Errorf("%w: must play the cards held in the led suit", ErrMustFollow)
+	}
+	return renege, nil
+}
+
 // IsGameComplete checks if the game is complete
 func (gs *GameState) IsGameComplete() bool {
 	// Game is complete when all players have no cards left
@@ -436,49 +900,172 @@ func (gs *GameState) IsGameComplete() bool {
 	return true
 }
 
-// CalculateFinalScore calculates the final score and determines the winner
-func (gs *GameState) CalculateFinalScore() {
+// collectCards gathers every card in the game from the players' hands, the
+// kitty and the formations played in completed and current tricks
+func (gs *GameState) collectCards() []Card {
+	cards := make([]Card, 0, DeckSize)
+	for _, player := range gs.Players {
+		cards = append(cards, player.Hand...)
+	}
+	cards = append(cards, gs.Kitty...)
+
+	tricks := gs.Tricks
+	if gs.CurrentTrick != nil {
+		tricks = append(tricks[:len(tricks):len(tricks)], *gs.CurrentTrick)
+	}
+	for _, trick := range tricks {
+		for _, formation := range trick.Plays {
+			cards = append(cards, formation.Cards...)
+		}
+	}
+	return cards
+}
+
+// VerifyCardIntegrity checks that, once dealt, all DeckSize cards of the deck are
+// accounted for exactly once across hands, kitty and played tricks
+func (gs *GameState) VerifyCardIntegrity() error {
+	// The composition check also rejects any card seen twice
+	deck := &Deck{Cards: gs.collectCards()}
+	if err := deck.ValidateDeckComposition(); err != nil {
+		return fmt.Errorf("card integrity: %w", err)
+	}
+	return nil
+}
+
+// GetTrickWinner returns the player who won a completed trick
+func (gs *GameState) GetTrickWinner(trick Trick) *Player {
+	position, err := ParsePlayerPosition(trick.Winner)
+	if err != nil {
+		return nil
+	}
+	return gs.GetPlayerByPosition(position)
+}
+
+// GetCapturedPoints returns the points each player has captured from the tricks they won
+func (gs *GameState) GetCapturedPoints() map[string]int {
+	captured := make(map[string]int, len(gs.Players))
+	for _, player := range gs.Players {
+		captured[player.ID] = 0
+	}
+
+	for _, trick := range gs.Tricks {
+		if winner := gs.GetTrickWinner(trick); winner != nil {
+			captured[winner.ID] += trick.Points
+		}
+	}
+	return captured
+}
+
+// GetKittyPoints returns the total point value of the cards in the kitty
+func (gs *GameState) GetKittyPoints() int {
+	kittyPoints := 0
+	for _, card := range gs.Kitty {
+		kittyPoints += card.GetPointValue()
+	}
+	return kittyPoints
+}
+
+// defendersWinKitty reports whether a defender won the final trick, which
+// awards them the kitty
+func (gs *GameState) defendersWinKitty() bool {
+	if gs.Declarer == nil || len(gs.Tricks) == 0 {
+		return false
+	}
+	lastWinner := gs.GetTrickWinner(gs.Tricks[len(gs.Tricks)-1])
+	return lastWinner != nil && !gs.IsOnDeclarerTeam(lastWinner.Position)
+}
+
+// GetDefendersPoints calculates the total points captured by the defenders,
+// including the kitty, scaled by the rules' kitty multiplier, when a defender
+// wins the final trick
+func (gs *GameState) GetDefendersPoints() int {
 	if gs.Declarer == nil {
-		return
+		return 0
+	}
+
+	defendersPoints := gs.GetDefendersTrickPoints()
+
+	// Add kitty points to the final trick winner's team
+	if gs.defendersWinKitty() {
+		defendersPoints += gs.GetKittyPoints() * gs.Rules.KittyMultiplier
+	}
+
+	return defendersPoints
+}
+
+// GetDefendersTrickPoints returns the points the defenders have captured in
+// the completed tricks so far. The kitty is left out, as it is only awarded
+// with the last trick.
+func (gs *GameState) GetDefendersTrickPoints() int {
+	if gs.Declarer == nil {
+		return 0
 	}
 
-	// Calculate total points captured by defenders
 	defendersPoints := 0
 	for _, trick := range gs.Tricks {
-		winner := gs.GetPlayer(trick.Winner)
-		if winner != nil && winner.Position != *gs.Declarer && winner.Position != gs.Declarer.GetPartnerPosition() {
+		winner := gs.GetTrickWinner(trick)
+		if winner != nil && !gs.IsOnDeclarerTeam(winner.Position) {
 			defendersPoints += trick.Points
 		}
 	}
+	return defendersPoints
+}
 
-	// Add kitty points to the final trick winner's team
-	if len(gs.Tricks) > 0 {
-		lastTrick := gs.Tricks[len(gs.Tricks)-1]
-		lastWinner := gs.GetPlayer(lastTrick.Winner)
-		if lastWinner != nil {
-			kittyPoints := 0
-			for _, card := range gs.Kitty {
-				kittyPoints += card.GetPointValue()
-			}
-
-			// If last trick winner is on defenders team, add kitty points to defenders
-			if lastWinner.Position != *gs.Declarer && lastWinner.Position != gs.Declarer.GetPartnerPosition() {
-				defendersPoints += kittyPoints
-			}
+// GetPointsInPlay returns the points on the cards that have not been captured
+// yet: those in the players' hands and in the trick in progress. Together
+// with the points captured in completed tricks and the kitty they make up
+// every point in the deck.
+func (gs *GameState) GetPointsInPlay() int {
+	points := 0
+	for _, player := range gs.Players {
+		for _, card := range player.Hand {
+			points += card.GetPointValue()
 		}
 	}
+	if gs.CurrentTrick != nil {
+		for _, formation := range gs.CurrentTrick.Plays {
+			points += formation.GetPointValue()
+		}
+	}
+	return points
+}
+
+// CalculateFinalScore calculates the final score, including any renege
+// penalties, and determines the winner
+func (gs *GameState) CalculateFinalScore() {
+	if gs.Declarer == nil {
+		return
+	}
 
-	// Determine winner
-	if defendersPoints >= gs.Contract {
-		gs.WinnerTeam = stringPtr("defenders")
-	} else {
-		gs.WinnerTeam = stringPtr("declarer")
+	result := gs.GetScoreResult()
+	gs.WinnerTeam = stringPtr(result.WinnerTeam)
+
+	// Record the points each player captured
+	for playerID, points := range gs.GetCapturedPoints() {
+		gs.Scores[playerID] = points
 	}
 
 	gs.Phase = PhaseEnded
 	gs.UpdatedAt = time.Now()
 }
 
+// Abort ends the game without a result, e.g. when an admin terminates a
+// stuck game. No team wins and no scores are recorded.
+func (gs *GameState) Abort() error {
+	if gs.IsOver() {
+		return fmt.Errorf("%w: game is already over", ErrWrongPhase)
+	}
+
+	gs.Phase = PhaseAborted
+	gs.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOver reports whether the game has ended or been aborted
+func (gs *GameState) IsOver() bool {
+	return gs.Phase == PhaseEnded || gs.Phase == PhaseAborted
+}
+
 // Helper function to create string pointer
 func stringPtr(s string) *string {
 	return &s
@@ -489,12 +1076,14 @@ func (gs *GameState) GetTeammates(position PlayerPosition) PlayerPosition {
 	return position.GetPartnerPosition()
 }
 
-// IsOnDeclarerTeam checks if a position is on the declarer's team
+// IsOnDeclarerTeam checks if a position is on the declarer's team. Under
+// called-card partnerships the partner only joins once revealed.
 func (gs *GameState) IsOnDeclarerTeam(position PlayerPosition) bool {
 	if gs.Declarer == nil {
 		return false
 	}
-	return position == *gs.Declarer || position == gs.Declarer.GetPartnerPosition()
+	partner := gs.GetDeclarerPartner()
+	return position == *gs.Declarer || (partner != nil && position == *partner)
 }
 
 // GetGameSummary returns a summary of the game state
@@ -0,0 +1,659 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestGameState creates a four-player game with predictable player IDs
+func newTestGameState(t *testing.T) *GameState {
+	t.Helper()
+
+	gs, err := NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North Player", "East Player", "South Player", "West Player"},
+		DefaultRules())
+	if err != nil {
+		t.Fatalf("NewGameState() error = %v", err)
+	}
+	return gs
+}
+
+// playTestTrick has every player play the given card as a single, starting with the leader
+func playTestTrick(t *testing.T, gs *GameState, leader PlayerPosition, cards map[PlayerPosition]Card) *Trick {
+	t.Helper()
+
+	trick := NewTrick(fmt.Sprintf("%s_trick_%d", gs.ID, len(gs.Tricks)+1), leader)
+	for _, position := range trick.GetPlayOrder() {
+		if err := trick.AddPlay(position, NewSingle(cards[position]), *gs.TrumpSuit); err != nil {
+			t.Fatalf("AddPlay() error = %v", err)
+		}
+	}
+	gs.Tricks = append(gs.Tricks, *trick)
+	return trick
+}
+
+// playFirstCards plays the first card of each hand as a single, removing it from the hand
+func playFirstCards(t *testing.T, gs *GameState, leader PlayerPosition) *Trick {
+	t.Helper()
+
+	cards := make(map[PlayerPosition]Card)
+	for _, player := range gs.Players {
+		card := player.Hand[0]
+		if err := player.RemoveCard(card); err != nil {
+			t.Fatalf("RemoveCard() error = %v", err)
+		}
+		cards[player.Position] = card
+	}
+	return playTestTrick(t, gs, leader, cards)
+}
+
+func TestParsePlayerPosition(t *testing.T) {
+	for position := North; position <= West; position++ {
+		parsed, err := ParsePlayerPosition(position.String())
+		if err != nil {
+			t.Fatalf("ParsePlayerPosition(%q) error = %v", position.String(), err)
+		}
+		if parsed != position {
+			t.Errorf("ParsePlayerPosition(%q) = %v, want %v", position.String(), parsed, position)
+		}
+	}
+
+	if _, err := ParsePlayerPosition("Nowhere"); err == nil {
+		t.Error("Expected error for unknown position")
+	}
+}
+
+func TestNewGameState_PlayerIDs(t *testing.T) {
+	names := []string{"North Player", "East Player", "South Player", "West Player"}
+	tests := []struct {
+		name      string
+		playerIDs []string
+		wantErr   bool
+	}{
+		{"Unique IDs", []string{"north", "east", "south", "west"}, false},
+		{"Duplicate ID", []string{"north", "east", "north", "west"}, true},
+		{"Empty ID", []string{"north", "", "south", "west"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, err := NewGameState("game-1", "room-1", tt.playerIDs, names, DefaultRules())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for player IDs %v", tt.playerIDs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewGameState() error = %v", err)
+			}
+			for _, playerID := range tt.playerIDs {
+				if player := gs.GetPlayer(playerID); player == nil || player.ID != playerID {
+					t.Errorf("Expected to find player %s", playerID)
+				}
+			}
+		})
+	}
+}
+
+func TestGameState_DealCardsRecordingOrder(t *testing.T) {
+	gs := newTestGameState(t)
+	if err := gs.DealCardsRecordingOrder(NewDeck()); err != nil {
+		t.Fatalf("DealCardsRecordingOrder() error = %v", err)
+	}
+	if len(gs.DealOrder) != DeckSize {
+		t.Fatalf("Expected %d cards in the deal order, got %d", DeckSize, len(gs.DealOrder))
+	}
+
+	// Handing out the same deck in the recorded order rebuilds every hand
+	hands := make(map[int][]Card)
+	for i, card := range NewDeck().Cards {
+		hands[gs.DealOrder[i]] = append(hands[gs.DealOrder[i]], card)
+	}
+	for _, player := range gs.Players {
+		if !reflect.DeepEqual(hands[int(player.Position)], player.Hand) {
+			t.Errorf("Replayed hand for %s does not match the dealt hand", player.ID)
+		}
+	}
+	if !reflect.DeepEqual(hands[DealToKitty], gs.Kitty) {
+		t.Error("Replayed kitty does not match the dealt kitty")
+	}
+
+	// The order is only kept until bidding gets under way
+	if err := gs.PassBid("north"); err != nil {
+		t.Fatalf("PassBid() error = %v", err)
+	}
+	if gs.DealOrder != nil {
+		t.Errorf("Expected the deal order to be cleared once bidding starts, got %d entries", len(gs.DealOrder))
+	}
+
+	plain := newTestGameState(t)
+	if err := plain.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+	if plain.DealOrder != nil {
+		t.Error("Expected DealCards not to record the deal order")
+	}
+}
+
+func TestGameState_DealCardsRejectsWrongDeckSize(t *testing.T) {
+	for _, size := range []int{DeckSize - 1, DeckSize - KittySize, 0} {
+		gs := newTestGameState(t)
+		deck := NewDeck()
+		deck.Cards = deck.Cards[:size]
+
+		err := gs.DealCards(deck)
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("found %d", size)) {
+			t.Errorf("DealCards(%d cards) error = %v, want a deck size error", size, err)
+		}
+		if gs.Phase != PhaseWaiting {
+			t.Errorf("Expected the game to stay waiting, got %v", gs.Phase)
+		}
+		if deck.Remaining() != size {
+			t.Errorf("Expected no cards to be dealt, %d of %d remain", deck.Remaining(), size)
+		}
+		for _, player := range gs.Players {
+			if len(player.Hand) != 0 {
+				t.Errorf("Expected %s to have no cards after a rejected deal, got %d", player.ID, len(player.Hand))
+			}
+		}
+	}
+
+	gs := newTestGameState(t)
+	deck := NewDeck()
+	deck.Cards = append(deck.Cards, NewCard(Spades, Ace, 3))
+	if err := gs.DealCards(deck); err == nil {
+		t.Error("Expected error for an oversized deck")
+	}
+}
+
+func TestGameState_DealCardsRejectsTamperedDeck(t *testing.T) {
+	withoutJoker := func() *Deck {
+		deck := NewDeck()
+		// Swap the last Small Joker for a third Ace of Spades, keeping 108 cards
+		deck.Cards[len(deck.Cards)-1] = NewCard(Spades, Ace, 1)
+		return deck
+	}
+	duplicated := func() *Deck {
+		deck := NewDeck()
+		// Both Kings of Hearts claim to come from deck 1
+		for i, card := range deck.Cards {
+			if card == NewCard(Hearts, King, 2) {
+				deck.Cards[i] = NewCard(Hearts, King, 1)
+			}
+		}
+		return deck
+	}
+
+	for name, build := range map[string]func() *Deck{"missing joker": withoutJoker, "duplicate card": duplicated} {
+		gs := newTestGameState(t)
+		deck := build()
+
+		if err := gs.DealCards(deck); err == nil {
+			t.Errorf("Expected error dealing a deck with a %s", name)
+		}
+		if gs.Phase != PhaseWaiting || len(gs.Kitty) != 0 {
+			t.Errorf("Expected the game to stay waiting with no kitty after dealing a deck with a %s", name)
+		}
+		if deck.Remaining() != DeckSize {
+			t.Errorf("Expected no cards to be dealt from a deck with a %s, %d remain", name, deck.Remaining())
+		}
+		for _, player := range gs.Players {
+			if len(player.Hand) != 0 {
+				t.Errorf("Expected %s to have no cards after a rejected deal, got %d", player.ID, len(player.Hand))
+			}
+		}
+	}
+}
+
+func TestGameState_GetCapturedPoints(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+
+	trick := playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ten, 1),
+		West:  NewCard(Hearts, Ace, 1),
+	})
+	if trick.Winner != West.String() {
+		t.Fatalf("Expected West to win the trick, got %s", trick.Winner)
+	}
+
+	playTestTrick(t, gs, West, map[PlayerPosition]Card{
+		West:  NewCard(Clubs, Five, 1),
+		North: NewCard(Clubs, Five, 2),
+		East:  NewCard(Clubs, Three, 1),
+		South: NewCard(Spades, Four, 1),
+	})
+
+	captured := gs.GetCapturedPoints()
+	expected := map[string]int{"north": 0, "east": 0, "south": 10, "west": 25}
+	for playerID, points := range expected {
+		if captured[playerID] != points {
+			t.Errorf("Expected %s to capture %d points, got %d", playerID, points, captured[playerID])
+		}
+	}
+}
+
+func TestGameState_CapturedPointsAccountForDeck(t *testing.T) {
+	gs := newTestGameState(t)
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+	trump := Clubs
+	gs.TrumpSuit = &trump
+
+	leader := North
+	for i := 0; i < 10; i++ {
+		trick := playFirstCards(t, gs, leader)
+		winner, err := ParsePlayerPosition(trick.Winner)
+		if err != nil {
+			t.Fatalf("ParsePlayerPosition() error = %v", err)
+		}
+		leader = winner
+	}
+
+	captured := 0
+	for _, points := range gs.GetCapturedPoints() {
+		captured += points
+	}
+
+	held := gs.GetKittyPoints()
+	for _, player := range gs.Players {
+		for _, card := range player.Hand {
+			held += card.GetPointValue()
+		}
+	}
+
+	if captured == 0 {
+		t.Error("Expected some points to be captured")
+	}
+	if captured+held != 200 {
+		t.Errorf("Expected captured (%d) plus held (%d) points to total 200", captured, held)
+	}
+}
+
+func TestGameState_CalculateFinalScore(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+	declarer := North
+	gs.Declarer = &declarer
+	gs.Contract = 20
+	gs.Kitty = []Card{NewCard(Diamonds, King, 1), NewCard(Diamonds, Two, 1)}
+
+	playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ten, 1),
+		West:  NewCard(Hearts, Ace, 1),
+	})
+
+	gs.CalculateFinalScore()
+
+	if gs.Phase != PhaseEnded {
+		t.Errorf("Expected phase Ended, got %v", gs.Phase)
+	}
+	if gs.WinnerTeam == nil || *gs.WinnerTeam != "defenders" {
+		t.Fatalf("Expected defenders to win, got %v", gs.WinnerTeam)
+	}
+	if defendersPoints := gs.GetDefendersPoints(); defendersPoints != 35 {
+		t.Errorf("Expected defenders to have 35 points including the kitty, got %d", defendersPoints)
+	}
+	if gs.Scores["west"] != 25 {
+		t.Errorf("Expected West to score 25 points, got %d", gs.Scores["west"])
+	}
+}
+
+func TestGameState_GetScoreboard(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+	declarer := East
+	gs.Declarer = &declarer
+	gs.Contract = 80
+
+	playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ten, 1),
+		West:  NewCard(Hearts, Ace, 1),
+	})
+	gs.CalculateFinalScore()
+
+	scoreboard := gs.GetScoreboard()
+	if len(scoreboard.Players) != 4 {
+		t.Fatalf("Expected 4 players on the scoreboard, got %d", len(scoreboard.Players))
+	}
+	if scoreboard.WinnerTeam == nil || *scoreboard.WinnerTeam != "declarer" {
+		t.Errorf("Expected declarer team to win, got %v", scoreboard.WinnerTeam)
+	}
+	if scoreboard.Margin == nil || *scoreboard.Margin != 80 {
+		t.Errorf("Expected the declarer team to win by 80 points, got %v", scoreboard.Margin)
+	}
+
+	expectedRoles := map[string]string{
+		"north": RoleDefender,
+		"east":  RoleDeclarer,
+		"south": RoleDefender,
+		"west":  RolePartner,
+	}
+	for _, score := range scoreboard.Players {
+		if score.Role != expectedRoles[score.PlayerID] {
+			t.Errorf("Expected %s to have role %s, got %s", score.PlayerID, expectedRoles[score.PlayerID], score.Role)
+		}
+	}
+	if scoreboard.Players[West].PointsCaptured != 25 {
+		t.Errorf("Expected West to have captured 25 points, got %d", scoreboard.Players[West].PointsCaptured)
+	}
+}
+
+func TestGameState_GetTrickHistory(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+
+	playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ten, 1),
+		West:  NewCard(Hearts, Ace, 1),
+	})
+	playTestTrick(t, gs, West, map[PlayerPosition]Card{
+		West:  NewCard(Clubs, Three, 1),
+		North: NewCard(Clubs, Four, 1),
+		East:  NewCard(Spades, Two, 1),
+		South: NewCard(Clubs, Six, 1),
+	})
+	gs.CurrentTrick = NewTrick("game_trick_3", East)
+	if err := gs.CurrentTrick.AddPlay(East, NewSingle(NewCard(Diamonds, Nine, 1)), trump); err != nil {
+		t.Fatalf("AddPlay() error = %v", err)
+	}
+
+	history := gs.GetTrickHistory()
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 completed tricks, got %d", len(history))
+	}
+	for i, wantWinner := range []string{"West", "East"} {
+		if history[i]["number"] != i+1 {
+			t.Errorf("Expected trick %d to be numbered %d, got %v", i, i+1, history[i]["number"])
+		}
+		if history[i]["winner"] != wantWinner {
+			t.Errorf("Expected trick %d to be won by %s, got %v", i+1, wantWinner, history[i]["winner"])
+		}
+	}
+
+	plays := history[1]["plays"].(map[string]interface{})
+	eastPlay := plays["East"].(map[string]interface{})
+	cards := eastPlay["cards"].([]Card)
+	if len(cards) != 1 || cards[0] != NewCard(Spades, Two, 1) {
+		t.Errorf("Expected East to have played the two of spades, got %v", cards)
+	}
+}
+
+func TestGameState_GetScoreboardRevealsKitty(t *testing.T) {
+	t.Run("Hidden mid-game", func(t *testing.T) {
+		gs := newPlayingGameState(t)
+
+		scoreboard := gs.GetScoreboard()
+		if scoreboard.Kitty != nil {
+			t.Errorf("Expected the kitty to stay hidden during play, got %v", scoreboard.Kitty)
+		}
+		data, err := json.Marshal(scoreboard)
+		if err != nil {
+			t.Fatalf("Marshal error = %v", err)
+		}
+		if strings.Contains(string(data), `"kitty"`) {
+			t.Errorf("Expected no kitty in the encoded scoreboard, got %s", data)
+		}
+	})
+
+	// playLastTrick plays the only trick of a game with a 15-point kitty, won by
+	// the given position
+	playLastTrick := func(t *testing.T, multiplier int, lastWinner PlayerPosition) *GameState {
+		rules := DefaultRules()
+		rules.KittyMultiplier = multiplier
+		gs := newTestGameStateWithRules(t, rules)
+		trump := Spades
+		declarer := North
+		gs.TrumpSuit = &trump
+		gs.Declarer = &declarer
+		gs.Contract = 80
+		gs.Kitty = []Card{NewCard(Diamonds, King, 1), NewCard(Diamonds, Five, 1)}
+
+		cards := map[PlayerPosition]Card{
+			North: NewCard(Hearts, Three, 1),
+			East:  NewCard(Hearts, Four, 1),
+			South: NewCard(Hearts, Six, 1),
+			West:  NewCard(Hearts, Seven, 1),
+		}
+		cards[lastWinner] = NewCard(Hearts, King, 1)
+		playTestTrick(t, gs, North, cards)
+		return gs
+	}
+
+	// endGame finishes the game after its only trick
+	endGame := func(t *testing.T, multiplier int, lastWinner PlayerPosition) *Scoreboard {
+		gs := playLastTrick(t, multiplier, lastWinner)
+		gs.CalculateFinalScore()
+		return gs.GetScoreboard()
+	}
+
+	t.Run("Kitty points left out until the game ends", func(t *testing.T) {
+		gs := playLastTrick(t, 2, West)
+		if gs.Phase == PhaseEnded {
+			t.Fatal("Expected the game not to have ended before scoring")
+		}
+
+		scoreboard := gs.GetScoreboard()
+		if scoreboard.DefendersPoints != 10 {
+			t.Errorf("Expected 10 defender points from the trick alone, got %d", scoreboard.DefendersPoints)
+		}
+		if scoreboard.Kitty != nil {
+			t.Errorf("Expected the kitty to stay hidden, got %v", scoreboard.Kitty)
+		}
+	})
+
+	t.Run("Doubled for the defenders", func(t *testing.T) {
+		scoreboard := endGame(t, 2, West)
+
+		kitty := scoreboard.Kitty
+		if kitty == nil {
+			t.Fatal("Expected the kitty to be revealed once the game has ended")
+		}
+		if len(kitty.Cards) != 2 || kitty.Points != 15 {
+			t.Errorf("Expected 2 kitty cards worth 15 points, got %d worth %d", len(kitty.Cards), kitty.Points)
+		}
+		if !kitty.WonByDefenders || kitty.AwardedPoints != 30 {
+			t.Errorf("Expected the defenders to be awarded 30 kitty points, got %v", kitty)
+		}
+		if scoreboard.DefendersPoints != 40 {
+			t.Errorf("Expected 40 defender points including the doubled kitty, got %d", scoreboard.DefendersPoints)
+		}
+	})
+
+	t.Run("Kept by the declarer", func(t *testing.T) {
+		scoreboard := endGame(t, 2, South)
+
+		kitty := scoreboard.Kitty
+		if kitty == nil {
+			t.Fatal("Expected the kitty to be revealed once the game has ended")
+		}
+		if kitty.Points != 15 || kitty.WonByDefenders || kitty.AwardedPoints != 0 {
+			t.Errorf("Expected 15 kitty points kept by the declarer's team, got %v", kitty)
+		}
+	})
+}
+
+func TestGameState_VerifyCardIntegrity(t *testing.T) {
+	gs := newPlayingGameState(t)
+	if err := gs.VerifyCardIntegrity(); err != nil {
+		t.Fatalf("Expected a freshly dealt game to pass, got %v", err)
+	}
+
+	// Cards moving into the current and completed tricks are still accounted for
+	for _, card := range []Card{NewCard(Spades, Ten, 1), NewCard(Clubs, Three, 1), NewCard(Spades, Ten, 2), NewCard(Hearts, Queen, 2)} {
+		if _, err := gs.PlayCards(gs.GetCurrentPlayer().ID, NewSingle(card)); err != nil {
+			t.Fatalf("PlayCards() error = %v", err)
+		}
+		if err := gs.VerifyCardIntegrity(); err != nil {
+			t.Fatalf("Expected integrity after playing %s, got %v", card.String(), err)
+		}
+	}
+}
+
+func TestGameState_VerifyCardIntegrityDetectsTampering(t *testing.T) {
+	t.Run("Duplicated card", func(t *testing.T) {
+		gs := newPlayingGameState(t)
+		east := gs.Players[East]
+		east.Hand[1] = east.Hand[0]
+
+		if err := gs.VerifyCardIntegrity(); err == nil {
+			t.Error("Expected error for a duplicated card")
+		}
+	})
+
+	t.Run("Missing card", func(t *testing.T) {
+		gs := newPlayingGameState(t)
+		gs.Kitty = gs.Kitty[1:]
+
+		if err := gs.VerifyCardIntegrity(); err == nil {
+			t.Error("Expected error for a missing card")
+		}
+	})
+
+	t.Run("Extra copy of a face", func(t *testing.T) {
+		gs := newPlayingGameState(t)
+		gs.Kitty[0] = NewCard(Spades, Ace, 3)
+
+		if err := gs.VerifyCardIntegrity(); err == nil {
+			t.Error("Expected error for a third copy of a card")
+		}
+	})
+}
+
+func TestGameState_Abort(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	if err := gs.Abort(); err != nil {
+		t.Fatalf("Expected abort to succeed, got %v", err)
+	}
+	if gs.Phase != PhaseAborted || !gs.IsOver() {
+		t.Errorf("Expected phase Aborted, got %v", gs.Phase)
+	}
+	if gs.WinnerTeam != nil {
+		t.Errorf("Expected no winner for an aborted game, got %v", *gs.WinnerTeam)
+	}
+	if _, err := gs.PlayCards("north", NewSingle(gs.Players[North].Hand[0])); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected play in an aborted game to fail with ErrWrongPhase, got %v", err)
+	}
+	if err := gs.Abort(); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected aborting twice to fail with ErrWrongPhase, got %v", err)
+	}
+}
+
+func BenchmarkDealGame(b *testing.B) {
+	playerIDs := []string{"north", "east", "south", "west"}
+	playerNames := []string{"North", "East", "South", "West"}
+	rules := DefaultRules()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		gs, err := NewGameState("game-1", "room-1", playerIDs, playerNames, rules)
+		if err != nil {
+			b.Fatalf("NewGameState() error = %v", err)
+		}
+		deck := NewDeck()
+		deck.Shuffle()
+		if err := gs.DealCards(deck); err != nil {
+			b.Fatalf("DealCards() error = %v", err)
+		}
+		if err := gs.VerifyCardIntegrity(); err != nil {
+			b.Fatalf("VerifyCardIntegrity() error = %v", err)
+		}
+	}
+}
+
+// deckPointsInPlay totals the points of every card in hands, the kitty and tricks
+func deckPointsInPlay(gs *GameState) int {
+	points := 0
+	for _, card := range gs.collectCards() {
+		points += card.GetPointValue()
+	}
+	return points
+}
+
+// newKittyExchangeGameState returns a dealt game where North won the bidding
+// and declared Hearts, ready to exchange the kitty
+func newKittyExchangeGameState(t *testing.T) *GameState {
+	t.Helper()
+
+	gs := newTestGameStateWithRules(t, DefaultRules())
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump() error = %v", err)
+	}
+	return gs
+}
+
+func TestGameState_ExchangeKittyKeepsDeckPoints(t *testing.T) {
+	gs := newKittyExchangeGameState(t)
+	if points := deckPointsInPlay(gs); points != DeckPoints {
+		t.Fatalf("Expected %d points after the deal, got %d", DeckPoints, points)
+	}
+
+	discards := make([]Card, KittySize)
+	copy(discards, gs.Players[North].Hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); err != nil {
+		t.Fatalf("ExchangeKitty() error = %v", err)
+	}
+
+	if points := deckPointsInPlay(gs); points != DeckPoints {
+		t.Errorf("Expected %d points after the exchange, got %d", DeckPoints, points)
+	}
+}
+
+func TestGameState_ExchangeKittyUndoesCorruptSwap(t *testing.T) {
+	gs := newKittyExchangeGameState(t)
+
+	// Lose one of East's point cards, as a bug elsewhere might
+	east := gs.Players[East]
+	for i, card := range east.Hand {
+		if card.GetPointValue() > 0 {
+			east.Hand = append(east.Hand[:i:i], east.Hand[i+1:]...)
+			break
+		}
+	}
+
+	hand := append([]Card(nil), gs.Players[North].Hand...)
+	kitty := append([]Card(nil), gs.Kitty...)
+	discards := make([]Card, KittySize)
+	copy(discards, hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); err == nil {
+		t.Fatal("Expected the exchange to detect the missing point card")
+	}
+
+	if gs.Phase != PhaseKittyExchange {
+		t.Errorf("Expected the game to stay in the kitty exchange, got phase %v", gs.Phase)
+	}
+	if !reflect.DeepEqual(gs.Players[North].Hand, hand) {
+		t.Error("Expected the declarer's hand to be restored")
+	}
+	if !reflect.DeepEqual(gs.Kitty, kitty) {
+		t.Error("Expected the kitty to be restored")
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// namedEnum is an integer enum with a String name for each valid value
+type namedEnum interface {
+	~int
+	String() string
+}
+
+// marshalEnum encodes an enum as its name. Values without a name, such as the
+// zero rank of a joker, are encoded as their number so they still round-trip.
+func marshalEnum[T namedEnum](value T) ([]byte, error) {
+	if value.String() == "Unknown" {
+		return json.Marshal(int(value))
+	}
+	return json.Marshal(value.String())
+}
+
+// unmarshalEnum decodes an enum from its name, or from its number for state
+// written before enums were encoded by name
+func unmarshalEnum[T namedEnum](data []byte, kind string, first, last T) (T, error) {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var number int
+		if err := json.Unmarshal(data, &number); err != nil {
+			return 0, fmt.Errorf("invalid %s: %s", kind, data)
+		}
+		return T(number), nil
+	}
+	return parseEnum(name, kind, first, last)
+}
+
+// parseEnum looks up an enum value by name in the range [first, last]
+func parseEnum[T namedEnum](name, kind string, first, last T) (T, error) {
+	for value := first; value <= last; value++ {
+		if value.String() == name {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s: %s", kind, name)
+}
+
+// ParseSuit converts a suit name such as "Hearts" or "No Trump" to a Suit
+func ParseSuit(name string) (Suit, error) {
+	return parseEnum(name, "suit", Spades, NoTrump)
+}
+
+// ParseFormationType converts a formation type name such as "Pair" to a FormationType
+func ParseFormationType(name string) (FormationType, error) {
+	return parseEnum(name, "formation type", Single, Mixed)
+}
+
+// MarshalJSON encodes the phase as its name
+func (p GamePhase) MarshalJSON() ([]byte, error) {
+	return marshalEnum(p)
+}
+
+// UnmarshalJSON decodes the phase from its name or number
+func (p *GamePhase) UnmarshalJSON(data []byte) error {
+	phase, err := unmarshalEnum(data, "game phase", PhaseWaiting, PhaseAborted)
+	if err != nil {
+		return err
+	}
+	*p = phase
+	return nil
+}
+
+// MarshalJSON encodes the position as its name
+func (p PlayerPosition) MarshalJSON() ([]byte, error) {
+	return marshalEnum(p)
+}
+
+// UnmarshalJSON decodes the position from its name or number
+func (p *PlayerPosition) UnmarshalJSON(data []byte) error {
+	position, err := unmarshalEnum(data, "player position", North, West)
+	if err != nil {
+		return err
+	}
+	*p = position
+	return nil
+}
+
+// MarshalText encodes the position as its name so that maps keyed by
+// position, such as Trick.Plays, use names as their JSON keys
+func (p PlayerPosition) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes the position from its name, or from its number for
+// map keys written before positions were encoded by name
+func (p *PlayerPosition) UnmarshalText(text []byte) error {
+	if number, err := strconv.Atoi(string(text)); err == nil {
+		*p = PlayerPosition(number)
+		return nil
+	}
+
+	position, err := ParsePlayerPosition(string(text))
+	if err != nil {
+		return err
+	}
+	*p = position
+	return nil
+}
+
+// MarshalJSON encodes the suit as its name
+func (s Suit) MarshalJSON() ([]byte, error) {
+	return marshalEnum(s)
+}
+
+// UnmarshalJSON decodes the suit from its name or number
+func (s *Suit) UnmarshalJSON(data []byte) error {
+	suit, err := unmarshalEnum(data, "suit", Spades, NoTrump)
+	if err != nil {
+		return err
+	}
+	*s = suit
+	return nil
+}
+
+// MarshalJSON encodes the rank as its name
+func (r Rank) MarshalJSON() ([]byte, error) {
+	return marshalEnum(r)
+}
+
+// UnmarshalJSON decodes the rank from its name or number
+func (r *Rank) UnmarshalJSON(data []byte) error {
+	rank, err := unmarshalEnum(data, "rank", Two, Ace)
+	if err != nil {
+		return err
+	}
+	*r = rank
+	return nil
+}
+
+// MarshalJSON encodes the formation type as its name
+func (f FormationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(f)
+}
+
+// UnmarshalJSON decodes the formation type from its name or number
+func (f *FormationType) UnmarshalJSON(data []byte) error {
+	formationType, err := unmarshalEnum(data, "formation type", Single, Mixed)
+	if err != nil {
+		return err
+	}
+	*f = formationType
+	return nil
+}
+
+// MarshalJSON encodes the joker type as its name
+func (j JokerType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(j)
+}
+
+// UnmarshalJSON decodes the joker type from its name or number
+func (j *JokerType) UnmarshalJSON(data []byte) error {
+	jokerType, err := unmarshalEnum(data, "joker type", BigJoker, SmallJoker)
+	if err != nil {
+		return err
+	}
+	*j = jokerType
+	return nil
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// roundTrip marshals value, checks it encodes as want and decodes it into
+// decoded, which must point to a zero value of the same type
+func roundTrip(t *testing.T, value, decoded interface{}, want string) {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal(%v) error = %v", value, err)
+	}
+	if string(data) != want {
+		t.Errorf("Marshal(%v) = %s, want %s", value, data, want)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", data, err)
+	}
+}
+
+func TestEnums_JSONRoundTrip(t *testing.T) {
+	for phase := PhaseWaiting; phase <= PhaseAborted; phase++ {
+		var decoded GamePhase
+		roundTrip(t, phase, &decoded, `"`+phase.String()+`"`)
+		if decoded != phase {
+			t.Errorf("GamePhase round-trip = %v, want %v", decoded, phase)
+		}
+	}
+
+	for position := North; position <= West; position++ {
+		var decoded PlayerPosition
+		roundTrip(t, position, &decoded, `"`+position.String()+`"`)
+		if decoded != position {
+			t.Errorf("PlayerPosition round-trip = %v, want %v", decoded, position)
+		}
+	}
+
+	for suit := Spades; suit <= NoTrump; suit++ {
+		var decoded Suit
+		roundTrip(t, suit, &decoded, `"`+suit.String()+`"`)
+		if decoded != suit {
+			t.Errorf("Suit round-trip = %v, want %v", decoded, suit)
+		}
+	}
+
+	for rank := Two; rank <= Ace; rank++ {
+		var decoded Rank
+		roundTrip(t, rank, &decoded, `"`+rank.String()+`"`)
+		if decoded != rank {
+			t.Errorf("Rank round-trip = %v, want %v", decoded, rank)
+		}
+	}
+
+	for formationType := Single; formationType <= Tractor; formationType++ {
+		var decoded FormationType
+		roundTrip(t, formationType, &decoded, `"`+formationType.String()+`"`)
+		if decoded != formationType {
+			t.Errorf("FormationType round-trip = %v, want %v", decoded, formationType)
+		}
+	}
+
+	for _, jokerType := range []JokerType{BigJoker, SmallJoker} {
+		var decoded JokerType
+		roundTrip(t, jokerType, &decoded, `"`+jokerType.String()+`"`)
+		if decoded != jokerType {
+			t.Errorf("JokerType round-trip = %v, want %v", decoded, jokerType)
+		}
+	}
+}
+
+func TestEnums_UnmarshalNumericFallback(t *testing.T) {
+	var phase GamePhase
+	if err := json.Unmarshal([]byte("5"), &phase); err != nil || phase != PhasePlaying {
+		t.Errorf("Unmarshal(5) = %v, %v; want Playing", phase, err)
+	}
+
+	var suit Suit
+	if err := json.Unmarshal([]byte("4"), &suit); err != nil || suit != NoTrump {
+		t.Errorf("Unmarshal(4) = %v, %v; want No Trump", suit, err)
+	}
+
+	// Cards and tricks saved with integer enums and integer map keys still decode
+	var trick Trick
+	legacy := `{"leader":2,"plays":{"2":{"type":1,"cards":[{"suit":1,"rank":14,"deck_id":1,"is_joker":false},{"suit":1,"rank":14,"deck_id":2,"is_joker":false}],"suit":1}}}`
+	if err := json.Unmarshal([]byte(legacy), &trick); err != nil {
+		t.Fatalf("Unmarshal(legacy trick) error = %v", err)
+	}
+	play := trick.Plays[South]
+	if trick.Leader != South || play == nil {
+		t.Fatalf("Expected South to lead and play, got leader %v and plays %v", trick.Leader, trick.Plays)
+	}
+	if play.Type != Pair || play.Suit != Hearts || play.Cards[0].Rank != Ace {
+		t.Errorf("Expected a pair of Heart Aces, got %v", play)
+	}
+}
+
+func TestEnums_UnmarshalUnknownName(t *testing.T) {
+	var suit Suit
+	if err := json.Unmarshal([]byte(`"Stars"`), &suit); err == nil {
+		t.Error("Expected error for unknown suit name")
+	}
+
+	var position PlayerPosition
+	if err := json.Unmarshal([]byte(`"Northeast"`), &position); err == nil {
+		t.Error("Expected error for unknown position name")
+	}
+}
+
+func TestGameState_JSONRoundTrip(t *testing.T) {
+	gs := newPlayingGameState(t)
+	if _, err := gs.PlayCards("north", NewSingle(NewCard(Spades, Ten, 1))); err != nil {
+		t.Fatalf("PlayCards error = %v", err)
+	}
+
+	data, err := json.Marshal(gs)
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+	for _, want := range []string{`"phase":"Playing"`, `"trump_suit":"Hearts"`, `"North":{"type":"Single"`, `"joker_type":"Small Joker"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected encoded state to contain %s", want)
+		}
+	}
+
+	var decoded GameState
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error = %v", err)
+	}
+	if decoded.Phase != PhasePlaying || *decoded.TrumpSuit != Hearts || *decoded.Declarer != North {
+		t.Errorf("Decoded state lost its phase, trump or declarer: %v %v %v", decoded.Phase, *decoded.TrumpSuit, *decoded.Declarer)
+	}
+	if err := decoded.VerifyCardIntegrity(); err != nil {
+		t.Errorf("Decoded state failed integrity check: %v", err)
+	}
+
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("Marshal decoded error = %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Error("Expected re-encoding the decoded state to produce identical JSON")
+	}
+}
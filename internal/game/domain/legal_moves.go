@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LegalMoves returns the formations the player may play in the current trick,
+// which are exactly those PlayCards accepts. The leader may play any single,
+// pair or tractor in their hand. A follower must play as many cards as were
+// led, as the led formation's type or as a mixed formation, including as many
+// cards of the led suit as were led or all they hold of it. Under rules that
+// penalize reneges, any cards may follow instead. Moves following the led suit
+// are listed first. Players waiting for their turn have no legal moves.
+func (gs *GameState) LegalMoves(playerID string) ([]*Formation, error) {
+	if gs.Phase != PhasePlaying || gs.TrumpSuit == nil {
+		return nil, fmt.Errorf("%w: not in playing phase", ErrWrongPhase)
+	}
+
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	trumpSuit := *gs.TrumpSuit
+	candidates := handFormations(player.Hand, trumpSuit)
+	var led *Formation
+	if gs.CurrentTrick != nil {
+		led = gs.CurrentTrick.Plays[gs.CurrentTrick.Leader]
+	}
+	if led != nil && led.Type != Single {
+		if gs.Rules.PenalizeReneges {
+			candidates = append(candidates, faceCombinations(sortedByFace(player.Hand, trumpSuit), nil, len(led.Cards))...)
+		} else {
+			candidates = append(candidates, mixedFormations(player.Hand, led, trumpSuit)...)
+		}
+	}
+
+	moves := make([]*Formation, 0)
+	for _, formation := range candidates {
+		if _, err := gs.checkPlay(player, formation); err == nil {
+			moves = append(moves, formation)
+		}
+	}
+	if led == nil {
+		return moves, nil
+	}
+
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+	sort.SliceStable(moves, func(i, j int) bool {
+		return formationSuit(moves[i], trumpSuit) == ledSuit && formationSuit(moves[j], trumpSuit) != ledSuit
+	})
+	return moves, nil
+}
+
+// breaksLedSuitFormation checks if the hand holds a pair or tractor of the led
+// suit matching the led formation, which the follower's formation is not
+func breaksLedSuitFormation(hand []Card, led, formation *Formation, trumpSuit Suit) bool {
+	if led.Type == Single {
+		return false
+	}
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+	if formation.Type == led.Type && formationSuit(formation, trumpSuit) == ledSuit {
+		return false
+	}
+	for _, held := range handFormations(hand, trumpSuit) {
+		if held.Type == led.Type && len(held.Cards) == len(led.Cards) && formationSuit(held, trumpSuit) == ledSuit {
+			return true
+		}
+	}
+	return false
+}
+
+// countSuit counts the cards that play as the suit, treating all trumps as the trump suit
+func countSuit(cards []Card, suit Suit, trumpSuit Suit) int {
+	count := 0
+	for _, card := range cards {
+		if effectiveSuit(card, trumpSuit) == suit {
+			count++
+		}
+	}
+	return count
+}
+
+// mixedFormations lists the mixed formations a follower may play without
+// reneging: as many cards of the led suit as were led, or all they hold of it
+// made up with any other cards. Cards of the same face are
+// interchangeable, so only one formation is listed per combination of faces.
+func mixedFormations(hand []Card, led *Formation, trumpSuit Suit) []*Formation {
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+	following := make([]Card, 0, len(hand))
+	others := make([]Card, 0, len(hand))
+	for _, card := range sortedByFace(hand, trumpSuit) {
+		if effectiveSuit(card, trumpSuit) == ledSuit {
+			following = append(following, card)
+		} else {
+			others = append(others, card)
+		}
+	}
+
+	size := len(led.Cards)
+	if len(following) >= size {
+		return faceCombinations(following, nil, size)
+	}
+	return faceCombinations(others, following, size-len(following))
+}
+
+// faceCombinations lists a mixed formation for each way of adding n of the
+// cards to the required ones, skipping combinations that differ only in which
+// deck a card came from. The cards must be sorted so equal faces are adjacent.
+func faceCombinations(cards, required []Card, n int) []*Formation {
+	formations := make([]*Formation, 0)
+	chosen := append(make([]Card, 0, len(required)+n), required...)
+
+	var choose func(start, n int)
+	choose = func(start, n int) {
+		if n == 0 {
+			formations = append(formations, NewMixed(append([]Card(nil), chosen...)))
+			return
+		}
+		for i := start; i <= len(cards)-n; i++ {
+			if i > start && cards[i].IsSameFace(cards[i-1]) {
+				continue
+			}
+			chosen = append(chosen, cards[i])
+			choose(i+1, n-1)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	choose(0, n)
+	return formations
+}
+
+// sortedByFace returns a copy of the cards ordered from weakest to strongest,
+// with cards of the same face next to each other
+func sortedByFace(cards []Card, trumpSuit Suit) []Card {
+	sorted := sortedByStrength(cards, trumpSuit)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if strengthI, strengthJ := cardStrength(sorted[i], trumpSuit), cardStrength(sorted[j], trumpSuit); strengthI != strengthJ {
+			return strengthI < strengthJ
+		}
+		return sorted[i].Suit < sorted[j].Suit
+	})
+	return sorted
+}
+
+// handFormations lists every distinct single, pair and tractor in the hand.
+// Cards of the same face from the two decks are interchangeable, so only one
+// formation is listed per face.
+func handFormations(hand []Card, trumpSuit Suit) []*Formation {
+	sorted := sortedByStrength(hand, trumpSuit)
+
+	formations := make([]*Formation, 0, len(sorted))
+	pairs := make([][]Card, 0)
+	for i, card := range sorted {
+		if i > 0 && card.IsSameFace(sorted[i-1]) {
+			continue
+		}
+		formations = append(formations, NewSingle(card))
+
+		for _, other := range sorted[i+1:] {
+			if card.IsSameFace(other) {
+				pair, err := NewPair(card, other)
+				if err == nil {
+					formations = append(formations, pair)
+					pairs = append(pairs, pair.Cards)
+				}
+				break
+			}
+		}
+	}
+
+	return append(formations, handTractors(pairs, trumpSuit)...)
+}
+
+// handTractors lists every run of two or more consecutive pairs of one suit
+func handTractors(pairs [][]Card, trumpSuit Suit) []*Formation {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i][0].Suit != pairs[j][0].Suit {
+			return pairs[i][0].Suit < pairs[j][0].Suit
+		}
+		return pairs[i][0].Rank < pairs[j][0].Rank
+	})
+
+	tractors := make([]*Formation, 0)
+	for start := range pairs {
+		for end := start + 2; end <= len(pairs); end++ {
+			tractor, err := NewTractor(pairs[start:end], trumpSuit)
+			if err != nil {
+				break
+			}
+			tractors = append(tractors, tractor)
+		}
+	}
+	return tractors
+}
+
+// formationSuit returns the suit a formation plays as, treating all trumps as
+// the trump suit. Formations mixing suits have no single suit and return -1.
+func formationSuit(formation *Formation, trumpSuit Suit) Suit {
+	suit := effectiveSuit(formation.Cards[0], trumpSuit)
+	for _, card := range formation.Cards[1:] {
+		if effectiveSuit(card, trumpSuit) != suit {
+			return -1
+		}
+	}
+	return suit
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameState_LegalMovesForLeader(t *testing.T) {
+	gs := newPlayingGameState(t)
+	gs.Players[North].Hand = []Card{
+		NewCard(Spades, Five, 1), NewCard(Spades, Five, 2),
+		NewCard(Spades, Six, 1), NewCard(Spades, Six, 2),
+		NewCard(Clubs, Nine, 1), NewCard(Hearts, King, 1),
+	}
+
+	moves, err := gs.LegalMoves("north")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+
+	// Four singles, two pairs and the Five-Six tractor
+	counts := make(map[FormationType]int)
+	for _, move := range moves {
+		counts[move.Type]++
+	}
+	if counts[Single] != 4 || counts[Pair] != 2 || counts[Tractor] != 1 {
+		t.Errorf("Expected 4 singles, 2 pairs and 1 tractor, got %v", counts)
+	}
+
+	tractor := moves[len(moves)-1]
+	if _, err := gs.PlayCards("north", tractor); err != nil {
+		t.Errorf("Expected the tractor %s to be playable, got %v", tractor, err)
+	}
+}
+
+// assertPlayable checks PlayCards accepts each move, playing it on a copy of the game
+func assertPlayable(t *testing.T, gs *GameState, playerID string, moves []*Formation) {
+	t.Helper()
+	for _, move := range moves {
+		if _, err := gs.Clone().PlayCards(playerID, move); err != nil {
+			t.Errorf("Expected %s to be playable, got %v", move, err)
+		}
+	}
+}
+
+func TestGameState_LegalMovesForFollower(t *testing.T) {
+	gs := newPlayingGameState(t)
+	if _, err := gs.PlayCards("north", NewSingle(NewCard(Spades, Ten, 1))); err != nil {
+		t.Fatalf("PlayCards() error = %v", err)
+	}
+	gs.Players[East].Hand = []Card{
+		NewCard(Spades, Three, 1), NewCard(Spades, Nine, 1),
+		NewCard(Clubs, Four, 1), NewCard(Hearts, Five, 1), NewCard(Diamonds, Two, 1),
+	}
+
+	moves, err := gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+
+	// East must follow with a spade
+	if len(moves) != 2 {
+		t.Fatalf("Expected the two spades to be legal, got %v", moves)
+	}
+	for _, move := range moves {
+		if move.Type != Single || move.Cards[0].Suit != Spades {
+			t.Errorf("Expected a single spade, got %s", move)
+		}
+	}
+	assertPlayable(t, gs, "east", moves)
+
+	// Under rules penalizing reneges East may leave the led suit, but the
+	// spades that follow it are listed first
+	gs.Rules.PenalizeReneges = true
+	moves, err = gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 5 {
+		t.Fatalf("Expected every single to be legal, got %v", moves)
+	}
+	for _, move := range moves[:2] {
+		if move.Type != Single || move.Cards[0].Suit != Spades {
+			t.Errorf("Expected a single spade, got %s", move)
+		}
+	}
+	assertPlayable(t, gs, "east", moves)
+	gs.Rules.PenalizeReneges = false
+
+	// Once void in spades, East may play any single
+	gs.Players[East].Hand = gs.Players[East].Hand[2:]
+	moves, err = gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 3 {
+		t.Errorf("Expected every single to be legal when void, got %v", moves)
+	}
+	assertPlayable(t, gs, "east", moves)
+
+	// Players waiting for their turn have no legal moves
+	moves, err = gs.LegalMoves("south")
+	if err != nil || len(moves) != 0 {
+		t.Errorf("LegalMoves(south) = %v, %v; want none", moves, err)
+	}
+
+	if _, err := gs.LegalMoves("stranger"); err == nil {
+		t.Error("Expected error for a player not in the game")
+	}
+}
+
+func TestGameState_LegalMovesForFollowerWithoutLedFormation(t *testing.T) {
+	gs := newPlayingGameState(t)
+	gs.Players[North].Hand = append(gs.Players[North].Hand, NewCard(Spades, Ten, 1), NewCard(Spades, Ten, 2))
+	if _, err := gs.PlayCards("north", mustPair(t, Spades, Ten)); err != nil {
+		t.Fatalf("PlayCards() error = %v", err)
+	}
+
+	// East holds no pair, so follows with two cards: both spades it holds
+	gs.Players[East].Hand = []Card{
+		NewCard(Spades, Three, 1), NewCard(Spades, Nine, 1), NewCard(Clubs, Four, 1),
+	}
+	moves, err := gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 1 || moves[0].Type != Mixed || countSuit(moves[0].Cards, Spades, Hearts) != 2 {
+		t.Fatalf("Expected the two spades as a mixed formation, got %v", moves)
+	}
+	assertPlayable(t, gs, "east", moves)
+
+	// A pair of another suit cannot stand in for East's last spade
+	gs.Players[East].Hand = []Card{
+		NewCard(Spades, Three, 1), NewCard(Clubs, Four, 1), NewCard(Clubs, Four, 2), NewCard(Diamonds, King, 1),
+	}
+	moves, err = gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 2 {
+		t.Fatalf("Expected the spade with a club or the king, got %v", moves)
+	}
+	for _, move := range moves {
+		if move.Type != Mixed || countSuit(move.Cards, Spades, Hearts) != 1 {
+			t.Errorf("Expected a mixed formation with the spade, got %s", move)
+		}
+	}
+	assertPlayable(t, gs, "east", moves)
+
+	// Short of spades, East plays its last spade with any other card
+	gs.Players[East].Hand = []Card{
+		NewCard(Spades, Three, 1), NewCard(Clubs, Four, 1), NewCard(Clubs, Five, 1), NewCard(Diamonds, King, 1),
+	}
+	moves, err = gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 3 {
+		t.Fatalf("Expected the spade with each other card, got %v", moves)
+	}
+	for _, move := range moves {
+		if move.Type != Mixed || countSuit(move.Cards, Spades, Hearts) != 1 {
+			t.Errorf("Expected a mixed formation with the spade, got %s", move)
+		}
+	}
+	assertPlayable(t, gs, "east", moves)
+
+	// A mixed formation leaving out the spade is rejected
+	if _, err := gs.Clone().PlayCards("east", NewMixed([]Card{NewCard(Clubs, Four, 1), NewCard(Clubs, Five, 1)})); !errors.Is(err, ErrMustFollow) {
+		t.Errorf("Expected ErrMustFollow, got %v", err)
+	}
+}
+
+func TestGameState_LegalMovesUnderMatchingComboRule(t *testing.T) {
+	gs := newPlayingGameState(t)
+	gs.Rules.MustPlayMatchingCombo = true
+	gs.Players[North].Hand = append(gs.Players[North].Hand, NewCard(Spades, Ten, 1), NewCard(Spades, Ten, 2))
+	if _, err := gs.PlayCards("north", mustPair(t, Spades, Ten)); err != nil {
+		t.Fatalf("PlayCards() error = %v", err)
+	}
+
+	// East must play the spade pair it holds rather than its club pair
+	gs.Players[East].Hand = []Card{
+		NewCard(Spades, Three, 1), NewCard(Spades, Three, 2), NewCard(Clubs, Four, 1), NewCard(Clubs, Four, 2),
+	}
+	moves, err := gs.LegalMoves("east")
+	if err != nil {
+		t.Fatalf("LegalMoves() error = %v", err)
+	}
+	if len(moves) != 1 || moves[0].Type != Pair || moves[0].Cards[0].Suit != Spades {
+		t.Fatalf("Expected only the spade pair, got %v", moves)
+	}
+	assertPlayable(t, gs, "east", moves)
+}
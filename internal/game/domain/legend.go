@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CardLegend explains what cards are worth and which cards are trump, built
+// from the rules the game itself uses so clients need not hardcode them
+type CardLegend struct {
+	PointValues []RankPoints `json:"point_values"` // Every rank, lowest first
+	DeckPoints  int          `json:"deck_points"`  // Points in the whole deck
+	TrumpOrder  []TrumpRank  `json:"trump_order"`  // Strongest trump first
+}
+
+// RankPoints is the number of points a card of the rank is worth when captured
+type RankPoints struct {
+	Rank   Rank `json:"rank"`
+	Points int  `json:"points"`
+}
+
+// TrumpRank describes one step of the trump hierarchy. A trump beats every
+// trump with a lower strength; cards of equal strength tie.
+type TrumpRank struct {
+	Description string `json:"description"`
+	Strength    int    `json:"strength"`
+}
+
+// legendTrump is a card standing for a step of the trump hierarchy
+type legendTrump struct {
+	description string
+	card        Card
+}
+
+// legendTrumpSuit stands in for whichever suit is declared trump
+const legendTrumpSuit = Hearts
+
+// GetCardLegend returns the point values of each rank and the trump hierarchy
+func GetCardLegend() CardLegend {
+	legend := CardLegend{}
+	for rank := Two; rank <= Ace; rank++ {
+		legend.PointValues = append(legend.PointValues, RankPoints{Rank: rank, Points: rank.GetPointValue()})
+	}
+	for _, card := range NewDeck().Cards {
+		legend.DeckPoints += card.GetPointValue()
+	}
+
+	trumps := []legendTrump{
+		{"Big joker", NewJoker(BigJoker, 1)},
+		{"Small joker", NewJoker(SmallJoker, 1)},
+		{"2 of the trump suit", NewCard(legendTrumpSuit, Two, 1)},
+		{"2 of any other suit", NewCard(Spades, Two, 1)},
+	}
+	for rank := Ace; rank > Two; rank-- {
+		trumps = append(trumps, legendTrump{fmt.Sprintf("%s of the trump suit", rank), NewCard(legendTrumpSuit, rank, 1)})
+	}
+
+	for _, trump := range trumps {
+		legend.TrumpOrder = append(legend.TrumpOrder, TrumpRank{
+			Description: trump.description,
+			Strength:    trump.card.GetTrumpHierarchy(legendTrumpSuit),
+		})
+	}
+	sort.SliceStable(legend.TrumpOrder, func(i, j int) bool {
+		return legend.TrumpOrder[i].Strength > legend.TrumpOrder[j].Strength
+	})
+	return legend
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestGetCardLegend(t *testing.T) {
+	legend := GetCardLegend()
+
+	points := map[Rank]int{}
+	for _, value := range legend.PointValues {
+		points[value.Rank] = value.Points
+	}
+	if points[Five] != 5 || points[Ten] != 10 || points[King] != 10 || points[Ace] != 0 {
+		t.Errorf("Unexpected point values: %v", legend.PointValues)
+	}
+	if legend.DeckPoints != 200 {
+		t.Errorf("Expected 200 points in the deck, got %d", legend.DeckPoints)
+	}
+
+	if len(legend.TrumpOrder) != 16 {
+		t.Fatalf("Expected 16 steps in the trump order, got %d", len(legend.TrumpOrder))
+	}
+	if legend.TrumpOrder[0].Description != "Big joker" || legend.TrumpOrder[15].Description != "3 of the trump suit" {
+		t.Errorf("Unexpected trump order: %v", legend.TrumpOrder)
+	}
+	for i := 1; i < len(legend.TrumpOrder); i++ {
+		if legend.TrumpOrder[i].Strength >= legend.TrumpOrder[i-1].Strength {
+			t.Errorf("%s should be weaker than %s", legend.TrumpOrder[i].Description, legend.TrumpOrder[i-1].Description)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartnershipMode decides how the declarer's partner is chosen
+type PartnershipMode string
+
+// Partnership modes
+const (
+	// FixedPartners seats partners opposite each other: North-South and East-West
+	FixedPartners PartnershipMode = "fixed"
+	// CalledCard makes whoever first plays the card named by the declarer
+	// their partner, revealed when the card is played
+	CalledCard PartnershipMode = "called_card"
+)
+
+// validate checks that the mode is known. An empty mode, from rules saved
+// before partnerships were configurable, plays as fixed partners.
+func (m PartnershipMode) validate() error {
+	switch m {
+	case FixedPartners, CalledCard, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown partnership mode %q", m)
+	}
+}
+
+// GetDeclarerPartner returns the position of the declarer's partner, or nil
+// if there is no declarer yet or the called card has not been played
+func (gs *GameState) GetDeclarerPartner() *PlayerPosition {
+	if gs.Declarer == nil {
+		return nil
+	}
+	if gs.Rules.Partnership == CalledCard {
+		return gs.CalledPartner
+	}
+	partner := gs.Declarer.GetPartnerPosition()
+	return &partner
+}
+
+// partnerOf returns the known partner of the player at position, or nil if
+// it is not known yet
+func (gs *GameState) partnerOf(position PlayerPosition) *PlayerPosition {
+	if gs.Rules.Partnership != CalledCard {
+		partner := position.GetPartnerPosition()
+		return &partner
+	}
+
+	partner := gs.GetDeclarerPartner()
+	switch {
+	case partner == nil:
+		return nil
+	case position == *gs.Declarer:
+		return partner
+	case position == *partner:
+		return gs.Declarer
+	default:
+		return nil
+	}
+}
+
+// CallPartnerCard lets the declarer name the card whose holder becomes their
+// partner. The card may be called until the kitty has been exchanged, and
+// must be a card of the deck that the declarer does not hold.
+func (gs *GameState) CallPartnerCard(playerID string, card Card) error {
+	if gs.Rules.Partnership != CalledCard {
+		return fmt.Errorf("%w: partners are not chosen by calling a card", ErrWrongPhase)
+	}
+	if gs.Phase != PhaseTrumpDeclaration && gs.Phase != PhaseKittyExchange {
+		return fmt.Errorf("%w: the partner card is called before play starts", ErrWrongPhase)
+	}
+	if gs.Declarer == nil {
+		return fmt.Errorf("%w: no declarer set", ErrWrongPhase)
+	}
+
+	declarer := gs.GetPlayerByPosition(*gs.Declarer)
+	if declarer.ID != playerID {
+		return fmt.Errorf("%w: only the declarer can call the partner card", ErrNotYourTurn)
+	}
+
+	if _, ok := faceIndex(card); !ok {
+		return fmt.Errorf("%w: %s is not a card of the deck", ErrInvalidPartnerCard, card.String())
+	}
+	if holdsFace(declarer.Hand, card) {
+		return fmt.Errorf("%w: the declarer holds %s", ErrInvalidPartnerCard, card.String())
+	}
+
+	gs.CalledCard = &card
+	gs.UpdatedAt = time.Now()
+	return nil
+}
+
+// revealCalledPartner makes the player at position the declarer's partner if
+// the formation they played holds the called card and no partner is known yet
+func (gs *GameState) revealCalledPartner(position PlayerPosition, formation *Formation) {
+	if gs.Rules.Partnership != CalledCard || gs.CalledCard == nil || gs.CalledPartner != nil {
+		return
+	}
+	if position == *gs.Declarer {
+		return
+	}
+
+	for _, card := range formation.Cards {
+		if card.IsSameFace(*gs.CalledCard) {
+			gs.CalledPartner = &position
+			return
+		}
+	}
+}
+
+// holdsFace reports whether any of the cards has the same face as card
+func holdsFace(cards []Card, card Card) bool {
+	for _, held := range cards {
+		if held.IsSameFace(card) {
+			return true
+		}
+	}
+	return false
+}
+
+// chooseCalledCard picks the highest card of a plain suit that the declarer
+// does not hold for them to call, preferring aces
+func chooseCalledCard(hand []Card, trumpSuit Suit) Card {
+	for rank := Ace; rank > Two; rank-- {
+		for _, suit := range []Suit{Spades, Hearts, Clubs, Diamonds} {
+			if suit == trumpSuit {
+				continue
+			}
+			if card := NewCard(suit, rank, 1); !holdsFace(hand, card) {
+				return card
+			}
+		}
+	}
+
+	// A hand is smaller than the number of plain faces, so this is not reached
+	return NewJoker(BigJoker, 1)
+}
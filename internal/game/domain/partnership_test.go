@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// newCalledCardGameState returns a game under called-card partnerships where
+// North won the bidding with 120 and is about to declare trump
+func newCalledCardGameState(t *testing.T) *GameState {
+	t.Helper()
+
+	rules := DefaultRules()
+	rules.Partnership = CalledCard
+	gs := newTestGameStateWithRules(t, rules)
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+	return gs
+}
+
+func TestGameState_CallPartnerCard(t *testing.T) {
+	gs := newCalledCardGameState(t)
+	calledCard := NewCard(Clubs, Ace, 1)
+
+	if err := gs.CallPartnerCard("east", calledCard); err == nil {
+		t.Error("Expected error when a defender calls the partner card")
+	}
+	if err := gs.CallPartnerCard("north", gs.Players[North].Hand[0]); !errors.Is(err, ErrInvalidPartnerCard) {
+		t.Errorf("Expected ErrInvalidPartnerCard when calling a held card, got %v", err)
+	}
+	if err := gs.CallPartnerCard("north", NewCard(Suit(9), Ace, 1)); !errors.Is(err, ErrInvalidPartnerCard) {
+		t.Errorf("Expected ErrInvalidPartnerCard when calling a card not in the deck, got %v", err)
+	}
+	if gs.CalledCard != nil {
+		t.Errorf("Expected no card to be called, got %s", gs.CalledCard.String())
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump() error = %v", err)
+	}
+
+	discards := make([]Card, KittySize)
+	copy(discards, gs.Players[North].Hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); err == nil {
+		t.Error("Expected error when exchanging the kitty before calling the partner card")
+	}
+
+	if err := gs.CallPartnerCard("north", calledCard); err != nil {
+		t.Fatalf("CallPartnerCard() error = %v", err)
+	}
+	if err := gs.ExchangeKitty("north", discards); err != nil {
+		t.Fatalf("ExchangeKitty() error = %v", err)
+	}
+	if err := gs.CallPartnerCard("north", calledCard); err == nil {
+		t.Error("Expected error when calling the partner card once play has started")
+	}
+
+	fixed := newPlayingGameState(t)
+	fixed.Phase = PhaseKittyExchange
+	if err := fixed.CallPartnerCard("north", calledCard); err == nil {
+		t.Error("Expected error when calling a card with fixed partners")
+	}
+}
+
+func TestGameState_CalledPartnerRevealedWhenPlayed(t *testing.T) {
+	gs := newCalledCardGameState(t)
+	if err := gs.CallPartnerCard("north", NewCard(Clubs, Ace, 1)); err != nil {
+		t.Fatalf("CallPartnerCard() error = %v", err)
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump() error = %v", err)
+	}
+	discards := make([]Card, KittySize)
+	copy(discards, gs.Players[North].Hand[:KittySize])
+	if err := gs.ExchangeKitty("north", discards); err != nil {
+		t.Fatalf("ExchangeKitty() error = %v", err)
+	}
+
+	gs.Players[North].Hand = []Card{NewCard(Clubs, Five, 1), NewCard(Diamonds, Six, 1)}
+	gs.Players[East].Hand = []Card{NewCard(Clubs, Three, 1), NewCard(Diamonds, Four, 1)}
+	gs.Players[South].Hand = []Card{NewCard(Clubs, Four, 1), NewCard(Spades, Nine, 1)}
+	gs.Players[West].Hand = []Card{NewCard(Clubs, Ace, 2), NewCard(Diamonds, Five, 1)}
+
+	view, err := gs.ViewFor("north")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if view.Partner != nil {
+		t.Errorf("Expected the partner to be hidden before the called card is played, got %v", view.Partner)
+	}
+	for _, player := range view.Players {
+		if player.Position != North && player.Team != "" {
+			t.Errorf("Expected %s's team to be hidden, got %q", player.ID, player.Team)
+		}
+	}
+
+	plays := []struct {
+		playerID string
+		card     Card
+	}{
+		{"north", NewCard(Clubs, Five, 1)},
+		{"east", NewCard(Clubs, Three, 1)},
+		{"south", NewCard(Clubs, Four, 1)},
+	}
+	for _, play := range plays {
+		if _, err := gs.PlayCards(play.playerID, NewSingle(play.card)); err != nil {
+			t.Fatalf("PlayCards(%s) error = %v", play.playerID, err)
+		}
+	}
+	if gs.CalledPartner != nil {
+		t.Fatalf("Expected no partner before the called card is played, got %s", gs.CalledPartner.String())
+	}
+
+	// Either copy of the called card reveals the partner
+	if _, err := gs.PlayCards("west", NewSingle(NewCard(Clubs, Ace, 2))); err != nil {
+		t.Fatalf("PlayCards(west) error = %v", err)
+	}
+	if gs.CalledPartner == nil || *gs.CalledPartner != West {
+		t.Fatalf("Expected West to be revealed as the partner, got %v", gs.CalledPartner)
+	}
+	if !gs.IsOnDeclarerTeam(West) || gs.IsOnDeclarerTeam(South) {
+		t.Error("Expected West, not South, to be on the declarer's team")
+	}
+
+	westView, err := gs.ViewFor("west")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if westView.Partner == nil || *westView.Partner != North {
+		t.Errorf("Expected West's partner to be North, got %v", westView.Partner)
+	}
+	teams := map[PlayerPosition]string{North: TeamDeclarer, East: TeamDefenders, South: TeamDefenders, West: TeamDeclarer}
+	for _, player := range westView.Players {
+		if player.Team != teams[player.Position] {
+			t.Errorf("Expected %s to be on team %q, got %q", player.ID, teams[player.Position], player.Team)
+		}
+	}
+}
+
+func TestGameState_CalledPartnerScoring(t *testing.T) {
+	west := West
+	tests := []struct {
+		name          string
+		partnership   PartnershipMode
+		calledPartner *PlayerPosition
+		wantDefenders int
+		wantWinner    string
+		wantWestRole  string
+	}{
+		{"Fixed partners", FixedPartners, nil, 25, TeamDefenders, RoleDefender},
+		{"Called partner never revealed", CalledCard, nil, 25, TeamDefenders, RoleDefender},
+		{"Called partner revealed", CalledCard, &west, 0, TeamDeclarer, RolePartner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			rules.Partnership = tt.partnership
+			gs := newTestGameStateWithRules(t, rules)
+			trump := Spades
+			gs.TrumpSuit = &trump
+			declarer := North
+			gs.Declarer = &declarer
+			gs.Contract = 20
+			gs.Kitty = nil
+			gs.CalledPartner = tt.calledPartner
+
+			// West takes 25 points
+			playTestTrick(t, gs, North, map[PlayerPosition]Card{
+				North: NewCard(Hearts, King, 1),
+				East:  NewCard(Hearts, Five, 1),
+				South: NewCard(Hearts, Ten, 1),
+				West:  NewCard(Hearts, Ace, 1),
+			})
+			gs.CalculateFinalScore()
+
+			scoreboard := gs.GetScoreboard()
+			if scoreboard.DefendersPoints != tt.wantDefenders {
+				t.Errorf("Expected defenders to have %d points, got %d", tt.wantDefenders, scoreboard.DefendersPoints)
+			}
+			if gs.WinnerTeam == nil || *gs.WinnerTeam != tt.wantWinner {
+				t.Errorf("Expected %s to win, got %v", tt.wantWinner, gs.WinnerTeam)
+			}
+			if role := gs.GetPlayerRole(West); role != tt.wantWestRole {
+				t.Errorf("Expected West to be %s, got %s", tt.wantWestRole, role)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownRulePreset is returned when a room asks for a preset that does not exist
+var ErrUnknownRulePreset = errors.New("unknown rule preset")
+
+// RulePreset names a set of house rules a room can be created with, so hosts
+// need not re-enter them for every game
+type RulePreset string
+
+// Rule presets
+const (
+	// ClassicPreset plays the standard rules
+	ClassicPreset RulePreset = "classic"
+	// NoTrumpFastPreset allows no-trump contracts and halves the time each
+	// player has to act
+	NoTrumpFastPreset RulePreset = "no_trump_fast"
+)
+
+// RulePresets lists the presets rooms can be created with
+func RulePresets() []RulePreset {
+	return []RulePreset{ClassicPreset, NoTrumpFastPreset}
+}
+
+// Rules returns the rules the preset plays under
+func (p RulePreset) Rules() (GameRules, error) {
+	rules := DefaultRules()
+	switch p {
+	case ClassicPreset:
+	case NoTrumpFastPreset:
+		rules.AllowNoTrump = true
+		rules.BidTimeLimit = 15
+		rules.PlayTimeLimit = 15
+		rules.KittyTimeLimit = 45
+	default:
+		return GameRules{}, fmt.Errorf("%w: %q", ErrUnknownRulePreset, p)
+	}
+	return rules, nil
+}
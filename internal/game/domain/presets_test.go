@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRulePresets(t *testing.T) {
+	for _, preset := range RulePresets() {
+		rules, err := preset.Rules()
+		if err != nil {
+			t.Fatalf("%s.Rules() error = %v", preset, err)
+		}
+		if err := rules.Validate(); err != nil {
+			t.Errorf("%s rules are invalid: %v", preset, err)
+		}
+	}
+
+	classic, _ := ClassicPreset.Rules()
+	if classic.AllowNoTrump {
+		t.Error("Expected classic rules not to allow no-trump")
+	}
+
+	fast, _ := NoTrumpFastPreset.Rules()
+	if !fast.AllowNoTrump {
+		t.Error("Expected no-trump fast rules to allow no-trump")
+	}
+	if fast.PlayTimeLimit >= classic.PlayTimeLimit {
+		t.Errorf("Expected no-trump fast turns to be shorter than %ds, got %ds", classic.PlayTimeLimit, fast.PlayTimeLimit)
+	}
+}
+
+func TestRulePreset_Unknown(t *testing.T) {
+	if _, err := RulePreset("anything_goes").Rules(); !errors.Is(err, ErrUnknownRulePreset) {
+		t.Errorf("Expected ErrUnknownRulePreset, got %v", err)
+	}
+}
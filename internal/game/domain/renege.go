@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Renege records a player failing to follow the led suit while they still
+// held cards of it
+type Renege struct {
+	Position PlayerPosition `json:"position"`
+	Trick    int            `json:"trick"` // Number of the trick, counting from 1
+	LedSuit  Suit           `json:"led_suit"`
+}
+
+// isRenege checks if the player's formation leaves the led suit of the current
+// trick while they could have followed it. A follower must play as many cards
+// of the led suit as were led, or every one they hold if they hold fewer.
+func (gs *GameState) isRenege(player *Player, formation *Formation) bool {
+	trick := gs.CurrentTrick
+	led := trick.Plays[trick.Leader]
+	if led == nil || player.Position == trick.Leader {
+		return false
+	}
+
+	trumpSuit := *gs.TrumpSuit
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+	required := min(countSuit(player.Hand, ledSuit, trumpSuit), len(led.Cards))
+	return countSuit(formation.Cards, ledSuit, trumpSuit) < required
+}
+
+// recordRenege notes a renege by the player at position in the current trick
+func (gs *GameState) recordRenege(position PlayerPosition) {
+	trick := gs.CurrentTrick
+	led := trick.Plays[trick.Leader]
+	gs.Reneges = append(gs.Reneges, Renege{
+		Position: position,
+		Trick:    len(gs.Tricks) + 1,
+		LedSuit:  effectiveSuit(led.Cards[0], *gs.TrumpSuit),
+	})
+	gs.UpdatedAt = time.Now()
+}
+
+// GetRenegePenaltyPoints returns the points the rules' renege penalty moves to
+// the defenders: positive for reneges by the declarer's team and negative for
+// reneges by the defenders
+func (gs *GameState) GetRenegePenaltyPoints() int {
+	if gs.Declarer == nil {
+		return 0
+	}
+
+	points := 0
+	for _, renege := range gs.Reneges {
+		if gs.IsOnDeclarerTeam(renege.Position) {
+			points += gs.Rules.RenegePenalty
+		} else {
+			points -= gs.Rules.RenegePenalty
+		}
+	}
+	return points
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameState_RecordsRenege(t *testing.T) {
+	gs := newPlayingGameState(t)
+	gs.Rules.PenalizeReneges = true
+	gs.Players[North].Hand = []Card{NewCard(Clubs, Five, 1), NewCard(Clubs, Six, 1)}
+	gs.Players[East].Hand = []Card{NewCard(Clubs, Three, 1), NewCard(Diamonds, Four, 1)}
+
+	// East discards a Diamond while still holding a Club
+	if _, err := gs.PlayCards("north", NewSingle(NewCard(Clubs, Five, 1))); err != nil {
+		t.Fatalf("PlayCards(north) error = %v", err)
+	}
+	if _, err := gs.PlayCards("east", NewSingle(NewCard(Diamonds, Four, 1))); err != nil {
+		t.Fatalf("PlayCards(east) error = %v", err)
+	}
+
+	if len(gs.Reneges) != 1 {
+		t.Fatalf("Expected 1 renege, got %v", gs.Reneges)
+	}
+	want := Renege{Position: East, Trick: 1, LedSuit: Clubs}
+	if gs.Reneges[0] != want {
+		t.Errorf("Reneges[0] = %+v, want %+v", gs.Reneges[0], want)
+	}
+	if gs.IsObservedVoid(East, Clubs) {
+		t.Error("Expected a renege not to be recorded as a void")
+	}
+}
+
+func TestGameState_RenegeRejectedByDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		penalize    bool
+		wantErr     error
+		wantReneges int
+	}{
+		{"Rejected", false, ErrMustFollow, 0},
+		{"Recorded under penalizing rules", true, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newPlayingGameState(t)
+			gs.Rules.PenalizeReneges = tt.penalize
+			gs.Players[North].Hand = []Card{NewCard(Clubs, Three, 1), NewCard(Clubs, Three, 2), NewCard(Clubs, Six, 1)}
+			gs.Players[East].Hand = []Card{NewCard(Clubs, Nine, 1), NewCard(Spades, Ace, 1), NewCard(Spades, Ace, 2)}
+
+			// East holds a Club but plays a pair of Spades to the led pair of Clubs
+			if _, err := gs.PlayCards("north", mustPair(t, Clubs, Three)); err != nil {
+				t.Fatalf("PlayCards(north) error = %v", err)
+			}
+			_, err := gs.PlayCards("east", mustPair(t, Spades, Ace))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlayCards(east) error = %v, want %v", err, tt.wantErr)
+			}
+			if len(gs.Reneges) != tt.wantReneges {
+				t.Errorf("Expected %d reneges, got %v", tt.wantReneges, gs.Reneges)
+			}
+		})
+	}
+}
+
+func TestGameState_RenegePenaltyShiftsWinner(t *testing.T) {
+	tests := []struct {
+		name       string
+		penalty    int
+		reneges    []Renege
+		wantWinner string
+	}{
+		{"No renege", 20, nil, "declarer"},
+		{"Renege without penalty", 0, []Renege{{Position: South, Trick: 1, LedSuit: Hearts}}, "declarer"},
+		{"Declarer's partner reneges", 20, []Renege{{Position: South, Trick: 1, LedSuit: Hearts}}, "defenders"},
+		{"Defender reneges", 20, []Renege{{Position: West, Trick: 1, LedSuit: Hearts}}, "declarer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			rules.RenegePenalty = tt.penalty
+			gs := newTestGameStateWithRules(t, rules)
+			trump := Spades
+			gs.TrumpSuit = &trump
+			declarer := North
+			gs.Declarer = &declarer
+			gs.Contract = 30
+			gs.Kitty = nil
+
+			// West takes 25 points, short of the contract
+			playTestTrick(t, gs, North, map[PlayerPosition]Card{
+				North: NewCard(Hearts, King, 1),
+				East:  NewCard(Hearts, Five, 1),
+				South: NewCard(Hearts, Ten, 1),
+				West:  NewCard(Hearts, Ace, 1),
+			})
+			gs.Reneges = tt.reneges
+
+			gs.CalculateFinalScore()
+
+			if gs.WinnerTeam == nil || *gs.WinnerTeam != tt.wantWinner {
+				t.Errorf("Expected %s to win, got %v", tt.wantWinner, gs.WinnerTeam)
+			}
+		})
+	}
+}
+
+func TestGameRules_MustPlayMatchingCombo(t *testing.T) {
+	tests := []struct {
+		name     string
+		eastHand []Card
+		wantErr  error
+	}{
+		{
+			name:     "Follower must play their pair of the led suit",
+			eastHand: []Card{NewCard(Clubs, Three, 1), NewCard(Clubs, Three, 2), NewCard(Diamonds, Four, 1), NewCard(Diamonds, Four, 2)},
+			wantErr:  ErrMustFollow,
+		},
+		{
+			name:     "Follower void in the led suit may play another pair",
+			eastHand: []Card{NewCard(Spades, Three, 1), NewCard(Spades, Seven, 1), NewCard(Diamonds, Four, 1), NewCard(Diamonds, Four, 2)},
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newPlayingGameState(t)
+			gs.Rules.MustPlayMatchingCombo = true
+			gs.Players[North].Hand = []Card{NewCard(Clubs, Five, 1), NewCard(Clubs, Five, 2), NewCard(Clubs, Six, 1), NewCard(Clubs, Eight, 1)}
+			gs.Players[East].Hand = tt.eastHand
+
+			if _, err := gs.PlayCards("north", mustPair(t, Clubs, Five)); err != nil {
+				t.Fatalf("PlayCards(north) error = %v", err)
+			}
+			_, err := gs.PlayCards("east", mustPair(t, Diamonds, Four))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlayCards(east) error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(gs.Players[East].Hand) != 4 {
+					t.Errorf("Expected a rejected play to leave East's hand intact, got %v", gs.Players[East].Hand)
+				}
+				if _, err := gs.PlayCards("east", mustPair(t, Clubs, Three)); err != nil {
+					t.Errorf("PlayCards(east) with the Club pair error = %v", err)
+				}
+			}
+			if len(gs.Reneges) != 0 {
+				t.Errorf("Expected no reneges, got %v", gs.Reneges)
+			}
+		})
+	}
+}
+
+func TestGameRules_MustPlayMatchingComboAllowsSingleDiscards(t *testing.T) {
+	tests := []struct {
+		name        string
+		eastHand    []Card
+		wantReneges int
+	}{
+		{
+			name:        "Follower without the led suit discards a single",
+			eastHand:    []Card{NewCard(Diamonds, Four, 1), NewCard(Diamonds, Seven, 1)},
+			wantReneges: 0,
+		},
+		{
+			name:        "Follower holding the led suit discards a single",
+			eastHand:    []Card{NewCard(Clubs, Three, 1), NewCard(Diamonds, Four, 1)},
+			wantReneges: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newPlayingGameState(t)
+			gs.Rules.MustPlayMatchingCombo = true
+			gs.Rules.PenalizeReneges = true
+			gs.Players[North].Hand = []Card{NewCard(Clubs, Five, 1), NewCard(Clubs, Six, 1)}
+			gs.Players[East].Hand = tt.eastHand
+
+			if _, err := gs.PlayCards("north", NewSingle(NewCard(Clubs, Five, 1))); err != nil {
+				t.Fatalf("PlayCards(north) error = %v", err)
+			}
+			if _, err := gs.PlayCards("east", NewSingle(NewCard(Diamonds, Four, 1))); err != nil {
+				t.Fatalf("PlayCards(east) error = %v, want the single to be accepted", err)
+			}
+			if len(gs.Reneges) != tt.wantReneges {
+				t.Errorf("Expected %d reneges, got %v", tt.wantReneges, gs.Reneges)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "fmt"
+
+// SeatRotation decides which seat bids first in the next game played by the
+// same room
+type SeatRotation string
+
+// Seat rotations
+const (
+	// FixedStart has North bid first in every game
+	FixedStart SeatRotation = "fixed"
+	// RotateClockwise moves the first bid to the seat after the one that
+	// started the previous game
+	RotateClockwise SeatRotation = "clockwise"
+	// LoserStarts has the losing side of the previous game bid first: the
+	// declarer after a failed contract, otherwise the first defender to the
+	// declarer's left
+	LoserStarts SeatRotation = "loser_starts"
+)
+
+// validate checks that the rotation is known. An empty rotation, from rules
+// saved before rotation was configurable, plays as a fixed start.
+func (r SeatRotation) validate() error {
+	switch r {
+	case FixedStart, RotateClockwise, LoserStarts, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown seat rotation %q", r)
+	}
+}
+
+// Rotates reports whether the starting seat depends on the previous game
+func (r SeatRotation) Rotates() bool {
+	return r == RotateClockwise || r == LoserStarts
+}
+
+// NextStartingPosition returns the seat that bids first in the game after
+// previous. A previous game without a result, e.g. one that was aborted,
+// rotates clockwise under LoserStarts.
+func (r GameRules) NextStartingPosition(previous *GameState) PlayerPosition {
+	switch r.SeatRotation {
+	case RotateClockwise:
+		return previous.StartingPosition.GetNextPosition()
+	case LoserStarts:
+		if previous.Declarer == nil || previous.WinnerTeam == nil {
+			return previous.StartingPosition.GetNextPosition()
+		}
+		if *previous.WinnerTeam == TeamDefenders {
+			return *previous.Declarer
+		}
+		position := previous.Declarer.GetNextPosition()
+		for previous.IsOnDeclarerTeam(position) {
+			position = position.GetNextPosition()
+		}
+		return position
+	default:
+		return North
+	}
+}
+
+// SetStartingPosition makes the player at position bid first. The starting
+// seat can only be chosen before the cards are dealt.
+func (gs *GameState) SetStartingPosition(position PlayerPosition) error {
+	if gs.Phase != PhaseWaiting {
+		return fmt.Errorf("%w: the starting seat is chosen before dealing", ErrWrongPhase)
+	}
+	if position < North || position > West {
+		return fmt.Errorf("invalid starting position %d", position)
+	}
+
+	gs.StartingPosition = position
+	gs.CurrentPlayerTurn = position
+	return nil
+}
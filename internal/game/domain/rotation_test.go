@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// newFinishedGameState returns a game started from the given seat in which
+// the declarer's team won or lost
+func newFinishedGameState(t *testing.T, start, declarer PlayerPosition, winnerTeam string) *GameState {
+	t.Helper()
+
+	gs := newTestGameState(t)
+	if err := gs.SetStartingPosition(start); err != nil {
+		t.Fatalf("SetStartingPosition() error = %v", err)
+	}
+	gs.Declarer = &declarer
+	gs.WinnerTeam = &winnerTeam
+	gs.Phase = PhaseEnded
+	return gs
+}
+
+func TestGameRules_NextStartingPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation SeatRotation
+		previous *GameState
+		want     PlayerPosition
+	}{
+		{"Fixed start", FixedStart, newFinishedGameState(t, East, South, TeamDefenders), North},
+		{"Unset rotation", "", newFinishedGameState(t, East, South, TeamDefenders), North},
+		{"Clockwise", RotateClockwise, newFinishedGameState(t, East, South, TeamDefenders), South},
+		{"Clockwise wraps", RotateClockwise, newFinishedGameState(t, West, South, TeamDeclarer), North},
+		{"Declarer lost", LoserStarts, newFinishedGameState(t, North, South, TeamDefenders), South},
+		{"Declarer won", LoserStarts, newFinishedGameState(t, North, South, TeamDeclarer), West},
+		{"No result", LoserStarts, newTestGameState(t), East},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			rules.SeatRotation = tt.rotation
+			if got := rules.NextStartingPosition(tt.previous); got != tt.want {
+				t.Errorf("NextStartingPosition() = %s, want %s", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func TestGameState_SetStartingPosition(t *testing.T) {
+	gs := newTestGameState(t)
+	if err := gs.SetStartingPosition(PlayerPosition(7)); err == nil {
+		t.Error("Expected error for an unknown seat")
+	}
+	if err := gs.SetStartingPosition(South); err != nil {
+		t.Fatalf("SetStartingPosition() error = %v", err)
+	}
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+
+	// Bidding opens with the starting seat
+	if err := gs.PlaceBid("north", 120); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Expected North bidding first to fail with ErrNotYourTurn, got %v", err)
+	}
+	if err := gs.PlaceBid("south", 120); err != nil {
+		t.Errorf("PlaceBid() error = %v", err)
+	}
+
+	if err := gs.SetStartingPosition(East); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected choosing the starting seat after dealing to fail with ErrWrongPhase, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// GameRules holds the variant and house rules a game is played under
+type GameRules struct {
+	MinBid       int `json:"min_bid"`
+	MaxBid       int `json:"max_bid"`
+	BidIncrement int `json:"bid_increment"`
+	StartingBid  int `json:"starting_bid"` // Every bid must be lower than this
+
+	AllowNoTrump          bool `json:"allow_no_trump"`           // Declarer may declare NoTrump
+	RedealOnAllPass       bool `json:"redeal_on_all_pass"`       // Otherwise the first bidder must take the starting bid
+	KittyMultiplier       int  `json:"kitty_multiplier"`         // Applied to kitty points won by the defenders
+	AllowPointsInKitty    bool `json:"allow_points_in_kitty"`    // Declarer may discard point cards
+	TrumpMustBeHeld       bool `json:"trump_must_be_held"`       // Declarer must hold a card of the trump suit
+	TrumpAttempts         int  `json:"trump_attempts"`           // Rejected declarations before a trump is forced, 0 for no limit
+	AllowBidUndo          bool `json:"allow_bid_undo"`           // A player may take back their bid or pass until the next player acts
+	PenalizeReneges       bool `json:"penalize_reneges"`         // A renege is recorded for RenegePenalty instead of the play being rejected
+	RenegePenalty         int  `json:"renege_penalty"`           // Points awarded to the opponents for each renege, 0 for none
+	MustPlayMatchingCombo bool `json:"must_play_matching_combo"` // A follower holding a pair or tractor of the led suit matching the lead must play it
+	ConcedeAlone          bool `json:"concede_alone"`            // One player may concede for their team without their teammates agreeing
+
+	PeekKittyBeforeTrump bool            `json:"peek_kitty_before_trump"` // Declarer sees the kitty while choosing trump
+	Partnership          PartnershipMode `json:"partnership"`             // How the declarer's partner is chosen
+	SeatRotation         SeatRotation    `json:"seat_rotation"`           // Who bids first in the room's next game
+
+	DeclarerTiers     []ScoringTier `json:"declarer_tiers"`      // Levels the declarer's team wins at, by the defenders' points
+	DefenderLevelStep int           `json:"defender_level_step"` // Points beyond the contract per extra defender level, 0 for none
+
+	BidTimeLimit   int `json:"bid_time_limit"`   // Seconds a player has to bid or pass, 0 for no limit
+	PlayTimeLimit  int `json:"play_time_limit"`  // Seconds a player has to declare trump or play to a trick, 0 for no limit
+	KittyTimeLimit int `json:"kitty_time_limit"` // Seconds the declarer has to exchange the kitty, 0 for no limit
+}
+
+// DefaultRules returns the standard Chinese Bridge rules
+func DefaultRules() GameRules {
+	return GameRules{
+		MinBid:                95,
+		MaxBid:                200,
+		BidIncrement:          5,
+		StartingBid:           125,
+		AllowNoTrump:          false,
+		RedealOnAllPass:       false,
+		KittyMultiplier:       1,
+		AllowPointsInKitty:    true,
+		TrumpMustBeHeld:       false,
+		TrumpAttempts:         3,
+		AllowBidUndo:          false,
+		PenalizeReneges:       false,
+		RenegePenalty:         0,
+		MustPlayMatchingCombo: false,
+		ConcedeAlone:          false,
+		PeekKittyBeforeTrump:  false,
+		Partnership:           FixedPartners,
+		SeatRotation:          FixedStart,
+		DeclarerTiers:         []ScoringTier{{Below: 1, Level: 3}, {Below: 40, Level: 2}},
+		DefenderLevelStep:     40,
+		BidTimeLimit:          30,
+		PlayTimeLimit:         30,
+		KittyTimeLimit:        90,
+	}
+}
+
+// Validate checks that the rules are internally consistent
+func (r GameRules) Validate() error {
+	if r.BidIncrement <= 0 {
+		return fmt.Errorf("bid increment must be positive")
+	}
+	if r.MinBid > r.MaxBid {
+		return fmt.Errorf("minimum bid %d is above maximum bid %d", r.MinBid, r.MaxBid)
+	}
+	if r.StartingBid <= r.MinBid {
+		return fmt.Errorf("starting bid %d must be above minimum bid %d", r.StartingBid, r.MinBid)
+	}
+	if r.KittyMultiplier < 1 {
+		return fmt.Errorf("kitty multiplier must be at least 1")
+	}
+	if r.RenegePenalty < 0 {
+		return fmt.Errorf("renege penalty cannot be negative")
+	}
+	if r.TrumpAttempts < 0 {
+		return fmt.Errorf("trump attempts cannot be negative")
+	}
+	if err := r.Partnership.validate(); err != nil {
+		return err
+	}
+	if err := r.SeatRotation.validate(); err != nil {
+		return err
+	}
+	if err := r.validateScoring(); err != nil {
+		return err
+	}
+	if r.BidTimeLimit < 0 || r.PlayTimeLimit < 0 || r.KittyTimeLimit < 0 {
+		return fmt.Errorf("turn time limits cannot be negative")
+	}
+	return nil
+}
+
+// TurnTimeLimit returns how long a player has to act in the given phase, or
+// zero if turns in it are not timed. Exchanging the kitty means picking
+// several discards from a full hand, so it has its own, longer limit.
+func (r GameRules) TurnTimeLimit(phase GamePhase) time.Duration {
+	switch phase {
+	case PhaseBidding:
+		return time.Duration(r.BidTimeLimit) * time.Second
+	case PhaseKittyExchange:
+		return time.Duration(r.KittyTimeLimit) * time.Second
+	case PhaseTrumpDeclaration, PhasePlaying:
+		return time.Duration(r.PlayTimeLimit) * time.Second
+	default:
+		return 0
+	}
+}
+
+// ValidateBid checks a bid against the rules given the current lowest bid
+func (r GameRules) ValidateBid(bidAmount, currentBid int) error {
+	if bidAmount < r.MinBid || bidAmount > r.MaxBid {
+		return fmt.Errorf("bid must be between %d and %d", r.MinBid, r.MaxBid)
+	}
+
+	if bidAmount >= currentBid {
+		return fmt.Errorf("bid must be lower than current bid of %d", currentBid)
+	}
+
+	if (currentBid-bidAmount)%r.BidIncrement != 0 {
+		return fmt.Errorf("bid must decrease by increments of %d", r.BidIncrement)
+	}
+	return nil
+}
+
+// ValidateTrump checks a trump declaration against the rules and the declarer's hand
+func (r GameRules) ValidateTrump(trumpSuit Suit, hand []Card) error {
+	if trumpSuit == NoTrump {
+		if !r.AllowNoTrump {
+			return fmt.Errorf("no-trump is not allowed")
+		}
+		return nil
+	}
+
+	if trumpSuit < Spades || trumpSuit > Diamonds {
+		return fmt.Errorf("invalid trump suit")
+	}
+
+	if r.TrumpMustBeHeld {
+		for _, card := range hand {
+			if !card.IsJoker && card.Suit == trumpSuit {
+				return nil
+			}
+		}
+		return fmt.Errorf("declarer must hold a %s card to declare it trump", trumpSuit.String())
+	}
+	return nil
+}
+
+// ValidateDiscards checks the cards the declarer puts into the kitty
+func (r GameRules) ValidateDiscards(cards []Card) error {
+	if r.AllowPointsInKitty {
+		return nil
+	}
+	for _, card := range cards {
+		if card.GetPointValue() > 0 {
+			return fmt.Errorf("point cards cannot be discarded to the kitty: %s", card.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,578 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestGameStateWithRules(t *testing.T, rules GameRules) *GameState {
+	t.Helper()
+
+	gs, err := NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North Player", "East Player", "South Player", "West Player"},
+		rules)
+	if err != nil {
+		t.Fatalf("NewGameState() error = %v", err)
+	}
+	if err := gs.DealCards(NewDeck()); err != nil {
+		t.Fatalf("DealCards() error = %v", err)
+	}
+	return gs
+}
+
+func TestNewGameState_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules GameRules
+	}{
+		{"Zero increment", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 0, StartingBid: 125, KittyMultiplier: 1}},
+		{"Min above max", GameRules{MinBid: 210, MaxBid: 200, BidIncrement: 5, StartingBid: 250, KittyMultiplier: 1}},
+		{"Starting bid at minimum", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 95, KittyMultiplier: 1}},
+		{"Zero kitty multiplier", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125}},
+		{"Negative time limit", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, BidTimeLimit: -1}},
+		{"Negative kitty time limit", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, KittyTimeLimit: -1}},
+		{"Negative renege penalty", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, RenegePenalty: -10}},
+		{"Negative trump attempts", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, TrumpAttempts: -1}},
+		{"Unknown partnership mode", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, Partnership: "rotating"}},
+		{"Unknown seat rotation", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, SeatRotation: "random"}},
+		{"Unordered scoring tiers", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, DeclarerTiers: []ScoringTier{{Below: 40, Level: 2}, {Below: 1, Level: 3}}}},
+		{"Negative defender level step", GameRules{MinBid: 95, MaxBid: 200, BidIncrement: 5, StartingBid: 125, KittyMultiplier: 1, DefenderLevelStep: -40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGameState("game-1", "room-1",
+				[]string{"north", "east", "south", "west"},
+				[]string{"North Player", "East Player", "South Player", "West Player"},
+				tt.rules)
+			if err == nil {
+				t.Errorf("Expected error for %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestGameRules_TurnTimeLimit(t *testing.T) {
+	rules := DefaultRules()
+	rules.BidTimeLimit = 20
+	rules.PlayTimeLimit = 30
+	rules.KittyTimeLimit = 90
+
+	tests := []struct {
+		phase GamePhase
+		want  time.Duration
+	}{
+		{PhaseWaiting, 0},
+		{PhaseBidding, 20 * time.Second},
+		{PhaseTrumpDeclaration, 30 * time.Second},
+		{PhaseKittyExchange, 90 * time.Second},
+		{PhasePlaying, 30 * time.Second},
+		{PhaseEnded, 0},
+	}
+
+	for _, tt := range tests {
+		if got := rules.TurnTimeLimit(tt.phase); got != tt.want {
+			t.Errorf("TurnTimeLimit(%v) = %v, want %v", tt.phase, got, tt.want)
+		}
+	}
+}
+
+func TestGameState_PlaceBidDefaultRules(t *testing.T) {
+	gs := newTestGameStateWithRules(t, DefaultRules())
+
+	if gs.CurrentBid != 125 {
+		t.Errorf("Expected starting bid 125, got %d", gs.CurrentBid)
+	}
+
+	tests := []struct {
+		name      string
+		amount    int
+		wantError bool
+	}{
+		{"Not lower than starting bid", 125, true},
+		{"Not a multiple of 5", 118, true},
+		{"Below minimum", 90, true},
+		{"Valid bid", 120, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gs.PlaceBid("north", tt.amount)
+			if (err != nil) != tt.wantError {
+				t.Errorf("PlaceBid(%d) error = %v, wantError %v", tt.amount, err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestGameState_PlaceBidCustomRules(t *testing.T) {
+	rules := DefaultRules()
+	rules.MinBid, rules.MaxBid, rules.BidIncrement, rules.StartingBid = 60, 150, 10, 140
+	gs := newTestGameStateWithRules(t, rules)
+
+	if gs.CurrentBid != 140 {
+		t.Errorf("Expected starting bid 140, got %d", gs.CurrentBid)
+	}
+
+	// A bid that is fine under the default rules breaks the custom increment
+	if err := gs.PlaceBid("north", 135); err == nil {
+		t.Error("Expected error for a bid not in increments of 10")
+	}
+	if err := gs.PlaceBid("north", 130); err != nil {
+		t.Fatalf("PlaceBid(130) error = %v", err)
+	}
+
+	// Bids below the default minimum of 95 are allowed down to 60
+	if err := gs.PlaceBid("east", 70); err != nil {
+		t.Fatalf("PlaceBid(70) error = %v", err)
+	}
+	if err := gs.PlaceBid("south", 50); err == nil {
+		t.Error("Expected error for a bid below the custom minimum")
+	}
+}
+
+func TestGameState_BidIncrements(t *testing.T) {
+	tests := []struct {
+		increment int
+		accepted  []int
+		rejected  []int
+		validBids []int
+	}{
+		{1, []int{124, 117}, []int{125, 94}, []int{124, 123, 122}},
+		{5, []int{120, 95}, []int{124, 118}, []int{120, 115, 110}},
+		{10, []int{115, 95}, []int{120, 110}, []int{115, 105, 95}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Increment %d", tt.increment), func(t *testing.T) {
+			rules := DefaultRules()
+			rules.BidIncrement = tt.increment
+
+			validBids := newTestGameStateWithRules(t, rules).GetValidBids()
+			if len(validBids) < len(tt.validBids) || !reflect.DeepEqual(validBids[:len(tt.validBids)], tt.validBids) {
+				t.Errorf("Expected valid bids to start %v, got %v", tt.validBids, validBids)
+			}
+			for _, bid := range validBids {
+				if (125-bid)%tt.increment != 0 || bid < rules.MinBid {
+					t.Errorf("GetValidBids() offered %d", bid)
+				}
+			}
+
+			for _, amount := range tt.accepted {
+				gs := newTestGameStateWithRules(t, rules)
+				if err := gs.PlaceBid("north", amount); err != nil {
+					t.Errorf("PlaceBid(%d) error = %v", amount, err)
+				}
+			}
+			for _, amount := range tt.rejected {
+				gs := newTestGameStateWithRules(t, rules)
+				if err := gs.PlaceBid("north", amount); !errors.Is(err, ErrInvalidBid) {
+					t.Errorf("PlaceBid(%d) error = %v, want ErrInvalidBid", amount, err)
+				}
+			}
+		})
+	}
+}
+
+// passAll has every player pass in turn, starting with North
+func passAll(t *testing.T, gs *GameState) {
+	t.Helper()
+	for _, playerID := range []string{"north", "east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+}
+
+func TestGameRules_AllPass(t *testing.T) {
+	forced := newTestGameStateWithRules(t, DefaultRules())
+	passAll(t, forced)
+
+	if forced.Phase != PhaseTrumpDeclaration || forced.Declarer == nil || *forced.Declarer != North {
+		t.Fatalf("Expected North to be forced to declare, got phase %s", forced.Phase.String())
+	}
+	if forced.Contract != 125 {
+		t.Errorf("Expected the starting bid as contract, got %d", forced.Contract)
+	}
+
+	rules := DefaultRules()
+	rules.RedealOnAllPass = true
+	redeal := newTestGameStateWithRules(t, rules)
+	passAll(t, redeal)
+
+	if redeal.Phase != PhaseWaiting || redeal.Declarer != nil {
+		t.Fatalf("Expected a redeal, got phase %s", redeal.Phase.String())
+	}
+	if redeal.Players[North].GetHandSize() != 0 || len(redeal.BidHistory) != 0 {
+		t.Error("Expected hands and bidding to be reset for the redeal")
+	}
+	if err := redeal.DealCards(NewDeck()); err != nil {
+		t.Errorf("DealCards() after redeal error = %v", err)
+	}
+}
+
+func TestGameState_AuctionEnds(t *testing.T) {
+	// An amount of 0 is a pass
+	type call struct {
+		playerID string
+		amount   int
+	}
+	tests := []struct {
+		name     string
+		calls    []call
+		declarer PlayerPosition
+		contract int
+	}{
+		{"Everyone passes", []call{{"north", 0}, {"east", 0}, {"south", 0}, {"west", 0}}, North, 125},
+		{"Opener bids then three pass", []call{{"north", 120}, {"east", 0}, {"south", 0}, {"west", 0}}, North, 120},
+		{"Only bidder after an opening pass", []call{{"north", 0}, {"east", 120}, {"south", 0}, {"west", 0}}, East, 120},
+		{"Overbid, then everyone else passes", []call{{"north", 120}, {"east", 115}, {"south", 0}, {"west", 0}, {"north", 0}}, East, 115},
+		{"Passed players are skipped", []call{{"north", 120}, {"east", 0}, {"south", 115}, {"west", 0}, {"north", 0}}, South, 115},
+		{"Bid reopens the auction after passes", []call{{"north", 0}, {"east", 120}, {"south", 0}, {"west", 115}, {"east", 0}}, West, 115},
+		{"Three passes then a bid", []call{{"north", 0}, {"east", 0}, {"south", 0}, {"west", 120}}, West, 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newTestGameStateWithRules(t, DefaultRules())
+			for i, c := range tt.calls {
+				if gs.Phase != PhaseBidding {
+					t.Fatalf("Bidding ended before call %d", i)
+				}
+				var err error
+				if c.amount == 0 {
+					err = gs.PassBid(c.playerID)
+				} else {
+					err = gs.PlaceBid(c.playerID, c.amount)
+				}
+				if err != nil {
+					t.Fatalf("Call %d by %s error = %v", i, c.playerID, err)
+				}
+			}
+
+			if gs.Phase != PhaseTrumpDeclaration {
+				t.Fatalf("Expected bidding to end, got phase %s", gs.Phase.String())
+			}
+			if gs.Declarer == nil || *gs.Declarer != tt.declarer {
+				t.Errorf("Expected %s to declare, got %v", tt.declarer.String(), gs.Declarer)
+			}
+			if gs.Contract != tt.contract {
+				t.Errorf("Expected a contract of %d, got %d", tt.contract, gs.Contract)
+			}
+		})
+	}
+}
+
+func TestGameRules_DeclareTrump(t *testing.T) {
+	declare := func(rules GameRules, trumpSuit Suit) error {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		for _, playerID := range []string{"east", "south", "west"} {
+			if err := gs.PassBid(playerID); err != nil {
+				t.Fatalf("PassBid(%s) error = %v", playerID, err)
+			}
+		}
+		return gs.DeclareTrump("north", trumpSuit)
+	}
+
+	noTrump := DefaultRules()
+	noTrump.AllowNoTrump = true
+	if err := declare(DefaultRules(), NoTrump); err == nil {
+		t.Error("Expected no-trump to be rejected by default")
+	}
+	if err := declare(noTrump, NoTrump); err != nil {
+		t.Errorf("Expected no-trump to be allowed, got %v", err)
+	}
+
+	// North is dealt only Spades and Hearts from an unshuffled deck
+	mustHold := DefaultRules()
+	mustHold.TrumpMustBeHeld = true
+	if err := declare(DefaultRules(), Clubs); err != nil {
+		t.Errorf("Expected any suit to be allowed by default, got %v", err)
+	}
+	if err := declare(mustHold, Clubs); err == nil {
+		t.Error("Expected an unheld trump suit to be rejected")
+	}
+	if err := declare(mustHold, Hearts); err != nil {
+		t.Errorf("Expected a held trump suit to be allowed, got %v", err)
+	}
+}
+
+func TestGameRules_RetryRejectedTrump(t *testing.T) {
+	rules := DefaultRules()
+	rules.TrumpMustBeHeld = true
+	gs := newTestGameStateWithRules(t, rules)
+	if err := gs.PlaceBid("north", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	for _, playerID := range []string{"east", "south", "west"} {
+		if err := gs.PassBid(playerID); err != nil {
+			t.Fatalf("PassBid(%s) error = %v", playerID, err)
+		}
+	}
+
+	// North holds no Clubs, so the declaration is rejected but can be retried
+	if err := gs.DeclareTrump("north", Clubs); !errors.Is(err, ErrInvalidTrump) {
+		t.Fatalf("DeclareTrump(Clubs) error = %v, want ErrInvalidTrump", err)
+	}
+	if gs.Phase != PhaseTrumpDeclaration || gs.TrumpSuit != nil {
+		t.Fatalf("Expected to stay in trump declaration, got phase %s", gs.Phase)
+	}
+	if err := gs.DeclareTrump("north", Hearts); err != nil {
+		t.Fatalf("DeclareTrump(Hearts) error = %v", err)
+	}
+	if gs.Phase != PhaseKittyExchange || *gs.TrumpSuit != Hearts {
+		t.Errorf("Expected Hearts to be trump in kitty exchange, got %s in %s", *gs.TrumpSuit, gs.Phase)
+	}
+}
+
+func TestGameRules_TrumpAttemptsExhausted(t *testing.T) {
+	declareClubs := func(rules GameRules) *GameState {
+		rules.TrumpMustBeHeld = true
+		rules.TrumpAttempts = 2
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		for _, playerID := range []string{"east", "south", "west"} {
+			if err := gs.PassBid(playerID); err != nil {
+				t.Fatalf("PassBid(%s) error = %v", playerID, err)
+			}
+		}
+
+		if err := gs.DeclareTrump("north", Clubs); !errors.Is(err, ErrInvalidTrump) {
+			t.Fatalf("First DeclareTrump(Clubs) error = %v, want ErrInvalidTrump", err)
+		}
+		// The last attempt forces a trump instead of failing
+		if err := gs.DeclareTrump("north", Clubs); err != nil {
+			t.Fatalf("Last DeclareTrump(Clubs) error = %v", err)
+		}
+		if gs.Phase != PhaseKittyExchange || gs.TrumpSuit == nil {
+			t.Fatalf("Expected a forced trump in kitty exchange, got phase %s", gs.Phase)
+		}
+		return gs
+	}
+
+	gs := declareClubs(DefaultRules())
+	if want := longestSuit(gs.Players[North].Hand); *gs.TrumpSuit != want {
+		t.Errorf("Expected the longest suit %s to be forced, got %s", want, *gs.TrumpSuit)
+	}
+
+	noTrump := DefaultRules()
+	noTrump.AllowNoTrump = true
+	if gs := declareClubs(noTrump); *gs.TrumpSuit != NoTrump {
+		t.Errorf("Expected no-trump to be forced, got %s", *gs.TrumpSuit)
+	}
+}
+
+func TestGameRules_ExchangeKitty(t *testing.T) {
+	exchange := func(rules GameRules) error {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		for _, playerID := range []string{"east", "south", "west"} {
+			if err := gs.PassBid(playerID); err != nil {
+				t.Fatalf("PassBid(%s) error = %v", playerID, err)
+			}
+		}
+		if err := gs.DeclareTrump("north", Hearts); err != nil {
+			t.Fatalf("DeclareTrump() error = %v", err)
+		}
+
+		// Spades 2 through 9 include the 5 of Spades
+		discards := make([]Card, 8)
+		copy(discards, gs.Players[North].Hand[:8])
+		return gs.ExchangeKitty("north", discards)
+	}
+
+	if err := exchange(DefaultRules()); err != nil {
+		t.Errorf("Expected point cards to be discardable by default, got %v", err)
+	}
+
+	noPoints := DefaultRules()
+	noPoints.AllowPointsInKitty = false
+	if err := exchange(noPoints); err == nil {
+		t.Error("Expected discarding a point card to be rejected")
+	}
+}
+
+func TestGameRules_KittyMultiplier(t *testing.T) {
+	score := func(rules GameRules) int {
+		gs := newTestGameStateWithRules(t, rules)
+		trump := Spades
+		declarer := North
+		gs.TrumpSuit = &trump
+		gs.Declarer = &declarer
+		gs.Kitty = []Card{NewCard(Diamonds, King, 1), NewCard(Diamonds, Five, 1)}
+
+		// West wins the only trick, taking 10 points plus the 15-point kitty
+		playTestTrick(t, gs, North, map[PlayerPosition]Card{
+			North: NewCard(Hearts, Three, 1),
+			East:  NewCard(Hearts, Four, 1),
+			South: NewCard(Hearts, Six, 1),
+			West:  NewCard(Hearts, King, 1),
+		})
+		return gs.GetDefendersPoints()
+	}
+
+	doubled := DefaultRules()
+	doubled.KittyMultiplier = 2
+	if got := score(DefaultRules()); got != 25 {
+		t.Errorf("Expected 25 defender points with the default rules, got %d", got)
+	}
+	if got := score(doubled); got != 40 {
+		t.Errorf("Expected 40 defender points with a doubled kitty, got %d", got)
+	}
+}
+
+func TestGameRules_UndoLastBid(t *testing.T) {
+	rules := DefaultRules()
+	rules.AllowBidUndo = true
+
+	t.Run("Undo bid", func(t *testing.T) {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		if err := gs.PassBid("east"); err != nil {
+			t.Fatalf("PassBid() error = %v", err)
+		}
+		if err := gs.PlaceBid("south", 110); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+
+		if err := gs.UndoLastBid("south"); err != nil {
+			t.Fatalf("UndoLastBid() error = %v", err)
+		}
+		if gs.CurrentBid != 120 || gs.ConsecutivePasses != 1 || gs.CurrentPlayerTurn != South || len(gs.BidHistory) != 2 {
+			t.Errorf("Expected bid 120, 1 pass and South to act, got bid %d, %d passes and %s to act",
+				gs.CurrentBid, gs.ConsecutivePasses, gs.CurrentPlayerTurn.String())
+		}
+		if err := gs.PlaceBid("south", 115); err != nil {
+			t.Errorf("Expected South to bid again after undoing, got %v", err)
+		}
+	})
+
+	t.Run("Undo pass", func(t *testing.T) {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PassBid("north"); err != nil {
+			t.Fatalf("PassBid() error = %v", err)
+		}
+
+		if err := gs.UndoLastBid("north"); err != nil {
+			t.Fatalf("UndoLastBid() error = %v", err)
+		}
+		if gs.Players[North].HasPassed || gs.ConsecutivePasses != 0 || gs.CurrentBid != rules.StartingBid || gs.CurrentPlayerTurn != North {
+			t.Errorf("Expected North's pass to be taken back, got passed %v, %d passes, bid %d and %s to act",
+				gs.Players[North].HasPassed, gs.ConsecutivePasses, gs.CurrentBid, gs.CurrentPlayerTurn.String())
+		}
+	})
+
+	t.Run("Blocked once the next player acts", func(t *testing.T) {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		if err := gs.PassBid("east"); err != nil {
+			t.Fatalf("PassBid() error = %v", err)
+		}
+
+		if err := gs.UndoLastBid("north"); !errors.Is(err, ErrNotYourTurn) {
+			t.Errorf("UndoLastBid() after East acted error = %v, want ErrNotYourTurn", err)
+		}
+		if gs.CurrentBid != 120 || len(gs.BidHistory) != 2 {
+			t.Errorf("Expected bidding to be unchanged, got bid %d and %d entries", gs.CurrentBid, len(gs.BidHistory))
+		}
+	})
+
+	t.Run("Wrong player", func(t *testing.T) {
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+
+		if err := gs.UndoLastBid("east"); !errors.Is(err, ErrNotYourTurn) {
+			t.Errorf("UndoLastBid() by East error = %v, want ErrNotYourTurn", err)
+		}
+	})
+
+	t.Run("Not allowed by default", func(t *testing.T) {
+		gs := newTestGameStateWithRules(t, DefaultRules())
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+
+		if err := gs.UndoLastBid("north"); err == nil {
+			t.Error("Expected error when the rules do not allow undo")
+		}
+	})
+}
+
+func TestGameRules_PeekKittyBeforeTrump(t *testing.T) {
+	// kittyViews returns whether each player sees the kitty once North has
+	// won the bidding, while trump is declared and after it
+	kittyViews := func(t *testing.T, rules GameRules) (beforeTrump, afterTrump map[string]bool) {
+		t.Helper()
+		gs := newTestGameStateWithRules(t, rules)
+		if err := gs.PlaceBid("north", 120); err != nil {
+			t.Fatalf("PlaceBid() error = %v", err)
+		}
+		for _, playerID := range []string{"east", "south", "west"} {
+			if err := gs.PassBid(playerID); err != nil {
+				t.Fatalf("PassBid(%s) error = %v", playerID, err)
+			}
+		}
+
+		sees := func() map[string]bool {
+			seen := make(map[string]bool)
+			for _, player := range gs.Players {
+				view, err := gs.ViewFor(player.ID)
+				if err != nil {
+					t.Fatalf("ViewFor(%s) error = %v", player.ID, err)
+				}
+				seen[player.ID] = len(view.Kitty) == KittySize
+			}
+			return seen
+		}
+
+		beforeTrump = sees()
+		if err := gs.DeclareTrump("north", Hearts); err != nil {
+			t.Fatalf("DeclareTrump() error = %v", err)
+		}
+		return beforeTrump, sees()
+	}
+
+	t.Run("Default rules", func(t *testing.T) {
+		beforeTrump, afterTrump := kittyViews(t, DefaultRules())
+		for playerID, seen := range beforeTrump {
+			if seen {
+				t.Errorf("Expected %s not to see the kitty before trump is declared", playerID)
+			}
+		}
+		if !afterTrump["north"] {
+			t.Error("Expected the declarer to see the kitty during the exchange")
+		}
+	})
+
+	t.Run("Peek before trump", func(t *testing.T) {
+		rules := DefaultRules()
+		rules.PeekKittyBeforeTrump = true
+
+		beforeTrump, afterTrump := kittyViews(t, rules)
+		if !beforeTrump["north"] || !afterTrump["north"] {
+			t.Error("Expected the declarer to see the kitty before and after declaring trump")
+		}
+		for _, playerID := range []string{"east", "south", "west"} {
+			if beforeTrump[playerID] || afterTrump[playerID] {
+				t.Errorf("Expected %s never to see the kitty", playerID)
+			}
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package domain
+
+// Player roles reported on the scoreboard
+const (
+	RoleDeclarer = "declarer"
+	RolePartner  = "partner"
+	RoleDefender = "defender"
+)
+
+// PlayerScore summarizes how a single player did in a game
+type PlayerScore struct {
+	PlayerID       string         `json:"player_id"`
+	Name           string         `json:"name"`
+	Position       PlayerPosition `json:"position"`
+	Role           string         `json:"role"`
+	PointsCaptured int            `json:"points_captured"`
+}
+
+// KittyReveal shows the declarer's discards once the game has ended
+type KittyReveal struct {
+	Cards          []Card `json:"cards"`
+	Points         int    `json:"points"`
+	WonByDefenders bool   `json:"won_by_defenders"`
+	Multiplier     int    `json:"multiplier"`
+	AwardedPoints  int    `json:"awarded_points"` // Points added to the defenders' total
+}
+
+// Scoreboard summarizes the points captured by each player in a game
+type Scoreboard struct {
+	GameID          string        `json:"game_id"`
+	Phase           GamePhase     `json:"phase"`
+	Contract        int           `json:"contract"`
+	DefendersPoints int           `json:"defenders_points"`
+	PenaltyPoints   int           `json:"penalty_points,omitempty"` // Renege penalties moved to the defenders' total
+	WinnerTeam      *string       `json:"winner_team,omitempty"`
+	Margin          *int          `json:"margin,omitempty"` // Points by which the winning team beat or held the contract
+	Level           int           `json:"level,omitempty"`  // The winning team's level under the scoring tiers
+	Players         []PlayerScore `json:"players"`
+	Kitty           *KittyReveal  `json:"kitty,omitempty"` // Only revealed once the game has ended
+}
+
+// GetPlayerRole returns the role of the player at a position once a declarer is known
+func (gs *GameState) GetPlayerRole(position PlayerPosition) string {
+	if gs.Declarer == nil {
+		return ""
+	}
+
+	switch {
+	case position == *gs.Declarer:
+		return RoleDeclarer
+	case gs.IsOnDeclarerTeam(position):
+		return RolePartner
+	default:
+		return RoleDefender
+	}
+}
+
+// GetScoreboard returns the per-player captured points along with roles and the
+// winning team. Until the game has ended the defenders' points leave out the
+// kitty, so the scoreboard does not give away what it holds.
+func (gs *GameState) GetScoreboard() *Scoreboard {
+	captured := gs.GetCapturedPoints()
+
+	defendersPoints := gs.GetDefendersTrickPoints()
+	if gs.Phase == PhaseEnded {
+		defendersPoints = gs.GetDefendersPoints()
+	}
+
+	scoreboard := &Scoreboard{
+		GameID:          gs.ID,
+		Phase:           gs.Phase,
+		Contract:        gs.Contract,
+		DefendersPoints: defendersPoints,
+		PenaltyPoints:   gs.GetRenegePenaltyPoints(),
+		WinnerTeam:      gs.WinnerTeam,
+		Players:         make([]PlayerScore, 0, len(gs.Players)),
+	}
+
+	for _, player := range gs.Players {
+		scoreboard.Players = append(scoreboard.Players, PlayerScore{
+			PlayerID:       player.ID,
+			Name:           player.Name,
+			Position:       player.Position,
+			Role:           gs.GetPlayerRole(player.Position),
+			PointsCaptured: captured[player.ID],
+		})
+	}
+
+	if gs.Phase == PhaseEnded {
+		scoreboard.Kitty = gs.revealKitty()
+	}
+	if gs.WinnerTeam != nil {
+		result := gs.GetScoreResult()
+		scoreboard.Margin = &result.Margin
+		scoreboard.Level = result.Level
+	}
+
+	return scoreboard
+}
+
+// GetTrickHistory returns summaries of the completed tricks in the order they
+// were played. The trick in progress is left out.
+func (gs *GameState) GetTrickHistory() []map[string]interface{} {
+	history := make([]map[string]interface{}, 0, len(gs.Tricks))
+	for i := range gs.Tricks {
+		summary := gs.Tricks[i].GetTrickSummary()
+		summary["number"] = i + 1
+		history = append(history, summary)
+	}
+	return history
+}
+
+// revealKitty reports the kitty's cards and how many of its points went to the defenders
+func (gs *GameState) revealKitty() *KittyReveal {
+	reveal := &KittyReveal{
+		Cards:          append([]Card(nil), gs.Kitty...),
+		Points:         gs.GetKittyPoints(),
+		WonByDefenders: gs.defendersWinKitty(),
+		Multiplier:     gs.Rules.KittyMultiplier,
+	}
+	if reveal.WonByDefenders {
+		reveal.AwardedPoints = reveal.Points * reveal.Multiplier
+	}
+	return reveal
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "fmt"
+
+// ScoringTier raises the declarer team's level when the defenders finish
+// below a number of points
+type ScoringTier struct {
+	Below int `json:"below"` // The defenders' points must be below this
+	Level int `json:"level"`
+}
+
+// ScoreResult is the outcome of a contract: the winning team, how many points
+// they won by and the level, which multiplies the game's stakes
+type ScoreResult struct {
+	WinnerTeam      string `json:"winner_team"`
+	DefendersPoints int    `json:"defenders_points"`
+	Margin          int    `json:"margin"`
+	Level           int    `json:"level"`
+}
+
+// validateScoring checks that the declarer tiers are ordered by increasing
+// threshold and decreasing level, and that the defender step is not negative
+func (r GameRules) validateScoring() error {
+	for i, tier := range r.DeclarerTiers {
+		if tier.Below <= 0 || tier.Level < 1 {
+			return fmt.Errorf("scoring tier %d needs a positive threshold and a level of at least 1", i+1)
+		}
+		if i > 0 {
+			previous := r.DeclarerTiers[i-1]
+			if tier.Below <= previous.Below || tier.Level >= previous.Level {
+				return fmt.Errorf("scoring tiers must rise in threshold and fall in level")
+			}
+		}
+	}
+	if r.DefenderLevelStep < 0 {
+		return fmt.Errorf("defender level step cannot be negative")
+	}
+	return nil
+}
+
+// ScoreContract scores a contract the defenders took the given points
+// against. The declarer's team wins at the level of the first tier the
+// defenders stayed below, or level 1. The defenders win from the contract up,
+// gaining a level for every full DefenderLevelStep points beyond it.
+func (r GameRules) ScoreContract(contract, defendersPoints int) ScoreResult {
+	result := ScoreResult{DefendersPoints: defendersPoints, Level: 1}
+
+	if defendersPoints >= contract {
+		result.WinnerTeam = TeamDefenders
+		result.Margin = defendersPoints - contract
+		if r.DefenderLevelStep > 0 {
+			result.Level += result.Margin / r.DefenderLevelStep
+		}
+		return result
+	}
+
+	result.WinnerTeam = TeamDeclarer
+	result.Margin = contract - defendersPoints
+	for _, tier := range r.DeclarerTiers {
+		if defendersPoints < tier.Below {
+			result.Level = tier.Level
+			break
+		}
+	}
+	return result
+}
+
+// GetScoreResult scores the game's contract against the defenders' points,
+// including any renege penalties, or returns nil before a declarer is known
+func (gs *GameState) GetScoreResult() *ScoreResult {
+	if gs.Declarer == nil {
+		return nil
+	}
+	if gs.ConcededBy != nil {
+		return gs.concessionResult()
+	}
+	result := gs.Rules.ScoreContract(gs.Contract, gs.GetDefendersPoints()+gs.GetRenegePenaltyPoints())
+	return &result
+}
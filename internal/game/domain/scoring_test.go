@@ -0,0 +1,87 @@
+package domain
+
+import "testing"
+
+func TestGameRules_ScoreContract(t *testing.T) {
+	tests := []struct {
+		name            string
+		defendersPoints int
+		wantWinner      string
+		wantMargin      int
+		wantLevel       int
+	}{
+		{"Defenders take nothing", 0, TeamDeclarer, 100, 3},
+		{"Defenders take a trick", 5, TeamDeclarer, 95, 2},
+		{"Just below 40", 35, TeamDeclarer, 65, 2},
+		{"At 40", 40, TeamDeclarer, 60, 1},
+		{"Just below the contract", 95, TeamDeclarer, 5, 1},
+		{"At the contract", 100, TeamDefenders, 0, 1},
+		{"One step short beyond the contract", 135, TeamDefenders, 35, 1},
+		{"One step beyond the contract", 140, TeamDefenders, 40, 2},
+		{"Two steps beyond the contract", 180, TeamDefenders, 80, 3},
+	}
+
+	rules := DefaultRules()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rules.ScoreContract(100, tt.defendersPoints)
+			if result.WinnerTeam != tt.wantWinner {
+				t.Errorf("Expected %s to win, got %s", tt.wantWinner, result.WinnerTeam)
+			}
+			if result.Margin != tt.wantMargin {
+				t.Errorf("Expected a margin of %d, got %d", tt.wantMargin, result.Margin)
+			}
+			if result.Level != tt.wantLevel {
+				t.Errorf("Expected level %d, got %d", tt.wantLevel, result.Level)
+			}
+		})
+	}
+}
+
+func TestGameRules_ScoreContractCustomTiers(t *testing.T) {
+	rules := DefaultRules()
+	rules.DeclarerTiers = []ScoringTier{{Below: 20, Level: 4}, {Below: 60, Level: 2}}
+	rules.DefenderLevelStep = 0
+
+	if level := rules.ScoreContract(100, 15).Level; level != 4 {
+		t.Errorf("Expected level 4 below 20 points, got %d", level)
+	}
+	if level := rules.ScoreContract(100, 55).Level; level != 2 {
+		t.Errorf("Expected level 2 below 60 points, got %d", level)
+	}
+	if level := rules.ScoreContract(100, 200).Level; level != 1 {
+		t.Errorf("Expected defenders to stay at level 1 without a level step, got %d", level)
+	}
+
+	// A tier above the contract does not turn a defenders' win around
+	if result := rules.ScoreContract(50, 55); result.WinnerTeam != TeamDefenders {
+		t.Errorf("Expected defenders to win at the contract, got %s", result.WinnerTeam)
+	}
+}
+
+func TestGameState_GetScoreboardReportsLevel(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+	declarer := North
+	gs.Declarer = &declarer
+	gs.Contract = 80
+	gs.Kitty = nil
+
+	// South, the declarer's partner, takes every point
+	playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ace, 1),
+		West:  NewCard(Hearts, Ten, 1),
+	})
+	gs.CalculateFinalScore()
+
+	scoreboard := gs.GetScoreboard()
+	if scoreboard.WinnerTeam == nil || *scoreboard.WinnerTeam != TeamDeclarer {
+		t.Fatalf("Expected the declarer team to win, got %v", scoreboard.WinnerTeam)
+	}
+	if scoreboard.Level != 3 {
+		t.Errorf("Expected level 3 when the defenders take nothing, got %d", scoreboard.Level)
+	}
+}
@@ -81,8 +81,8 @@ func (t *Trick) validatePlay(position PlayerPosition, formation *Formation, trum
 		return fmt.Errorf("leader formation not found")
 	}
 
-	// Must match formation type
-	if formation.Type != leaderFormation.Type {
+	// Must match formation type, or play a mixed formation of as many cards
+	if formation.Type != leaderFormation.Type && formation.Type != Mixed {
 		return fmt.Errorf("must match led formation type %s", leaderFormation.Type.String())
 	}
 
@@ -111,19 +111,17 @@ func (t *Trick) completeTrick(trumpSuit Suit) {
 	winningPosition := t.Leader
 	winningFormation := leaderFormation
 
-	// Compare all plays to find the winner
-	currentPos := t.Leader.GetNextPosition()
-	for i := 0; i < 3; i++ {
-		currentFormation := t.Plays[currentPos]
-		if currentFormation != nil {
-			// Compare formations
-			comparison := currentFormation.Compare(winningFormation, trumpSuit, *t.LedSuit)
-			if comparison > 0 {
-				winningPosition = currentPos
-				winningFormation = currentFormation
-			}
+	// Compare the plays in trick order. A later play must strictly beat the
+	// winner so that, when formations tie, the earliest play takes the trick.
+	for _, position := range t.GetPlayOrder()[1:] {
+		currentFormation := t.Plays[position]
+		if currentFormation == nil {
+			continue
+		}
+		if currentFormation.Compare(winningFormation, trumpSuit, *t.LedSuit) > 0 {
+			winningPosition = position
+			winningFormation = currentFormation
 		}
-		currentPos = currentPos.GetNextPosition()
 	}
 
 	// Calculate total points in the trick
@@ -201,7 +199,36 @@ func (t *Trick) CanPlayerPlay(position PlayerPosition) bool {
 	return nextToPlay != nil && *nextToPlay == position
 }
 
-// GetTrickSummary returns a summary of the trick
+// GetPointCards returns the Fives, Tens and Kings played to the trick, in
+// play order
+func (t *Trick) GetPointCards() []Card {
+	var cards []Card
+	for _, position := range t.GetPlayOrder() {
+		formation := t.Plays[position]
+		if formation == nil {
+			continue
+		}
+		for _, card := range formation.Cards {
+			if card.GetPointValue() > 0 {
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards
+}
+
+// GetPointsBySuit totals the points played to the trick by the suit of the
+// cards that carry them
+func (t *Trick) GetPointsBySuit() map[Suit]int {
+	points := make(map[Suit]int)
+	for _, card := range t.GetPointCards() {
+		points[card.Suit] += card.GetPointValue()
+	}
+	return points
+}
+
+// GetTrickSummary returns a summary of the trick, including the cards each
+// player has played to it and the point cards among them
 func (t *Trick) GetTrickSummary() map[string]interface{} {
 	summary := map[string]interface{}{
 		"id":           t.ID,
@@ -234,12 +261,20 @@ func (t *Trick) GetTrickSummary() map[string]interface{} {
 		plays[position.String()] = map[string]interface{}{
 			"type":        formation.Type.String(),
 			"suit":        formation.Suit.String(),
+			"cards":       formation.Cards,
 			"cards_count": len(formation.Cards),
 			"points":      formation.GetPointValue(),
 		}
 	}
 	summary["plays"] = plays
 
+	pointsBySuit := make(map[string]int)
+	for suit, points := range t.GetPointsBySuit() {
+		pointsBySuit[suit.String()] = points
+	}
+	summary["point_cards"] = t.GetPointCards()
+	summary["points_by_suit"] = pointsBySuit
+
 	return summary
 }
 
@@ -265,7 +300,7 @@ func (t *Trick) ValidateFormationAgainstTrick(position PlayerPosition, formation
 	}
 
 	if !t.CanPlayerPlay(position) {
-		return fmt.Errorf("not player's turn to play")
+		return ErrNotYourTurn
 	}
 
 	// Validate formation itself
@@ -273,27 +308,50 @@ func (t *Trick) ValidateFormationAgainstTrick(position PlayerPosition, formation
 		return fmt.Errorf("invalid formation: %w", err)
 	}
 
-	// Validate player has all cards in the formation
+	// Validate player has all cards in the formation, each held card
+	// covering only one card of the formation
+	used := make([]bool, len(playerHand))
 	for _, card := range formation.Cards {
 		hasCard := false
-		for _, handCard := range playerHand {
-			if handCard.IsEqual(card) {
+		for i, handCard := range playerHand {
+			if !used[i] && handCard.IsEqual(card) {
+				used[i] = true
 				hasCard = true
 				break
 			}
 		}
 		if !hasCard {
-			return fmt.Errorf("player does not have card: %s", card.String())
+			return fmt.Errorf("%w: %s", ErrCardNotHeld, card.String())
 		}
 	}
 
-	// If this is the first play, any valid formation is allowed
+	// If this is the first play, any single, pair or tractor is allowed
 	if len(t.Plays) == 0 {
+		if formation.Type == Mixed {
+			return fmt.Errorf("the leader must play a single, pair or tractor")
+		}
 		return nil
 	}
 
 	// Validate against suit-following rules
-	return t.validatePlay(position, formation, trumpSuit)
+	if err := t.validatePlay(position, formation, trumpSuit); err != nil {
+		return err
+	}
+	return t.validateFollow(formation)
+}
+
+// validateFollow checks a follower plays as many cards as were led. A mixed
+// formation may follow any lead of more than one card. Which cards of the led
+// suit the follower must include depends on the rules, so the game checks it.
+func (t *Trick) validateFollow(formation *Formation) error {
+	led := t.Plays[t.Leader]
+	if len(formation.Cards) != len(led.Cards) {
+		return fmt.Errorf("must play %d cards to follow the led %s", len(led.Cards), led.Type.String())
+	}
+	if formation.Type == Mixed && led.Type == Single {
+		return fmt.Errorf("must match led formation type %s", led.Type.String())
+	}
+	return nil
 }
 
 // GetRemainingPositions returns positions that haven't played yet
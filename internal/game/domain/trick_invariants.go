@@ -0,0 +1,85 @@
+package domain
+
+import "fmt"
+
+// ValidateTrickInvariants checks that the trick in progress is consistent:
+// the plays were made in turn order from the leader with no card played
+// twice, the led suit matches the leader's play and it is the turn of the
+// next player to play. A game without a trick in progress is consistent.
+func (gs *GameState) ValidateTrickInvariants() error {
+	trick := gs.CurrentTrick
+	if trick == nil {
+		return nil
+	}
+	if trick.IsComplete {
+		return fmt.Errorf("%w: completed trick %s was not moved to the finished tricks", ErrCorruptTrick, trick.ID)
+	}
+	if len(trick.Plays) >= 4 {
+		return fmt.Errorf("%w: trick %s has %d plays but is not complete", ErrCorruptTrick, trick.ID, len(trick.Plays))
+	}
+
+	// Nobody may have played after the first player in turn order who has not
+	order := trick.GetPlayOrder()
+	waiting := false
+	for _, position := range order {
+		formation, played := trick.Plays[position]
+		if !played {
+			waiting = true
+			continue
+		}
+		if waiting {
+			return fmt.Errorf("%w: %s has played out of turn in trick %s", ErrCorruptTrick, position, trick.ID)
+		}
+		if formation == nil || len(formation.Cards) == 0 {
+			return fmt.Errorf("%w: %s has an empty play in trick %s", ErrCorruptTrick, position, trick.ID)
+		}
+	}
+
+	seen := make(map[Card]PlayerPosition)
+	for _, position := range order[:len(trick.Plays)] {
+		for _, card := range trick.Plays[position].Cards {
+			if other, ok := seen[card]; ok {
+				return fmt.Errorf("%w: %s was played by both %s and %s", ErrCorruptTrick, card, other, position)
+			}
+			seen[card] = position
+		}
+	}
+
+	if err := gs.validateLedSuit(trick); err != nil {
+		return err
+	}
+
+	if next := order[len(trick.Plays)]; gs.CurrentPlayerTurn != next {
+		return fmt.Errorf("%w: it is %s's turn but %s plays next in trick %s",
+			ErrCorruptTrick, gs.CurrentPlayerTurn, next, trick.ID)
+	}
+	return nil
+}
+
+// validateLedSuit checks that the trick's led suit is set from the leader's
+// play in the same way AddPlay sets it, and is unset before anyone plays
+func (gs *GameState) validateLedSuit(trick *Trick) error {
+	led, ok := trick.Plays[trick.Leader]
+	if !ok {
+		if trick.LedSuit != nil {
+			return fmt.Errorf("%w: trick %s has a led suit before anyone played", ErrCorruptTrick, trick.ID)
+		}
+		return nil
+	}
+	if trick.LedSuit == nil {
+		return fmt.Errorf("%w: trick %s has no led suit", ErrCorruptTrick, trick.ID)
+	}
+	if gs.TrumpSuit == nil {
+		return nil
+	}
+
+	want := led.Suit
+	if led.IsTrump(*gs.TrumpSuit) {
+		want = *gs.TrumpSuit
+	}
+	if *trick.LedSuit != want {
+		return fmt.Errorf("%w: trick %s has led suit %s but %s led %s",
+			ErrCorruptTrick, trick.ID, *trick.LedSuit, trick.Leader, want)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newTrickInProgress has North lead the Five of Clubs and East follow with the
+// Three of Clubs, leaving South to play
+func newTrickInProgress(t *testing.T) *GameState {
+	t.Helper()
+
+	gs := newPlayingGameState(t)
+	gs.Players[North].Hand = []Card{NewCard(Clubs, Five, 1), NewCard(Clubs, Six, 1)}
+	gs.Players[East].Hand = []Card{NewCard(Clubs, Three, 1), NewCard(Diamonds, Four, 1)}
+	if _, err := gs.PlayCards("north", NewSingle(NewCard(Clubs, Five, 1))); err != nil {
+		t.Fatalf("PlayCards(north) error = %v", err)
+	}
+	if _, err := gs.PlayCards("east", NewSingle(NewCard(Clubs, Three, 1))); err != nil {
+		t.Fatalf("PlayCards(east) error = %v", err)
+	}
+	return gs
+}
+
+func TestGameState_ValidateTrickInvariants(t *testing.T) {
+	t.Run("No trick in progress", func(t *testing.T) {
+		gs := newPlayingGameState(t)
+		if err := gs.ValidateTrickInvariants(); err != nil {
+			t.Errorf("ValidateTrickInvariants() error = %v", err)
+		}
+	})
+
+	t.Run("Well-formed trick", func(t *testing.T) {
+		gs := newTrickInProgress(t)
+		if err := gs.ValidateTrickInvariants(); err != nil {
+			t.Errorf("ValidateTrickInvariants() error = %v", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		corrupt func(gs *GameState)
+		wantMsg string
+	}{
+		{
+			name: "Duplicate play",
+			corrupt: func(gs *GameState) {
+				gs.CurrentTrick.Plays[East] = NewSingle(NewCard(Clubs, Five, 1))
+			},
+			wantMsg: "5 of Clubs (Deck 1) was played by both North and East",
+		},
+		{
+			name: "Missing led suit",
+			corrupt: func(gs *GameState) {
+				gs.CurrentTrick.LedSuit = nil
+			},
+			wantMsg: "has no led suit",
+		},
+		{
+			name: "Wrong led suit",
+			corrupt: func(gs *GameState) {
+				diamonds := Diamonds
+				gs.CurrentTrick.LedSuit = &diamonds
+			},
+			wantMsg: "has led suit Diamonds but North led Clubs",
+		},
+		{
+			name: "Play out of turn",
+			corrupt: func(gs *GameState) {
+				gs.CurrentTrick.Plays[West] = NewSingle(NewCard(Clubs, Two, 1))
+			},
+			wantMsg: "West has played out of turn",
+		},
+		{
+			name: "Turn pointer behind the trick",
+			corrupt: func(gs *GameState) {
+				gs.CurrentPlayerTurn = East
+			},
+			wantMsg: "it is East's turn but South plays next",
+		},
+		{
+			name: "Four plays without completing",
+			corrupt: func(gs *GameState) {
+				gs.CurrentTrick.Plays[South] = NewSingle(NewCard(Clubs, Seven, 1))
+				gs.CurrentTrick.Plays[West] = NewSingle(NewCard(Clubs, Eight, 1))
+			},
+			wantMsg: "has 4 plays but is not complete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newTrickInProgress(t)
+			tt.corrupt(gs)
+
+			err := gs.ValidateTrickInvariants()
+			if !errors.Is(err, ErrCorruptTrick) {
+				t.Fatalf("ValidateTrickInvariants() error = %v, want ErrCorruptTrick", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("ValidateTrickInvariants() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestGameState_PlayCardsRejectsCorruptTrick(t *testing.T) {
+	gs := newTrickInProgress(t)
+	gs.CurrentTrick.LedSuit = nil
+	gs.Players[South].Hand = []Card{NewCard(Clubs, Seven, 1)}
+
+	_, err := gs.PlayCards("south", NewSingle(NewCard(Clubs, Seven, 1)))
+	if !errors.Is(err, ErrCorruptTrick) {
+		t.Fatalf("PlayCards() error = %v, want ErrCorruptTrick", err)
+	}
+	if len(gs.Players[South].Hand) != 1 {
+		t.Error("Expected the rejected play to leave South's hand untouched")
+	}
+}
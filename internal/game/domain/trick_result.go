@@ -0,0 +1,25 @@
+package domain
+
+// TrickResult reports a completed trick: who won it and the points it carried
+type TrickResult struct {
+	TrickNumber int            `json:"trick_number"` // Counting from 1
+	Winner      PlayerPosition `json:"winner"`
+	WinnerID    string         `json:"winner_id"`
+	Points      int            `json:"points"`
+}
+
+// GetTrickResult returns the result of the completed trick with the given
+// number, counting from 1, or nil if that trick has not been completed
+func (gs *GameState) GetTrickResult(number int) *TrickResult {
+	if number < 1 || number > len(gs.Tricks) {
+		return nil
+	}
+
+	trick := gs.Tricks[number-1]
+	result := &TrickResult{TrickNumber: number, Points: trick.Points}
+	if winner := gs.GetTrickWinner(trick); winner != nil {
+		result.Winner = winner.Position
+		result.WinnerID = winner.ID
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestGameState_PlayCardsReturnsTrickResult(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	plays := []struct {
+		playerID string
+		card     Card
+	}{
+		{"north", NewCard(Spades, Ten, 1)},
+		{"east", NewCard(Clubs, Three, 1)},
+		{"south", NewCard(Spades, Ten, 2)},
+		{"west", NewCard(Hearts, Queen, 2)},
+	}
+	for i, play := range plays {
+		result, err := gs.PlayCards(play.playerID, NewSingle(play.card))
+		if err != nil {
+			t.Fatalf("PlayCards(%s) error = %v", play.playerID, err)
+		}
+		if i < len(plays)-1 {
+			if result != nil {
+				t.Errorf("Expected no result before the trick is complete, got %+v", result)
+			}
+			continue
+		}
+
+		if result == nil {
+			t.Fatal("Expected a result for the completed trick")
+		}
+		trick := gs.Tricks[0]
+		if result.TrickNumber != 1 || result.Winner.String() != trick.Winner || result.WinnerID != "west" {
+			t.Errorf("Result %+v does not match the trick won by %s", result, trick.Winner)
+		}
+		if result.Points != trick.Points || result.Points != 20 {
+			t.Errorf("Expected the trick's 20 points, got %d", result.Points)
+		}
+	}
+
+	if result := gs.GetTrickResult(2); result != nil {
+		t.Errorf("Expected no result for an unplayed trick, got %+v", result)
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// mustPair builds a pair of both decks' copies of a card
+func mustPair(t *testing.T, suit Suit, rank Rank) *Formation {
+	t.Helper()
+
+	pair, err := NewPair(NewCard(suit, rank, 1), NewCard(suit, rank, 2))
+	if err != nil {
+		t.Fatalf("NewPair() error = %v", err)
+	}
+	return pair
+}
+
+func TestTrick_TiedFormationsGoToEarliestPlay(t *testing.T) {
+	tests := []struct {
+		name   string
+		leader PlayerPosition
+		plays  map[PlayerPosition]*Formation
+		want   PlayerPosition
+	}{
+		{
+			name:   "Tie after the leader",
+			leader: North,
+			plays: map[PlayerPosition]*Formation{
+				North: mustPair(t, Clubs, Five),
+				East:  mustPair(t, Spades, Two),
+				South: mustPair(t, Diamonds, Two),
+				West:  mustPair(t, Clubs, Three),
+			},
+			want: East,
+		},
+		{
+			name:   "Tie across the turn order wrapping to North",
+			leader: West,
+			plays: map[PlayerPosition]*Formation{
+				West:  mustPair(t, Clubs, Five),
+				North: mustPair(t, Diamonds, Two),
+				East:  mustPair(t, Spades, Two),
+				South: mustPair(t, Clubs, Three),
+			},
+			want: North,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trick := NewTrick("trick-1", tt.leader)
+			for _, position := range trick.GetPlayOrder() {
+				if err := trick.AddPlay(position, tt.plays[position], Hearts); err != nil {
+					t.Fatalf("AddPlay(%s) error = %v", position.String(), err)
+				}
+			}
+
+			// Off-suit Twos are equal trumps, so the two pairs of Twos tie
+			first, second := tt.plays[tt.want], tt.plays[tt.want.GetNextPosition()]
+			if first.Compare(second, Hearts, Clubs) != 0 {
+				t.Fatalf("Expected %s and %s to tie", first, second)
+			}
+			if trick.Winner != tt.want.String() {
+				t.Errorf("Expected %s to win the trick, got %s", tt.want.String(), trick.Winner)
+			}
+		})
+	}
+}
+
+func TestTrick_OffSuitCardCannotWin(t *testing.T) {
+	trick := NewTrick("trick-1", North)
+	plays := map[PlayerPosition]*Formation{
+		North: NewSingle(NewCard(Clubs, King, 1)),
+		East:  NewSingle(NewCard(Spades, Ace, 1)),
+		South: NewSingle(NewCard(Clubs, Four, 1)),
+		West:  NewSingle(NewCard(Diamonds, Ace, 1)),
+	}
+	for _, position := range trick.GetPlayOrder() {
+		if err := trick.AddPlay(position, plays[position], Hearts); err != nil {
+			t.Fatalf("AddPlay(%s) error = %v", position.String(), err)
+		}
+	}
+
+	if trick.Winner != North.String() {
+		t.Errorf("Expected the led King of Clubs to win, got %s", trick.Winner)
+	}
+}
+
+func TestTrick_ValidateFormationRejectsSameCardTwice(t *testing.T) {
+	kingOfHearts := NewCard(Hearts, King, 1)
+	hand := []Card{kingOfHearts, NewCard(Hearts, Queen, 1)}
+	pair := &Formation{Type: Pair, Cards: []Card{kingOfHearts, kingOfHearts}, Suit: Hearts}
+
+	trick := NewTrick("trick-1", North)
+	if err := trick.ValidateFormationAgainstTrick(North, pair, hand, Spades); err == nil {
+		t.Error("Expected error for a pair of one physical King of Hearts played twice")
+	}
+
+	// With both copies in hand the pair is playable
+	hand = append(hand, NewCard(Hearts, King, 2))
+	pair = mustPair(t, Hearts, King)
+	if err := trick.ValidateFormationAgainstTrick(North, pair, hand, Spades); err != nil {
+		t.Errorf("ValidateFormationAgainstTrick() error = %v", err)
+	}
+}
+
+func TestTrick_PointBreakdown(t *testing.T) {
+	trick := NewTrick("trick-1", North)
+	plays := map[PlayerPosition]Card{
+		North: NewCard(Clubs, King, 1),
+		East:  NewCard(Clubs, Five, 1),
+		South: NewCard(Diamonds, Five, 1),
+		West:  NewCard(Clubs, Three, 1),
+	}
+	for _, position := range trick.GetPlayOrder() {
+		if err := trick.AddPlay(position, NewSingle(plays[position]), Hearts); err != nil {
+			t.Fatalf("AddPlay(%s) error = %v", position.String(), err)
+		}
+	}
+
+	cards := trick.GetPointCards()
+	want := []Card{plays[North], plays[East], plays[South]}
+	if !reflect.DeepEqual(cards, want) {
+		t.Errorf("Expected point cards %v, got %v", want, cards)
+	}
+
+	bySuit := trick.GetPointsBySuit()
+	if bySuit[Clubs] != 15 || bySuit[Diamonds] != 5 || len(bySuit) != 2 {
+		t.Errorf("Expected 15 points in Clubs and 5 in Diamonds, got %v", bySuit)
+	}
+	total := 0
+	for _, points := range bySuit {
+		total += points
+	}
+	if total != 20 || total != trick.Points {
+		t.Errorf("Expected the breakdown to total the trick's 20 points, got %d of %d", total, trick.Points)
+	}
+
+	summary := trick.GetTrickSummary()
+	if summary["points_by_suit"].(map[string]int)["Clubs"] != 15 {
+		t.Errorf("Expected the summary to break down 15 points in Clubs, got %v", summary["points_by_suit"])
+	}
+}
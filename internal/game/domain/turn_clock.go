@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// now is the clock used for turn deadlines, replaced in tests to simulate time passing
+var now = time.Now
+
+// refreshTurnDeadline starts the clock for the player whose turn it now is.
+// Phases without a time limit, and games that are not in progress, clear it.
+func (gs *GameState) refreshTurnDeadline() {
+	limit := gs.Rules.TurnTimeLimit(gs.Phase)
+	if limit <= 0 {
+		gs.TurnDeadline = nil
+		return
+	}
+
+	deadline := now().Add(limit)
+	gs.TurnDeadline = &deadline
+}
+
+// TimeRemaining returns how long the current player has left to act at the
+// given time, and false if the turn is not timed
+func (gs *GameState) TimeRemaining(at time.Time) (time.Duration, bool) {
+	if gs.TurnDeadline == nil {
+		return 0, false
+	}
+
+	remaining := gs.TurnDeadline.Sub(at)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// SecondsRemaining returns the whole seconds the current player has left to
+// act at the given time, rounded up so a client never shows zero early
+func (gs *GameState) SecondsRemaining(at time.Time) (int, bool) {
+	remaining, ok := gs.TimeRemaining(at)
+	if !ok {
+		return 0, false
+	}
+	return int(math.Ceil(remaining.Seconds())), true
+}
+
+// IsTurnExpired checks if the current player ran out of time to act at the given time
+func (gs *GameState) IsTurnExpired(at time.Time) bool {
+	return gs.TurnDeadline != nil && !at.Before(*gs.TurnDeadline)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// setClock makes the turn clock read the given time for the rest of the test
+func setClock(t *testing.T, at time.Time) {
+	t.Helper()
+	previous := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = previous })
+}
+
+func TestGameState_TurnDeadlineRefreshes(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, start)
+	gs := newTestGameStateWithRules(t, DefaultRules())
+
+	limit := 30 * time.Second
+	if gs.TurnDeadline == nil || !gs.TurnDeadline.Equal(start.Add(limit)) {
+		t.Fatalf("Expected bidding to start with a deadline of %v, got %v", start.Add(limit), gs.TurnDeadline)
+	}
+
+	// NextTurn restarts the clock for the next player
+	later := start.Add(10 * time.Second)
+	setClock(t, later)
+	gs.NextTurn()
+	if !gs.TurnDeadline.Equal(later.Add(limit)) {
+		t.Errorf("Expected NextTurn to move the deadline to %v, got %v", later.Add(limit), gs.TurnDeadline)
+	}
+
+	// So does a bid
+	later = later.Add(5 * time.Second)
+	setClock(t, later)
+	if err := gs.PlaceBid("east", 120); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	if !gs.TurnDeadline.Equal(later.Add(limit)) {
+		t.Errorf("Expected PlaceBid to move the deadline to %v, got %v", later.Add(limit), gs.TurnDeadline)
+	}
+}
+
+func TestGameState_SecondsRemaining(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, start)
+	gs := newTestGameStateWithRules(t, DefaultRules())
+
+	steps := []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 30},
+		{500 * time.Millisecond, 30},
+		{10 * time.Second, 20},
+		{29 * time.Second, 1},
+		{30 * time.Second, 0},
+		{45 * time.Second, 0},
+	}
+	for _, step := range steps {
+		seconds, ok := gs.SecondsRemaining(start.Add(step.elapsed))
+		if !ok || seconds != step.want {
+			t.Errorf("SecondsRemaining() after %v = %d, %v, want %d", step.elapsed, seconds, ok, step.want)
+		}
+		if expired := gs.IsTurnExpired(start.Add(step.elapsed)); expired != (step.elapsed >= 30*time.Second) {
+			t.Errorf("IsTurnExpired() after %v = %v", step.elapsed, expired)
+		}
+	}
+
+	// The view reports the time left according to the clock when it is built
+	setClock(t, start.Add(12*time.Second))
+	view, err := gs.ViewFor("north")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if view.TurnDeadline == nil || view.SecondsRemaining == nil || *view.SecondsRemaining != 18 {
+		t.Errorf("Expected the view to have a deadline and 18 seconds remaining, got %v and %v", view.TurnDeadline, view.SecondsRemaining)
+	}
+}
+
+func TestGameState_UntimedTurns(t *testing.T) {
+	rules := DefaultRules()
+	rules.BidTimeLimit = 0
+	gs := newTestGameStateWithRules(t, rules)
+
+	if gs.TurnDeadline != nil {
+		t.Errorf("Expected no deadline when bidding is untimed, got %v", gs.TurnDeadline)
+	}
+	if _, ok := gs.SecondsRemaining(time.Now()); ok {
+		t.Error("Expected no time remaining to be reported for an untimed turn")
+	}
+	if gs.IsTurnExpired(time.Now().Add(time.Hour)) {
+		t.Error("Expected an untimed turn never to expire")
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+// UnseenCards returns the cards the player has not seen: the full deck minus
+// their hand, every card played in completed tricks and the current one, and
+// the kitty once they are allowed to see it. It returns nil for a player who
+// is not in the game.
+func (gs *GameState) UnseenCards(playerID string) []Card {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return nil
+	}
+
+	// Cards are keyed with their deck, so the two copies of a face are told apart
+	seen := make(map[Card]bool, DeckSize)
+	markSeen := func(cards []Card) {
+		for _, card := range cards {
+			seen[card] = true
+		}
+	}
+	markPlayed := func(trick *Trick) {
+		for _, formation := range trick.Plays {
+			if formation != nil {
+				markSeen(formation.Cards)
+			}
+		}
+	}
+
+	markSeen(player.Hand)
+	for i := range gs.Tricks {
+		markPlayed(&gs.Tricks[i])
+	}
+	if gs.CurrentTrick != nil {
+		markPlayed(gs.CurrentTrick)
+	}
+	if gs.canSeeKitty(player) {
+		markSeen(gs.Kitty)
+	}
+
+	unseen := make([]Card, 0, DeckSize)
+	for _, card := range NewDeck().Cards {
+		if !seen[card] {
+			unseen = append(unseen, card)
+		}
+	}
+	return unseen
+}
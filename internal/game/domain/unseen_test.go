@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func TestGameState_UnseenCardsAtStartOfPlay(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	// A defender has only seen their own hand
+	unseen := gs.UnseenCards("east")
+	if len(unseen) != DeckSize-HandSize {
+		t.Errorf("Expected %d unseen cards for a defender, got %d", DeckSize-HandSize, len(unseen))
+	}
+	for _, card := range gs.Players[East].Hand {
+		if containsCard(unseen, card) {
+			t.Errorf("Expected East's own %s to be seen", card)
+		}
+	}
+	if !containsCard(unseen, gs.Kitty[0]) {
+		t.Error("Expected the kitty to be unseen by a defender")
+	}
+
+	// The declarer also knows what they discarded into the kitty
+	if unseen := gs.UnseenCards("north"); len(unseen) != DeckSize-HandSize-KittySize {
+		t.Errorf("Expected %d unseen cards for the declarer, got %d", DeckSize-HandSize-KittySize, len(unseen))
+	}
+
+	if unseen := gs.UnseenCards("nobody"); unseen != nil {
+		t.Errorf("Expected no unseen cards for a non-participant, got %d", len(unseen))
+	}
+}
+
+func TestGameState_UnseenCardsAfterTricks(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	playFirstCards(t, gs, North)
+	playFirstCards(t, gs, North)
+	played := gs.Players[South].Hand[0]
+	gs.CurrentTrick = NewTrick("game-1_trick_3", South)
+	if err := gs.CurrentTrick.AddPlay(South, NewSingle(played), *gs.TrumpSuit); err != nil {
+		t.Fatalf("AddPlay() error = %v", err)
+	}
+	if err := gs.Players[South].RemoveCard(played); err != nil {
+		t.Fatalf("RemoveCard() error = %v", err)
+	}
+
+	// Eight cards from the completed tricks and one from the current trick
+	// are seen on top of East's remaining hand
+	unseen := gs.UnseenCards("east")
+	want := DeckSize - gs.Players[East].GetHandSize() - 9
+	if len(unseen) != want {
+		t.Errorf("Expected %d unseen cards, got %d", want, len(unseen))
+	}
+	if containsCard(unseen, played) {
+		t.Errorf("Expected %s in the current trick to be seen", played)
+	}
+	for _, trick := range gs.Tricks {
+		for position, formation := range trick.Plays {
+			if containsCard(unseen, formation.Cards[0]) {
+				t.Errorf("Expected %s played by %s to be seen", formation.Cards[0], position)
+			}
+		}
+	}
+}
+
+func containsCard(cards []Card, card Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
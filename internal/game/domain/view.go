@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Team labels, matching the winner_team values
+const (
+	TeamDeclarer  = "declarer"
+	TeamDefenders = "defenders"
+)
+
+// PlayerPublic is what anyone at the table may know about a player's seat and
+// hand: how many cards they hold, but never which
+type PlayerPublic struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Position  PlayerPosition `json:"position"`
+	HandSize  int            `json:"hand_size"`
+	HasPassed bool           `json:"has_passed"`
+}
+
+// PublicView returns the player's public information, without their cards
+func (p *Player) PublicView() PlayerPublic {
+	return PlayerPublic{
+		ID:        p.ID,
+		Name:      p.Name,
+		Position:  p.Position,
+		HandSize:  p.GetHandSize(),
+		HasPassed: p.HasPassed,
+	}
+}
+
+// PlayerView is the public information about a player at the table
+type PlayerView struct {
+	PlayerPublic
+	Disconnected bool           `json:"disconnected"`
+	IsBot        bool           `json:"is_bot"`
+	Status       ConnectionStatus `json:"status"`
+	ReconnectDeadline *time.Time `json:"reconnect_deadline,omitempty"` // When a reconnecting player's held seat is released
+	Team         string         `json:"team,omitempty"` // Set once the declarer is known
+}
+
+// GameView is the game state as seen by a single player, with the other
+// players' hands and any hidden kitty cards removed
+type GameView struct {
+	ID                string          `json:"id"`
+	RoomID            string          `json:"room_id"`
+	Phase             GamePhase       `json:"phase"`
+	Position          PlayerPosition  `json:"position"`
+	Partner           *PlayerPosition `json:"partner,omitempty"` // The viewer's partner, once known
+	CalledCard        *Card           `json:"called_card,omitempty"`
+	CalledPartner     *PlayerPosition `json:"called_partner,omitempty"`
+	Hand              []Card          `json:"hand"`
+	DealOrder         []int           `json:"deal_order,omitempty"` // Position each card was dealt to, for animating the deal
+	Players           []PlayerView    `json:"players"`
+	CurrentPlayerTurn PlayerPosition  `json:"current_player_turn"`
+	Declarer          *PlayerPosition `json:"declarer,omitempty"`
+	TrumpSuit         *Suit           `json:"trump_suit,omitempty"`
+	Contract          int             `json:"contract"`
+	DefendersPointsSoFar  int         `json:"defenders_points_so_far"`  // Captured in completed tricks, without the kitty
+	PointsRemainingInPlay int         `json:"points_remaining_in_play"` // Not yet captured, outside the kitty
+	CurrentBid        int             `json:"current_bid"`
+	BidHistory        []BidInfo       `json:"bid_history"`
+	CurrentTrick      *Trick          `json:"current_trick,omitempty"`
+	Tricks            []Trick         `json:"tricks"`
+	Kitty             []Card          `json:"kitty,omitempty"`
+	Scores            map[string]int  `json:"scores"`
+	WinnerTeam        *string         `json:"winner_team,omitempty"`
+	Concessions       []PlayerPosition `json:"concessions,omitempty"` // Players who have agreed to concede for their team
+	ConcededBy        *string         `json:"conceded_by,omitempty"`
+	ObservedVoids     map[PlayerPosition][]Suit `json:"observed_voids,omitempty"`
+	TurnDeadline      *time.Time      `json:"turn_deadline,omitempty"`
+	SecondsRemaining  *int            `json:"seconds_remaining,omitempty"` // Time left to act when the view was built
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// ViewFor returns the game state redacted for the given player
+func (gs *GameState) ViewFor(playerID string) (*GameView, error) {
+	viewer := gs.GetPlayer(playerID)
+	if viewer == nil {
+		return nil, fmt.Errorf("player %s is not in this game", playerID)
+	}
+
+	view := &GameView{
+		ID:                gs.ID,
+		RoomID:            gs.RoomID,
+		Phase:             gs.Phase,
+		Position:          viewer.Position,
+		Partner:           gs.partnerOf(viewer.Position),
+		CalledCard:        gs.CalledCard,
+		CalledPartner:     gs.CalledPartner,
+		Hand:              append([]Card(nil), viewer.Hand...),
+		DealOrder:         gs.DealOrder,
+		Players:           make([]PlayerView, 0, len(gs.Players)),
+		CurrentPlayerTurn: gs.CurrentPlayerTurn,
+		Declarer:          gs.Declarer,
+		TrumpSuit:         gs.TrumpSuit,
+		Contract:          gs.Contract,
+		DefendersPointsSoFar:  gs.GetDefendersTrickPoints(),
+		PointsRemainingInPlay: gs.GetPointsInPlay(),
+		CurrentBid:        gs.CurrentBid,
+		BidHistory:        gs.BidHistory,
+		CurrentTrick:      gs.CurrentTrick,
+		Tricks:            gs.Tricks,
+		Scores:            gs.Scores,
+		WinnerTeam:        gs.WinnerTeam,
+		Concessions:       gs.Concessions,
+		ConcededBy:        gs.ConcededBy,
+		ObservedVoids:     gs.ObservedVoids,
+		TurnDeadline:      gs.TurnDeadline,
+		UpdatedAt:         gs.UpdatedAt,
+	}
+
+	if seconds, ok := gs.SecondsRemaining(now()); ok {
+		view.SecondsRemaining = &seconds
+	}
+
+	for _, player := range gs.Players {
+		playerView := PlayerView{
+			PlayerPublic: player.PublicView(),
+			Disconnected: player.IsDisconnected(),
+			IsBot:        player.IsBot,
+			Status:       player.ConnectionStatus(now()),
+			Team:         gs.GetTeam(player.Position),
+		}
+		if playerView.Status == StatusReconnecting {
+			playerView.ReconnectDeadline = player.ReconnectDeadline
+		}
+		view.Players = append(view.Players, playerView)
+	}
+
+	if gs.canSeeKitty(viewer) {
+		view.Kitty = append([]Card(nil), gs.Kitty...)
+	}
+
+	return view, nil
+}
+
+// GetTeam returns the team label of the player at position, or an empty
+// string before a declarer is known or while a called partner is hidden
+func (gs *GameState) GetTeam(position PlayerPosition) string {
+	if gs.Declarer == nil {
+		return ""
+	}
+	if gs.Rules.Partnership == CalledCard && gs.CalledPartner == nil &&
+		gs.Phase != PhaseEnded && position != *gs.Declarer {
+		return ""
+	}
+	if gs.IsOnDeclarerTeam(position) {
+		return TeamDeclarer
+	}
+	return TeamDefenders
+}
+
+// canSeeKitty checks if a player may see the kitty cards. The declarer picks
+// up the kitty during the exchange and knows what they discarded, and may
+// peek at it while choosing trump if the rules allow; everyone sees it once
+// the game has ended.
+func (gs *GameState) canSeeKitty(player *Player) bool {
+	if gs.Phase == PhaseEnded {
+		return true
+	}
+	if gs.Declarer == nil || player.Position != *gs.Declarer {
+		return false
+	}
+	switch gs.Phase {
+	case PhaseTrumpDeclaration:
+		return gs.Rules.PeekKittyBeforeTrump
+	case PhaseKittyExchange, PhasePlaying:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameState_ViewFor(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	view, err := gs.ViewFor("east")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+
+	if view.Position != East {
+		t.Errorf("Expected East position, got %s", view.Position.String())
+	}
+	if len(view.Hand) != 25 || !view.Hand[0].IsEqual(gs.Players[East].Hand[0]) {
+		t.Errorf("Expected the viewer's own 25 cards, got %d", len(view.Hand))
+	}
+	if len(view.Players) != 4 {
+		t.Fatalf("Expected 4 players, got %d", len(view.Players))
+	}
+	for _, player := range view.Players {
+		if player.HandSize != 25 {
+			t.Errorf("Expected %s to hold 25 cards, got %d", player.ID, player.HandSize)
+		}
+	}
+	if view.Kitty != nil {
+		t.Error("Defenders must not see the kitty during play")
+	}
+
+	declarerView, err := gs.ViewFor("north")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if len(declarerView.Kitty) != 8 {
+		t.Errorf("Expected the declarer to see their 8 discards, got %d", len(declarerView.Kitty))
+	}
+
+	if _, err := gs.ViewFor("stranger"); err == nil {
+		t.Error("Expected error for a player not in the game")
+	}
+}
+
+func TestPlayer_PublicView(t *testing.T) {
+	gs := newPlayingGameState(t)
+	east := gs.Players[East]
+	east.HasPassed = true
+
+	public := east.PublicView()
+	if public.ID != "east" || public.Position != East || !public.HasPassed {
+		t.Errorf("Unexpected public view %+v", public)
+	}
+	if public.HandSize != len(east.Hand) || public.HandSize != 25 {
+		t.Errorf("Expected a hand size of 25, got %d", public.HandSize)
+	}
+
+	data, err := json.Marshal(public)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, field := range []string{`"hand"`, `"suit"`, `"rank"`} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("Expected no card data in %s", data)
+		}
+	}
+}
+
+func TestGameState_ViewForShowsDisconnectedPlayers(t *testing.T) {
+	gs := newPlayingGameState(t)
+	if err := gs.MarkDisconnected("west", gs.UpdatedAt, 30*time.Second); err != nil {
+		t.Fatalf("MarkDisconnected() error = %v", err)
+	}
+
+	setClock(t, gs.UpdatedAt.Add(10*time.Second))
+	view, err := gs.ViewFor("north")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if !view.Players[West].Disconnected || view.Players[East].Disconnected {
+		t.Error("Expected only West to be shown as disconnected")
+	}
+	if view.Players[West].Status != StatusReconnecting || view.Players[West].ReconnectDeadline == nil {
+		t.Errorf("Expected West's seat to be held while reconnecting, got %s", view.Players[West].Status)
+	}
+	if view.Players[East].Status != StatusConnected {
+		t.Errorf("Expected East to be connected, got %s", view.Players[East].Status)
+	}
+
+	if err := gs.MarkConnected("west"); err != nil {
+		t.Fatalf("MarkConnected() error = %v", err)
+	}
+	if len(gs.GetDisconnectedPlayers()) != 0 {
+		t.Error("Expected no disconnected players after reconnecting")
+	}
+	if status := gs.Players[West].ConnectionStatus(now()); status != StatusConnected {
+		t.Errorf("Expected West to be connected again, got %s", status)
+	}
+}
+
+func TestPlayer_ConnectionStatus(t *testing.T) {
+	gs := newPlayingGameState(t)
+	droppedAt := gs.UpdatedAt
+	if err := gs.MarkDisconnected("west", droppedAt, 30*time.Second); err != nil {
+		t.Fatalf("MarkDisconnected() error = %v", err)
+	}
+
+	west := gs.Players[West]
+	if status := west.ConnectionStatus(droppedAt.Add(29 * time.Second)); status != StatusReconnecting {
+		t.Errorf("Expected reconnecting within the grace period, got %s", status)
+	}
+	if status := west.ConnectionStatus(droppedAt.Add(30 * time.Second)); status != StatusDisconnected {
+		t.Errorf("Expected disconnected once the grace period ends, got %s", status)
+	}
+
+	if err := gs.ReplaceWithBot("west"); err != nil {
+		t.Fatalf("ReplaceWithBot() error = %v", err)
+	}
+	if status := west.ConnectionStatus(droppedAt.Add(time.Minute)); status != StatusReplacedByBot {
+		t.Errorf("Expected the seat to be shown as taken by a bot, got %s", status)
+	}
+}
+
+func TestGameState_ViewForShowsTeams(t *testing.T) {
+	gs := newTestGameState(t)
+
+	view, err := gs.ViewFor("east")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if view.Partner == nil || *view.Partner != West {
+		t.Errorf("Expected East's partner to be West, got %v", view.Partner)
+	}
+	for _, player := range view.Players {
+		if player.Team != "" {
+			t.Errorf("Expected no team for %s before a declarer exists, got %q", player.ID, player.Team)
+		}
+	}
+
+	declarer := South
+	gs.Declarer = &declarer
+	view, err = gs.ViewFor("east")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+
+	want := map[PlayerPosition]string{
+		North: TeamDeclarer, // The declarer's partner
+		East:  TeamDefenders,
+		South: TeamDeclarer,
+		West:  TeamDefenders,
+	}
+	for _, player := range view.Players {
+		if player.Team != want[player.Position] {
+			t.Errorf("Expected %s on team %q, got %q", player.Position.String(), want[player.Position], player.Team)
+		}
+	}
+}
+
+func TestGameState_ViewForShowsPointProgress(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	// Two full tricks and the lead of a third
+	for i := 0; i < 9; i++ {
+		if err := gs.AutoAct(); err != nil {
+			t.Fatalf("AutoAct() error = %v", err)
+		}
+	}
+	if len(gs.Tricks) != 2 || gs.CurrentTrick == nil {
+		t.Fatalf("Expected 2 completed tricks and one in progress, got %d", len(gs.Tricks))
+	}
+
+	defenders, declarerTeam := 0, 0
+	for _, trick := range gs.Tricks {
+		winner := gs.GetTrickWinner(trick)
+		if winner.Position == East || winner.Position == West {
+			defenders += trick.Points
+		} else {
+			declarerTeam += trick.Points
+		}
+	}
+
+	view, err := gs.ViewFor("east")
+	if err != nil {
+		t.Fatalf("ViewFor() error = %v", err)
+	}
+	if view.Contract != gs.Contract {
+		t.Errorf("Expected contract %d, got %d", gs.Contract, view.Contract)
+	}
+	if view.DefendersPointsSoFar != defenders {
+		t.Errorf("Expected defenders to have %d points so far, got %d", defenders, view.DefendersPointsSoFar)
+	}
+	total := view.DefendersPointsSoFar + declarerTeam + view.PointsRemainingInPlay + gs.GetKittyPoints()
+	if total != 200 {
+		t.Errorf("Expected defender (%d), declarer (%d), remaining (%d) and kitty (%d) points to total 200",
+			view.DefendersPointsSoFar, declarerTeam, view.PointsRemainingInPlay, gs.GetKittyPoints())
+	}
+}
+
+func TestGameState_GetDefendersTrickPointsLeavesOutKitty(t *testing.T) {
+	gs := newTestGameState(t)
+	trump := Spades
+	gs.TrumpSuit = &trump
+	declarer := North
+	gs.Declarer = &declarer
+	gs.Kitty = []Card{NewCard(Diamonds, King, 1)}
+
+	playTestTrick(t, gs, North, map[PlayerPosition]Card{
+		North: NewCard(Hearts, King, 1),
+		East:  NewCard(Hearts, Five, 1),
+		South: NewCard(Hearts, Ten, 1),
+		West:  NewCard(Hearts, Ace, 1),
+	})
+	playTestTrick(t, gs, West, map[PlayerPosition]Card{
+		West:  NewCard(Clubs, Three, 1),
+		North: NewCard(Clubs, Ace, 1),
+		East:  NewCard(Clubs, Five, 1),
+		South: NewCard(Clubs, Four, 1),
+	})
+
+	if points := gs.GetDefendersTrickPoints(); points != 25 {
+		t.Errorf("Expected defenders to have 25 points from tricks, got %d", points)
+	}
+}
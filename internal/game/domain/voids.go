@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// VoidSuits returns which suits the player holds no cards of. Trumps count as
+// the trump suit, so a player holding only trump-rank cards of a plain suit is
+// still void in that suit. Under No Trump, trumps are keyed by NoTrump.
+func (p *Player) VoidSuits(trumpSuit Suit) map[Suit]bool {
+	voids := map[Suit]bool{trumpSuit: true}
+	for suit := Spades; suit <= Diamonds; suit++ {
+		voids[suit] = true
+	}
+
+	for _, card := range p.Hand {
+		voids[effectiveSuit(card, trumpSuit)] = false
+	}
+	return voids
+}
+
+// IsObservedVoid checks if the player at position has shown they are void in
+// suit by failing to follow it earlier in the game
+func (gs *GameState) IsObservedVoid(position PlayerPosition, suit Suit) bool {
+	for _, void := range gs.ObservedVoids[position] {
+		if void == suit {
+			return true
+		}
+	}
+	return false
+}
+
+// recordObservedVoid notes when the player plays cards outside the led suit of
+// the current trick after playing every card of it they held. It must be called
+// before the formation leaves the player's hand. A player who leaves the led
+// suit while still holding it reneges and is not recorded void.
+func (gs *GameState) recordObservedVoid(player *Player, formation *Formation) {
+	trick := gs.CurrentTrick
+	led := trick.Plays[trick.Leader]
+	position := player.Position
+	if led == nil || position == trick.Leader {
+		return
+	}
+
+	trumpSuit := *gs.TrumpSuit
+	ledSuit := effectiveSuit(led.Cards[0], trumpSuit)
+	following := countSuit(formation.Cards, ledSuit, trumpSuit)
+	if following == len(formation.Cards) || countSuit(player.Hand, ledSuit, trumpSuit) > following {
+		return
+	}
+	if gs.IsObservedVoid(position, ledSuit) {
+		return
+	}
+
+	if gs.ObservedVoids == nil {
+		gs.ObservedVoids = make(map[PlayerPosition][]Suit)
+	}
+	gs.ObservedVoids[position] = append(gs.ObservedVoids[position], ledSuit)
+	gs.UpdatedAt = time.Now()
+}
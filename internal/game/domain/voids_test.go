@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlayer_VoidSuits(t *testing.T) {
+	player := NewPlayer("north", "North Player", North)
+	player.AddCards([]Card{
+		NewCard(Spades, Ten, 1),
+		NewCard(Clubs, Two, 1), // A trump under any trump suit
+		NewJoker(BigJoker, 1),
+	})
+
+	voids := player.VoidSuits(Hearts)
+	want := map[Suit]bool{Spades: false, Hearts: false, Clubs: true, Diamonds: true}
+	for suit, void := range want {
+		if voids[suit] != void {
+			t.Errorf("VoidSuits(Hearts)[%s] = %v, want %v", suit, voids[suit], void)
+		}
+	}
+
+	voids = player.VoidSuits(NoTrump)
+	if voids[NoTrump] || voids[Spades] || !voids[Hearts] || !voids[Clubs] {
+		t.Errorf("VoidSuits(NoTrump) = %v, want trumps and Spades held", voids)
+	}
+}
+
+func TestGameState_RecordsObservedVoids(t *testing.T) {
+	gs := newPlayingGameState(t)
+
+	// East discards a Club and West trumps when North leads a Spade
+	plays := []struct {
+		playerID string
+		card     Card
+	}{
+		{"north", NewCard(Spades, Ten, 1)},
+		{"east", NewCard(Clubs, Three, 1)},
+		{"south", NewCard(Spades, Ten, 2)},
+		{"west", NewCard(Hearts, Queen, 2)},
+	}
+	for _, play := range plays {
+		if _, err := gs.PlayCards(play.playerID, NewSingle(play.card)); err != nil {
+			t.Fatalf("PlayCards(%s) error = %v", play.playerID, err)
+		}
+	}
+
+	for _, position := range []PlayerPosition{East, West} {
+		if !gs.IsObservedVoid(position, Spades) {
+			t.Errorf("Expected %s to be recorded void in Spades", position.String())
+		}
+	}
+	for _, position := range []PlayerPosition{North, South} {
+		if len(gs.ObservedVoids[position]) != 0 {
+			t.Errorf("Expected no voids for %s, got %v", position.String(), gs.ObservedVoids[position])
+		}
+	}
+	if gs.IsObservedVoid(East, Clubs) {
+		t.Error("Expected East not to be void in Clubs, the suit they discarded")
+	}
+}
+
+func TestGameState_RecordsVoidOnlyWhenOutOfLedSuit(t *testing.T) {
+	tests := []struct {
+		name     string
+		penalize bool
+		eastHand []Card
+		play     func(t *testing.T) *Formation
+		wantErr  error
+		wantVoid bool
+	}{
+		{
+			name:     "Off-suit pair while holding the led suit is rejected",
+			eastHand: []Card{NewCard(Clubs, Nine, 1), NewCard(Spades, Ace, 1), NewCard(Spades, Ace, 2)},
+			play:     func(t *testing.T) *Formation { return mustPair(t, Spades, Ace) },
+			wantErr:  ErrMustFollow,
+		},
+		{
+			name:     "Renege is not recorded as a void",
+			penalize: true,
+			eastHand: []Card{NewCard(Clubs, Nine, 1), NewCard(Spades, Ace, 1), NewCard(Spades, Ace, 2)},
+			play:     func(t *testing.T) *Formation { return mustPair(t, Spades, Ace) },
+		},
+		{
+			name:     "Last card of the led suit made up with another",
+			eastHand: []Card{NewCard(Clubs, Nine, 1), NewCard(Spades, Ace, 1), NewCard(Spades, King, 1)},
+			play: func(t *testing.T) *Formation {
+				return NewMixed([]Card{NewCard(Clubs, Nine, 1), NewCard(Spades, King, 1)})
+			},
+			wantVoid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newPlayingGameState(t)
+			gs.Rules.PenalizeReneges = tt.penalize
+			gs.Players[North].Hand = []Card{NewCard(Clubs, Three, 1), NewCard(Clubs, Three, 2), NewCard(Clubs, Six, 1)}
+			gs.Players[East].Hand = tt.eastHand
+
+			if _, err := gs.PlayCards("north", mustPair(t, Clubs, Three)); err != nil {
+				t.Fatalf("PlayCards(north) error = %v", err)
+			}
+			if _, err := gs.PlayCards("east", tt.play(t)); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlayCards(east) error = %v, want %v", err, tt.wantErr)
+			}
+			if gs.IsObservedVoid(East, Clubs) != tt.wantVoid {
+				t.Errorf("Expected East void in Clubs to be %v, got voids %v", tt.wantVoid, gs.ObservedVoids)
+			}
+		})
+	}
+}
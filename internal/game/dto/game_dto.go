@@ -0,0 +1,176 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"chinese-bridge-game/internal/game/domain"
+)
+
+// IdempotencyKeyHeader is the header clients set so that a retried mutating
+// request returns the original result instead of being applied twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// PlaceBidRequest represents a bid, or a pass when Pass is set. A bid must
+// have a positive amount.
+type PlaceBidRequest struct {
+	Amount int  `json:"amount" binding:"required_unless=Pass true,min=0" example:"120"`
+	Pass   bool `json:"pass" example:"false"`
+}
+
+// DeclareTrumpRequest represents the declarer's choice of trump suit, by name:
+// Spades, Hearts, Clubs, Diamonds or "No Trump"
+type DeclareTrumpRequest struct {
+	Suit string `json:"suit" binding:"required" example:"Hearts"`
+}
+
+// TrumpSuit returns the suit named by the request
+func (r DeclareTrumpRequest) TrumpSuit() (domain.Suit, error) {
+	return domain.ParseSuit(r.Suit)
+}
+
+// PartnerCardRequest represents the card the declarer calls, whose holder
+// becomes their partner under called-card partnerships
+type PartnerCardRequest struct {
+	Card *domain.Card `json:"card" binding:"required"`
+}
+
+// ExchangeKittyRequest represents the cards the declarer discards into the
+// kitty, as compact card codes such as "HK1". It must name exactly
+// domain.KittySize cards.
+type ExchangeKittyRequest struct {
+	Discard []string `json:"discard" binding:"required" example:"C31,C41,C51,C61,D31,D41,D51,D61"`
+}
+
+// DiscardedCards decodes the discarded cards
+func (r ExchangeKittyRequest) DiscardedCards() ([]domain.Card, error) {
+	if len(r.Discard) != domain.KittySize {
+		return nil, fmt.Errorf("discard must have exactly %d items", domain.KittySize)
+	}
+	return domain.DecodeCards(r.Discard)
+}
+
+// PlayCardsRequest represents cards played to the current trick, as compact
+// card codes, and the formation they are played as, by name: Single, Pair,
+// Tractor, or Mixed for a follower who holds no formation like the led one.
+// The server rebuilds the formation from its cards and type.
+type PlayCardsRequest struct {
+	Cards []string `json:"cards" binding:"required,min=1" example:"HK1,HK2"`
+	Type  string   `json:"type" binding:"required" example:"Pair"`
+}
+
+// Formation decodes the played formation
+func (r PlayCardsRequest) Formation() (*domain.Formation, error) {
+	formationType, err := domain.ParseFormationType(r.Type)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := domain.DecodeCards(r.Cards)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Formation{Type: formationType, Cards: cards}, nil
+}
+
+// ValidateFormationRequest represents cards to check as a formation of the
+// given type, by name: Single, Pair or Tractor, under the given trump suit
+type ValidateFormationRequest struct {
+	Cards []domain.Card         `json:"cards" binding:"required,min=1"`
+	Type  *domain.FormationType `json:"type" binding:"required" swaggertype:"string" example:"Pair"`
+	Trump *domain.Suit          `json:"trump" binding:"required" swaggertype:"string" example:"Hearts"`
+}
+
+// ValidateFormationResponse reports whether the cards form the formation. A
+// valid formation is returned normalized, strongest card first, with its
+// point value; otherwise Error says why the cards do not form it.
+type ValidateFormationResponse struct {
+	Valid     bool              `json:"valid"`
+	Error     string            `json:"error,omitempty"`
+	Formation *domain.Formation `json:"formation,omitempty"`
+	Points    int               `json:"points"`
+}
+
+// LegalMovesResponse lists the formations the caller may play in the current trick
+type LegalMovesResponse struct {
+	Formations []*domain.Formation `json:"formations"`
+}
+
+// AllowedActionsResponse lists the kinds of action the caller may take now:
+// bid, pass, undo_bid, declare_trump, call_partner, exchange_kitty or play
+type AllowedActionsResponse struct {
+	Actions []domain.Action `json:"actions"`
+}
+
+// CurrentGameResponse identifies the latest game in a room and its state as
+// seen by the caller
+type CurrentGameResponse struct {
+	GameID string           `json:"game_id"`
+	State  *domain.GameView `json:"state"`
+}
+
+// AbortGameResponse summarizes an aborted game without revealing any hand or
+// the kitty
+type AbortGameResponse struct {
+	GameID       string                `json:"game_id"`
+	RoomID       string                `json:"room_id"`
+	Phase        domain.GamePhase      `json:"phase" swaggertype:"string" example:"Aborted"`
+	Players      []domain.PlayerPublic `json:"players"`
+	TricksPlayed int                   `json:"tricks_played"`
+}
+
+// NewAbortGameResponse summarizes the state of an aborted game
+func NewAbortGameResponse(state *domain.GameState) AbortGameResponse {
+	players := make([]domain.PlayerPublic, 0, len(state.Players))
+	for _, player := range state.Players {
+		players = append(players, player.PublicView())
+	}
+	return AbortGameResponse{
+		GameID:       state.ID,
+		RoomID:       state.RoomID,
+		Phase:        state.Phase,
+		Players:      players,
+		TricksPlayed: len(state.Tricks),
+	}
+}
+
+// TrickHistoryResponse lists the completed tricks of a game in the order they
+// were played, with the cards each player played to them
+type TrickHistoryResponse struct {
+	Tricks []map[string]interface{} `json:"tricks"`
+}
+
+// BidHistoryResponse lists a game's bids and passes in the order they were
+// made, with each bidder's seat
+type BidHistoryResponse struct {
+	Bids []domain.AnnotatedBid `json:"bids"`
+}
+
+// ChatMessage is a message posted to a room's chat
+type ChatMessage struct {
+	ID       string    `json:"id"`
+	RoomID   string    `json:"room_id"`
+	UserID   string    `json:"user_id"`
+	UserName string    `json:"user_name"`
+	Text     string    `json:"text"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// CreateRoomRequest represents a request to open a new room. The name is
+// trimmed and must not contain control characters. The optional preset picks
+// the house rules the room's games are played under: classic or no_trump_fast.
+type CreateRoomRequest struct {
+	Name   string            `json:"name" binding:"required,min=1,max=64" example:"Friday night bridge"`
+	Preset domain.RulePreset `json:"preset,omitempty" swaggertype:"string" example:"classic"`
+}
+
+// ChatMessageRequest represents a message posted to a room's chat
+type ChatMessageRequest struct {
+	Text string `json:"text" binding:"required" example:"Good luck!"`
+}
+
+// ChatMessagesResponse lists chat messages in the order they were sent
+type ChatMessagesResponse struct {
+	Messages []ChatMessage `json:"messages"`
+	Limit    int           `json:"limit"`
+	Offset   int           `json:"offset"`
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// bindingErrorDetails describes why a request body could not be bound, naming
+// the offending fields by their JSON names where the error identifies them
+func bindingErrorDetails(req interface{}, err error) string {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]string, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			details = append(details, jsonFieldPath(req, fieldErr)+" "+validationMessage(fieldErr))
+		}
+		return strings.Join(details, "; ")
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return fmt.Sprintf("%s must be %s, got %s", field, jsonKind(typeErr.Type), typeErr.Value)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset)
+	}
+	if errors.Is(err, io.EOF) {
+		return "request body is empty"
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return "request body is incomplete JSON"
+	}
+
+	// Errors from the domain's own decoders, such as an unknown suit name
+	return err.Error()
+}
+
+// validationMessage describes a failed validation rule
+func validationMessage(fieldErr validator.FieldError) string {
+	collection := fieldErr.Kind() == reflect.Slice || fieldErr.Kind() == reflect.Array || fieldErr.Kind() == reflect.Map
+	switch fieldErr.Tag() {
+	case "required", "required_unless", "required_if", "required_with":
+		return "is required"
+	case "min":
+		if collection {
+			return fmt.Sprintf("must have at least %s items", fieldErr.Param())
+		}
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		if collection {
+			return fmt.Sprintf("must have at most %s items", fieldErr.Param())
+		}
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "len":
+		if collection {
+			return fmt.Sprintf("must have exactly %s items", fieldErr.Param())
+		}
+		return fmt.Sprintf("must have length %s", fieldErr.Param())
+	default:
+		return fmt.Sprintf("failed the %s check", fieldErr.Tag())
+	}
+}
+
+// jsonFieldPath converts a validation error's Go field path, such as
+// PartnerCardRequest.Card.Suit, to the JSON path the client sent: card.suit
+func jsonFieldPath(req interface{}, fieldErr validator.FieldError) string {
+	segments := strings.Split(fieldErr.StructNamespace(), ".")
+	if len(segments) < 2 {
+		return fieldErr.Field()
+	}
+
+	t := reflect.TypeOf(req)
+	path := make([]string, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		t = elemType(t)
+		if t.Kind() != reflect.Struct {
+			return fieldErr.Field()
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return fieldErr.Field()
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "" || jsonName == "-" {
+			jsonName = field.Name
+		}
+		path = append(path, jsonName+index)
+
+		t = field.Type
+		if index != "" {
+			t = elemType(t).Elem()
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// elemType strips pointers from a type
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonKind names the JSON type a Go type is decoded from
+func jsonKind(t reflect.Type) string {
+	switch elemType(t).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
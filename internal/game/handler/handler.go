@@ -1,18 +1,47 @@
 package handler
 
 import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/game/domain"
+	gamedto "chinese-bridge-game/internal/game/dto"
 	"chinese-bridge-game/internal/game/service"
+	"chinese-bridge-game/internal/game/ws"
+	"chinese-bridge-game/pkg/apierror"
+	"chinese-bridge-game/pkg/buildinfo"
+	"chinese-bridge-game/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Chat rate limits per user: a sustained message every two seconds, with short bursts
+const (
+	chatMessagesPerSecond = 0.5
+	chatBurst             = 5
+)
+
+// Chat history page sizes
+const (
+	defaultChatPageSize = 50
+	maxChatPageSize     = 100
+)
+
 type GameHandler struct {
 	gameService service.GameService
+	roomService service.RoomService
+	hub         *ws.Hub
+	wsChat      wsChatLimiters
 }
 
-func NewGameHandler(gameService service.GameService) *GameHandler {
+func NewGameHandler(gameService service.GameService, roomService service.RoomService, hub *ws.Hub) *GameHandler {
 	return &GameHandler{
 		gameService: gameService,
+		roomService: roomService,
+		hub:         hub,
 	}
 }
 
@@ -20,49 +49,917 @@ func (h *GameHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// Room-related routes
 	rooms := router.Group("/rooms")
 	{
+		rooms.POST("", h.CreateRoom)
 		rooms.POST("/:roomId/start", h.StartGame)
+		rooms.DELETE("/:roomId/participants/:userId", h.KickParticipant)
+		rooms.GET("/:roomId/game", h.GetCurrentGame)
+		rooms.GET("/:roomId/messages", h.GetMessages)
+		rooms.POST("/:roomId/messages", middleware.UserRateLimiter(chatMessagesPerSecond, chatBurst), h.PostMessage)
 	}
 	
 	// Game-related routes
 	games := router.Group("/games")
 	{
+		games.POST("/validate-formation", h.ValidateFormation)
+		games.GET("/rules/cards", h.GetCardLegend)
 		games.GET("/:gameId", h.GetGameState)
+		games.GET("/:gameId/scoreboard", h.GetScoreboard)
+		games.GET("/:gameId/resume", h.ResumeGame)
+		games.GET("/:gameId/legal-moves", h.GetLegalMoves)
+		games.GET("/:gameId/actions", h.GetAllowedActions)
+		games.GET("/:gameId/tricks", h.GetTrickHistory)
+		games.GET("/:gameId/bids", h.GetBidHistory)
 		games.POST("/:gameId/bid", h.PlaceBid)
+		games.POST("/:gameId/bid/undo", h.UndoBid)
 		games.POST("/:gameId/trump", h.DeclareTrump)
+		games.POST("/:gameId/partner-card", h.CallPartnerCard)
 		games.POST("/:gameId/kitty", h.ExchangeKitty)
 		games.POST("/:gameId/play", h.PlayCards)
+		games.POST("/:gameId/concede", h.Concede)
+		games.POST("/:gameId/rematch", h.Rematch)
+	}
+}
+
+// RegisterWebSocketRoutes adds the WebSocket routes, which the caller must
+// guard with middleware.JWTAuthWS so browsers can authenticate the handshake
+func (h *GameHandler) RegisterWebSocketRoutes(router *gin.RouterGroup) {
+	router.GET("/games/:gameId/ws", h.ConnectWebSocket)
+}
+
+// RegisterAdminRoutes adds the admin routes, which the caller must guard with admin-only middleware
+func (h *GameHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	{
+		admin.POST("/games/:gameId/abort", h.AbortGame)
+		admin.GET("/games/:gameId/deal", h.GetDealRecord)
 	}
 }
 
+// CreateRoom godoc
+// @Summary Create a room
+// @Description Open a new waiting room with the caller as host. Names are trimmed, limited to 64 characters and may not contain control characters. An optional rule preset, classic or no_trump_fast, sets the house rules for the room's games.
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body gamedto.CreateRoomRequest true "Room"
+// @Success 201 {object} database.Room
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms [post]
+func (h *GameHandler) CreateRoom(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req gamedto.CreateRoomRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	room, err := h.roomService.CreateRoom(c.Request.Context(), userID, req.Name, req.Preset)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to create room")
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// Rematch godoc
+// @Summary Accept a rematch
+// @Description Join the waiting room for a rematch of a finished game, opening it if no one has yet. The first player to accept hosts the room, seating rotates to start from the player after the previous host, and the other players are invited over WebSocket; only those who accept are seated.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} database.Room
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/rematch [post]
+func (h *GameHandler) Rematch(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	room, err := h.roomService.Rematch(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to set up rematch")
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// StartGame godoc
+// @Summary Start a game
+// @Description Deal a new game in a full room. Only the room host can start it.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param roomId path string true "Room ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Success 201 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms/{roomId}/start [post]
 func (h *GameHandler) StartGame(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Start game endpoint"})
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	state, err := h.gameService.StartGame(h.requestContext(c), c.Param("roomId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to start game")
+		return
+	}
+
+	view, err := state.ViewFor(userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to start game")
+		return
+	}
+	c.JSON(http.StatusCreated, view)
+}
+
+// KickParticipant godoc
+// @Summary Kick a player from a room
+// @Description Remove a player from a waiting room. Only the room host can kick, and not themselves.
+// @Tags game
+// @Security BearerAuth
+// @Param roomId path string true "Room ID"
+// @Param userId path string true "User ID of the player to kick"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms/{roomId}/participants/{userId} [delete]
+func (h *GameHandler) KickParticipant(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.KickParticipant(c.Request.Context(), c.Param("roomId"), userID, c.Param("userId")); err != nil {
+		h.handleGameError(c, err, "Failed to kick player")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
 
+// PostMessage godoc
+// @Summary Post a chat message
+// @Description Post a message to a room's chat and broadcast it to the room's players. Messages are limited in length and rate.
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param roomId path string true "Room ID"
+// @Param request body gamedto.ChatMessageRequest true "Message"
+// @Success 201 {object} gamedto.ChatMessage
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms/{roomId}/messages [post]
+func (h *GameHandler) PostMessage(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req gamedto.ChatMessageRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	message, err := h.roomService.PostMessage(c.Request.Context(), c.Param("roomId"), userID, req.Text)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to post message")
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// GetMessages godoc
+// @Summary Get chat messages
+// @Description Get a page of a room's recent chat messages in the order they were sent. Offset counts back from the newest message.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param roomId path string true "Room ID"
+// @Param limit query int false "Number of messages" default(50)
+// @Param offset query int false "Number of newest messages to skip" default(0)
+// @Success 200 {object} gamedto.ChatMessagesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms/{roomId}/messages [get]
+func (h *GameHandler) GetMessages(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultChatPageSize)))
+	if err != nil || limit < 1 || limit > maxChatPageSize {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid limit parameter",
+			Details: "Limit must be between 1 and " + strconv.Itoa(maxChatPageSize),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid offset parameter",
+			Details: "Offset must be a non-negative integer",
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	messages, err := h.roomService.GetMessages(c.Request.Context(), c.Param("roomId"), userID, offset, limit)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.ChatMessagesResponse{
+		Messages: messages,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
+
+// PlaceBid godoc
+// @Summary Bid or pass
+// @Description Place a bid for the caller, or pass when "pass" is set
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Param request body gamedto.PlaceBidRequest true "Bid"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/bid [post]
 func (h *GameHandler) PlaceBid(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Place bid endpoint"})
+	var req gamedto.PlaceBidRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	h.applyAction(c, "Failed to place bid", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		if req.Pass {
+			return h.gameService.PassBid(ctx, gameID, userID)
+		}
+		return h.gameService.PlaceBid(ctx, gameID, userID, req.Amount)
+	})
+}
+
+// UndoBid godoc
+// @Summary Undo last bid
+// @Description Take back the caller's last bid or pass, if the game allows it and no one has acted since
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/bid/undo [post]
+func (h *GameHandler) UndoBid(c *gin.Context) {
+	h.applyAction(c, "Failed to undo bid", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.UndoBid(ctx, gameID, userID)
+	})
 }
 
+// DeclareTrump godoc
+// @Summary Declare trump
+// @Description Declare the trump suit after winning the bidding. A rejected declaration may be retried until the attempts allowed by the rules run out, when a trump is chosen for the declarer.
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Param request body gamedto.DeclareTrumpRequest true "Trump suit"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/trump [post]
 func (h *GameHandler) DeclareTrump(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Declare trump endpoint"})
+	var req gamedto.DeclareTrumpRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+	suit, err := req.TrumpSuit()
+	if err != nil {
+		h.rejectRequest(c, err.Error())
+		return
+	}
+
+	h.applyAction(c, "Failed to declare trump", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.DeclareTrump(ctx, gameID, userID, suit)
+	})
+}
+
+// CallPartnerCard godoc
+// @Summary Call the partner card
+// @Description Name the card whose holder becomes the declarer's partner, revealed when the card is played. Only used when partners are chosen by calling a card, and required before the kitty is exchanged. The card must not be one the declarer holds.
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Param request body gamedto.PartnerCardRequest true "Called card"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/partner-card [post]
+func (h *GameHandler) CallPartnerCard(c *gin.Context) {
+	var req gamedto.PartnerCardRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	h.applyAction(c, "Failed to call partner card", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.CallPartnerCard(ctx, gameID, userID, *req.Card)
+	})
 }
 
+// ExchangeKitty godoc
+// @Summary Exchange the kitty
+// @Description Discard cards into the kitty after picking it up, as compact card codes such as "HK1"
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Param request body gamedto.ExchangeKittyRequest true "Discarded cards"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/kitty [post]
 func (h *GameHandler) ExchangeKitty(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Exchange kitty endpoint"})
+	var req gamedto.ExchangeKittyRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+	cards, err := req.DiscardedCards()
+	if err != nil {
+		h.rejectRequest(c, err.Error())
+		return
+	}
+
+	h.applyAction(c, "Failed to exchange kitty", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.ExchangeKitty(ctx, gameID, userID, cards)
+	})
 }
 
+// PlayCards godoc
+// @Summary Play cards
+// @Description Play a formation to the current trick, given as compact card codes such as "HK1" and the formation type
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Param request body gamedto.PlayCardsRequest true "Formation"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/play [post]
 func (h *GameHandler) PlayCards(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Play cards endpoint"})
+	var req gamedto.PlayCardsRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+	formation, err := req.Formation()
+	if err != nil {
+		h.rejectRequest(c, err.Error())
+		return
+	}
+
+	h.applyAction(c, "Failed to play cards", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.PlayCards(ctx, gameID, userID, formation)
+	})
+}
+
+// Concede godoc
+// @Summary Concede the game
+// @Description Concede on behalf of the caller's team. The game ends once every member of the team has conceded, or at once if the rules let one player concede alone. The room's host may set "override" to end the game for their team immediately.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param override query bool false "End the game for the host's team without waiting for teammates" default(false)
+// @Param Idempotency-Key header string false "Key identifying retries of the same request"
+// @Success 200 {object} domain.GameView
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /games/{gameId}/concede [post]
+func (h *GameHandler) Concede(c *gin.Context) {
+	override, err := strconv.ParseBool(c.DefaultQuery("override", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid override parameter",
+			Details: "Override must be true or false",
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	h.applyAction(c, "Failed to concede", func(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+		return h.gameService.Concede(ctx, gameID, userID, override)
+	})
 }
 
+// ValidateFormation godoc
+// @Summary Validate a formation
+// @Description Check whether cards form a single, pair or tractor under a trump suit, without a game. Valid formations are returned normalized with their point value.
+// @Tags game
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body gamedto.ValidateFormationRequest true "Cards, formation type and trump"
+// @Success 200 {object} gamedto.ValidateFormationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /games/validate-formation [post]
+func (h *GameHandler) ValidateFormation(c *gin.Context) {
+	var req gamedto.ValidateFormationRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	formation, err := domain.NormalizeFormation(req.Cards, *req.Type, *req.Trump)
+	if err != nil {
+		c.JSON(http.StatusOK, gamedto.ValidateFormationResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gamedto.ValidateFormationResponse{
+		Valid:     true,
+		Formation: formation,
+		Points:    formation.GetPointValue(),
+	})
+}
+
+// GetCardLegend godoc
+// @Summary Card value legend
+// @Description List the points each rank is worth when captured and the trump hierarchy from strongest to weakest, as the server scores and compares cards
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CardLegend
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /games/rules/cards [get]
+func (h *GameHandler) GetCardLegend(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.GetCardLegend())
+}
+
+// applyAction runs a player action against the game in the path, passing on
+// the request's idempotency key, and responds with the caller's view of the result
+func (h *GameHandler) applyAction(c *gin.Context, message string, action func(ctx context.Context, gameID, userID string) (*domain.GameState, error)) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	state, err := action(h.requestContext(c), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, message)
+		return
+	}
+
+	view, err := state.ViewFor(userID)
+	if err != nil {
+		h.handleGameError(c, err, message)
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// requestContext returns the request's context carrying its idempotency key
+func (h *GameHandler) requestContext(c *gin.Context) context.Context {
+	return service.WithIdempotencyKey(c.Request.Context(), c.GetHeader(gamedto.IdempotencyKeyHeader))
+}
+
+// bindRequest decodes the JSON body, responding with 400 and the fields at
+// fault if it is invalid
+func (h *GameHandler) bindRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.rejectRequest(c, bindingErrorDetails(req, err))
+		return false
+	}
+	return true
+}
+
+// rejectRequest responds that the request body is invalid for the reason given
+func (h *GameHandler) rejectRequest(c *gin.Context, details string) {
+	c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: "Invalid request body",
+		Details: details,
+		TraceID: c.GetString("trace_id"),
+	})
+}
+
+// GetGameState godoc
+// @Summary Get game state
+// @Description Get the game as seen by the caller: their own hand, the cards played so far and every player's card count, but not the other players' hands
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} domain.GameView
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId} [get]
 func (h *GameHandler) GetGameState(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Get game state endpoint"})
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.gameService.GetGameView(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get game state")
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// GetScoreboard godoc
+// @Summary Get game scoreboard
+// @Description Get the points captured by each player, their roles, and the winning team
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} domain.Scoreboard
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/scoreboard [get]
+func (h *GameHandler) GetScoreboard(c *gin.Context) {
+	scoreboard, err := h.gameService.GetScoreboard(c.Request.Context(), c.Param("gameId"))
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get scoreboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, scoreboard)
+}
+
+// ResumeGame godoc
+// @Summary Resume an in-progress game
+// @Description Rejoin a game after a disconnect and get the current state as seen by the caller
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} domain.GameView
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/resume [get]
+func (h *GameHandler) ResumeGame(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.gameService.ResumeGame(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to resume game")
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// GetCurrentGame godoc
+// @Summary Get a room's current game
+// @Description Get the ID of the most recent game started in the room and its state as seen by the caller, so players who only know their room can reconnect
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param roomId path string true "Room ID"
+// @Success 200 {object} gamedto.CurrentGameResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rooms/{roomId}/game [get]
+func (h *GameHandler) GetCurrentGame(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.gameService.GetCurrentGame(c.Request.Context(), c.Param("roomId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get current game")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.CurrentGameResponse{GameID: view.ID, State: view})
+}
+
+// GetLegalMoves godoc
+// @Summary Preview legal moves
+// @Description Get the formations the caller may play in the current trick, given their hand, the led formation and trump. Players waiting for their turn get an empty list.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} gamedto.LegalMovesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/legal-moves [get]
+func (h *GameHandler) GetLegalMoves(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	moves, err := h.gameService.GetLegalMoves(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get legal moves")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.LegalMovesResponse{Formations: moves})
+}
+
+// GetAllowedActions godoc
+// @Summary Get allowed actions
+// @Description Get the kinds of action the caller may take now, given the phase and whose turn it is, such as ["bid","pass"] or ["play"]. Players waiting for their turn get an empty list.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} gamedto.AllowedActionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/actions [get]
+func (h *GameHandler) GetAllowedActions(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	actions, err := h.gameService.GetAllowedActions(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get allowed actions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.AllowedActionsResponse{Actions: actions})
+}
+
+// GetTrickHistory godoc
+// @Summary Get trick history
+// @Description Get the completed tricks of the game in the order they were played, with each trick's winner, points and the cards played to it. The trick in progress is not included.
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} gamedto.TrickHistoryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/tricks [get]
+func (h *GameHandler) GetTrickHistory(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	tricks, err := h.gameService.GetTrickHistory(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get trick history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.TrickHistoryResponse{Tricks: tricks})
+}
+
+// GetBidHistory godoc
+// @Summary Get bid history
+// @Description Get the game's bids and passes in the order they were made, with each bidder's position and whether the server passed for them
+// @Tags game
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} gamedto.BidHistoryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /games/{gameId}/bids [get]
+func (h *GameHandler) GetBidHistory(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	bids, err := h.gameService.GetBidHistory(c.Request.Context(), c.Param("gameId"), userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get bid history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.BidHistoryResponse{Bids: bids})
+}
+
+// AbortGame godoc
+// @Summary Abort a game
+// @Description Terminate a stuck game without a result. The game is not counted in any player's stats, its room is released and the players are notified. The response summarizes the game without revealing any cards. Admin only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} gamedto.AbortGameResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/games/{gameId}/abort [post]
+func (h *GameHandler) AbortGame(c *gin.Context) {
+	state, err := h.gameService.AbortGame(c.Request.Context(), c.Param("gameId"))
+	if err != nil {
+		h.handleGameError(c, err, "Failed to abort game")
+		return
+	}
+
+	c.JSON(http.StatusOK, gamedto.NewAbortGameResponse(state))
+}
+
+// GetDealRecord godoc
+// @Summary Get a game's deal
+// @Description Get the seed a game's deck was shuffled with and the hands and kitty it deals, to investigate a disputed deal. Admin only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Success 200 {object} service.DealRecord
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/games/{gameId}/deal [get]
+func (h *GameHandler) GetDealRecord(c *gin.Context) {
+	record, err := h.gameService.GetDealRecord(c.Request.Context(), c.Param("gameId"))
+	if err != nil {
+		h.handleGameError(c, err, "Failed to get deal record")
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// ConnectWebSocket godoc
+// @Summary Connect to game updates
+// @Description Upgrade to a WebSocket that receives real-time updates for a game, starting with the caller's view of the current state. Closing the connection marks the player as disconnected. Browsers that cannot set the Authorization header may pass the access token in the token query parameter.
+// @Tags game
+// @Security BearerAuth
+// @Param gameId path string true "Game ID"
+// @Param token query string false "Access token, when the Authorization header cannot be set"
+// @Success 101
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /games/{gameId}/ws [get]
+func (h *GameHandler) ConnectWebSocket(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	gameID := c.Param("gameId")
+	view, err := h.gameService.ResumeGame(c.Request.Context(), gameID, userID)
+	if err != nil {
+		h.handleGameError(c, err, "Failed to join game")
+		return
+	}
+
+	welcome := ws.WSMessage{
+		Type:    ws.EventStateUpdate,
+		GameID:  gameID,
+		UserID:  userID,
+		Payload: view,
+	}
+	if err := h.hub.ServeWS(c.Writer, c.Request, gameID, userID, welcome); err != nil {
+		log.Printf("WebSocket upgrade failed for user %s: %v", userID, err)
+	}
+	// A player who reconnected keeps their chat limit
+	if !h.hub.IsConnected(userID) {
+		h.wsChat.forget(userID)
+	}
+}
+
+// requireUser returns the authenticated user's ID, responding with 401 if there is none
+func (h *GameHandler) requireUser(c *gin.Context) (string, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    "AUTHENTICATION_ERROR",
+			Message: "User not authenticated",
+			TraceID: c.GetString("trace_id"),
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// gameErrors maps game service errors to HTTP responses. Invalid moves wrap
+// the domain error saying why, which the default mappings try first.
+var gameErrors = apierror.Default.Extend(
+	apierror.Mapping{Err: service.ErrGameNotFound, Status: http.StatusNotFound, Code: apierror.CodeNotFound, Message: "Game not found"},
+	apierror.Mapping{Err: service.ErrRoomNotFound, Status: http.StatusNotFound, Code: apierror.CodeNotFound, Message: "Room not found"},
+	apierror.Mapping{Err: service.ErrNotRoomHost, Status: http.StatusForbidden, Code: apierror.CodeAuthorization, Message: "Only the room host can manage the room"},
+	apierror.Mapping{Err: service.ErrGameNotEnded, Status: http.StatusConflict, Code: apierror.CodeConflict, Message: "Game has not ended"},
+	apierror.Mapping{Err: service.ErrNoDealSeed, Status: http.StatusNotFound, Code: apierror.CodeNotFound, Message: "Game has no recorded deal"},
+	apierror.Mapping{Err: service.ErrGameOver, Status: http.StatusConflict, Code: apierror.CodeConflict, Message: "Game is already over"},
+	apierror.Mapping{Err: service.ErrIdempotencyKeyReused, Status: http.StatusConflict, Code: apierror.CodeConflict, Message: "Idempotency key was used for a different request"},
+	apierror.Mapping{Err: service.ErrInvalidMove, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid move", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrRoomNotFull, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Room is not ready for this action", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrRoomNotWaiting, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Room is not ready for this action", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrCannotKickSelf, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "The host cannot kick themselves", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrInvalidRoomName, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid room name", ShowDetails: true},
+	apierror.Mapping{Err: domain.ErrUnknownRulePreset, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Unknown rule preset", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrEmptyChatMessage, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid chat message", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrChatMessageTooLong, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid chat message", ShowDetails: true},
+	apierror.Mapping{Err: service.ErrNotRoomParticipant, Status: http.StatusForbidden, Code: apierror.CodeAuthorization, Message: "You are not a participant in this room"},
+	apierror.Mapping{Err: service.ErrParticipantNotFound, Status: http.StatusNotFound, Code: apierror.CodeNotFound, Message: "Player is not in this room"},
+	apierror.Mapping{Err: service.ErrNotParticipant, Status: http.StatusForbidden, Code: apierror.CodeAuthorization, Message: "You are not a participant in this game"},
+)
+
+// handleGameError maps game service errors to HTTP responses
+func (h *GameHandler) handleGameError(c *gin.Context, err error, message string) {
+	gameErrors.Respond(c, err, message)
 }
 
 func (h *GameHandler) HealthCheck(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status": "healthy",
-		"service": "game-service",
-	})
+	c.JSON(200, buildinfo.NewHealth("healthy", "game-service"))
 }
 
 func (h *GameHandler) ReadyCheck(c *gin.Context) {
@@ -70,4 +967,4 @@ func (h *GameHandler) ReadyCheck(c *gin.Context) {
 		"status": "ready",
 		"service": "game-service",
 	})
-}
\ No newline at end of file
+}
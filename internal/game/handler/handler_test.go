@@ -0,0 +1,1811 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	gamedto "chinese-bridge-game/internal/game/dto"
+	"chinese-bridge-game/internal/game/service"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGameService is a mock implementation of GameService
+type MockGameService struct {
+	mock.Mock
+}
+
+func (m *MockGameService) StartGame(ctx context.Context, roomID, userID string) (*domain.GameState, error) {
+	args := m.Called(ctx, roomID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) PlaceBid(ctx context.Context, gameID, userID string, amount int) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) PassBid(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) UndoBid(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) DeclareTrump(ctx context.Context, gameID, userID string, suit domain.Suit) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, suit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) CallPartnerCard(ctx context.Context, gameID, userID string, card domain.Card) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, card)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) ExchangeKitty(ctx context.Context, gameID, userID string, discards []domain.Card) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, discards)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) PlayCards(ctx context.Context, gameID, userID string, formation *domain.Formation) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, formation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) Concede(ctx context.Context, gameID, userID string, override bool) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID, userID, override)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) GetLegalMoves(ctx context.Context, gameID, userID string) ([]*domain.Formation, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Formation), args.Error(1)
+}
+
+func (m *MockGameService) HandleDisconnect(ctx context.Context, gameID, userID string) error {
+	args := m.Called(ctx, gameID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGameService) ResumeGame(ctx context.Context, gameID, userID string) (*domain.GameView, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameView), args.Error(1)
+}
+
+func (m *MockGameService) FinalizeGame(ctx context.Context, state *domain.GameState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockGameService) GetScoreboard(ctx context.Context, gameID string) (*domain.Scoreboard, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Scoreboard), args.Error(1)
+}
+
+func (m *MockGameService) GetCurrentGame(ctx context.Context, roomID, userID string) (*domain.GameView, error) {
+	args := m.Called(ctx, roomID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameView), args.Error(1)
+}
+
+func (m *MockGameService) GetTrickHistory(ctx context.Context, gameID, userID string) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *MockGameService) GetAllowedActions(ctx context.Context, gameID, userID string) ([]domain.Action, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Action), args.Error(1)
+}
+
+func (m *MockGameService) GetBidHistory(ctx context.Context, gameID, userID string) ([]domain.AnnotatedBid, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AnnotatedBid), args.Error(1)
+}
+
+func (m *MockGameService) GetGameView(ctx context.Context, gameID, userID string) (*domain.GameView, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameView), args.Error(1)
+}
+
+func (m *MockGameService) AbortGame(ctx context.Context, gameID string) (*domain.GameState, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GameState), args.Error(1)
+}
+
+func (m *MockGameService) GetDealRecord(ctx context.Context, gameID string) (*service.DealRecord, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.DealRecord), args.Error(1)
+}
+
+// MockRoomService is a mock implementation of RoomService
+type MockRoomService struct {
+	mock.Mock
+}
+
+func (m *MockRoomService) CreateRoom(ctx context.Context, hostID, name string, preset domain.RulePreset) (*database.Room, error) {
+	args := m.Called(ctx, hostID, name, preset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Room), args.Error(1)
+}
+
+func (m *MockRoomService) KickParticipant(ctx context.Context, roomID, hostID, targetUserID string) error {
+	args := m.Called(ctx, roomID, hostID, targetUserID)
+	return args.Error(0)
+}
+
+func (m *MockRoomService) Rematch(ctx context.Context, gameID, userID string) (*database.Room, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Room), args.Error(1)
+}
+
+func (m *MockRoomService) PostMessage(ctx context.Context, roomID, userID, text string) (*gamedto.ChatMessage, error) {
+	args := m.Called(ctx, roomID, userID, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gamedto.ChatMessage), args.Error(1)
+}
+
+func (m *MockRoomService) GetMessages(ctx context.Context, roomID, userID string, offset, limit int) ([]gamedto.ChatMessage, error) {
+	args := m.Called(ctx, roomID, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]gamedto.ChatMessage), args.Error(1)
+}
+
+func setupTestRouter(gameService service.GameService) *gin.Engine {
+	return setupTestRouterWithRooms(gameService, &MockRoomService{})
+}
+
+func setupTestRouterWithRooms(gameService service.GameService, roomService service.RoomService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Add trace ID and authenticated user for testing
+	router.Use(func(c *gin.Context) {
+		c.Set("trace_id", "test-trace-id")
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewGameHandler(gameService, roomService, ws.NewHub(nil))
+	api := router.Group("/api/v1")
+	handler.RegisterRoutes(api)
+	handler.RegisterAdminRoutes(api)
+
+	return router
+}
+
+func TestGameHandler_GetScoreboard_Success(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	winnerTeam := "defenders"
+	scoreboard := &domain.Scoreboard{
+		GameID:          "game-1",
+		Phase:           domain.PhaseEnded,
+		Contract:        100,
+		DefendersPoints: 110,
+		WinnerTeam:      &winnerTeam,
+		Players: []domain.PlayerScore{
+			{PlayerID: "north", Position: domain.North, Role: domain.RoleDeclarer, PointsCaptured: 40},
+			{PlayerID: "east", Position: domain.East, Role: domain.RoleDefender, PointsCaptured: 60},
+			{PlayerID: "south", Position: domain.South, Role: domain.RolePartner, PointsCaptured: 0},
+			{PlayerID: "west", Position: domain.West, Role: domain.RoleDefender, PointsCaptured: 50},
+		},
+	}
+	mockService.On("GetScoreboard", mock.Anything, "game-1").Return(scoreboard, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/scoreboard", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.Scoreboard
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "defenders", *response.WinnerTeam)
+	assert.Len(t, response.Players, 4)
+	assert.Equal(t, 60, response.Players[1].PointsCaptured)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetScoreboard_NotFound(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetScoreboard", mock.Anything, "missing").Return(nil, service.ErrGameNotFound)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/missing/scoreboard", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "NOT_FOUND", response.Code)
+	assert.Equal(t, "test-trace-id", response.TraceID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetScoreboard_GameInProgress(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetScoreboard", mock.Anything, "live-game").Return(nil, service.ErrGameNotEnded)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/live-game/scoreboard", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "CONFLICT", response.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetGameState(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	view := &domain.GameView{
+		ID:                "game-1",
+		Phase:             domain.PhasePlaying,
+		Position:          domain.South,
+		Hand:              []domain.Card{domain.NewCard(domain.Spades, domain.Ace, 1)},
+		Players: []domain.PlayerView{
+			{PlayerPublic: domain.PlayerPublic{ID: "north", Position: domain.North, HandSize: 25}},
+		},
+		CurrentPlayerTurn: domain.North,
+	}
+	mockService.On("GetGameView", mock.Anything, "game-1", "south").Return(view, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1", nil)
+	req.Header.Set("X-Test-User", "south")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.GameView
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.South, response.Position)
+	assert.Equal(t, view.Hand, response.Hand)
+	if assert.Len(t, response.Players, 1) {
+		assert.Equal(t, 25, response.Players[0].HandSize)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetGameState_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"Non-participant", "stranger", service.ErrNotParticipant, http.StatusForbidden, "AUTHORIZATION_ERROR"},
+		{"Unknown game", "south", service.ErrGameNotFound, http.StatusNotFound, "NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+			mockService.On("GetGameView", mock.Anything, "game-1", tt.userID).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("GET", "/api/v1/games/game-1", nil)
+			req.Header.Set("X-Test-User", tt.userID)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var response dto.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, response.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_GetGameState_Unauthenticated(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "GetGameView", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGameHandler_ResumeGame_Participant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	view := &domain.GameView{
+		ID:                "game-1",
+		Phase:             domain.PhasePlaying,
+		Position:          domain.East,
+		Hand:              []domain.Card{domain.NewCard(domain.Clubs, domain.Three, 1)},
+		CurrentPlayerTurn: domain.North,
+	}
+	mockService.On("ResumeGame", mock.Anything, "game-1", "east").Return(view, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/resume", nil)
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.GameView
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.East, response.Position)
+	assert.Equal(t, domain.PhasePlaying, response.Phase)
+	assert.Len(t, response.Hand, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_ResumeGame_NonParticipant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("ResumeGame", mock.Anything, "game-1", "stranger").Return(nil, service.ErrNotParticipant)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/resume", nil)
+	req.Header.Set("X-Test-User", "stranger")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "AUTHORIZATION_ERROR", response.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetCurrentGame(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	view := &domain.GameView{ID: "game-1", RoomID: "room-1", Phase: domain.PhaseBidding, Position: domain.South}
+	mockService.On("GetCurrentGame", mock.Anything, "room-1", "south").Return(view, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/rooms/room-1/game", nil)
+	req.Header.Set("X-Test-User", "south")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response gamedto.CurrentGameResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "game-1", response.GameID)
+	assert.Equal(t, domain.South, response.State.Position)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetCurrentGame_Rejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"No game started", "south", service.ErrGameNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"Non-participant", "stranger", service.ErrNotParticipant, http.StatusForbidden, "AUTHORIZATION_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+			mockService.On("GetCurrentGame", mock.Anything, "room-1", tt.userID).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("GET", "/api/v1/rooms/room-1/game", nil)
+			req.Header.Set("X-Test-User", tt.userID)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var response dto.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, response.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_GetTrickHistory(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	history := []map[string]interface{}{{"number": 1, "winner": "North"}}
+	mockService.On("GetTrickHistory", mock.Anything, "game-1", "south").Return(history, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/tricks", nil)
+	req.Header.Set("X-Test-User", "south")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response gamedto.TrickHistoryResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Tricks, 1)
+	assert.Equal(t, "North", response.Tricks[0]["winner"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetTrickHistory_NonParticipant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("GetTrickHistory", mock.Anything, "game-1", "stranger").Return(nil, service.ErrNotParticipant)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/tricks", nil)
+	req.Header.Set("X-Test-User", "stranger")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetBidHistory(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	bids := []domain.AnnotatedBid{
+		{Turn: 1, PlayerID: "north", Position: domain.North, Amount: 120},
+		{Turn: 2, PlayerID: "east", Position: domain.East, IsPassed: true, IsAuto: true},
+	}
+	mockService.On("GetBidHistory", mock.Anything, "game-1", "south").Return(bids, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/bids", nil)
+	req.Header.Set("X-Test-User", "south")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response gamedto.BidHistoryResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, bids, response.Bids)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetBidHistory_NonParticipant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("GetBidHistory", mock.Anything, "game-1", "stranger").Return(nil, service.ErrNotParticipant)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/bids", nil)
+	req.Header.Set("X-Test-User", "stranger")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_AbortGame_HidesCards(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+	assert.NoError(t, state.DealCards(domain.NewDeck()))
+	assert.NoError(t, state.Abort())
+	mockService.On("AbortGame", mock.Anything, "game-1").Return(state, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/games/game-1/abort", nil)
+	req.Header.Set("X-Test-User", "admin")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"hand"`)
+	assert.NotContains(t, w.Body.String(), `"kitty"`)
+
+	var response gamedto.AbortGameResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "game-1", response.GameID)
+	assert.Equal(t, domain.PhaseAborted, response.Phase)
+	if assert.Len(t, response.Players, 4) {
+		assert.Equal(t, 25, response.Players[0].HandSize)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_AbortGame_AlreadyOver(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("AbortGame", mock.Anything, "game-1").Return(nil, service.ErrGameOver)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/games/game-1/abort", nil)
+	req.Header.Set("X-Test-User", "admin")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_ResumeGame_Unauthenticated(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/resume", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "ResumeGame", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGameHandler_StartGame_Host(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+	assert.NoError(t, state.DealCards(domain.NewDeck()))
+	mockService.On("StartGame", mock.Anything, "room-1", "north").Return(state, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/start", nil)
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.GameView
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PhaseBidding, response.Phase)
+	assert.Equal(t, domain.North, response.Position)
+	assert.Len(t, response.Hand, 25)
+	assert.Empty(t, response.Kitty, "the kitty stays hidden until bidding is won")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_StartGame_NonHost(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("StartGame", mock.Anything, "room-1", "east").Return(nil, service.ErrNotRoomHost)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/start", nil)
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "AUTHORIZATION_ERROR", response.Code)
+}
+
+func TestGameHandler_StartGame_UnderFilledRoom(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("StartGame", mock.Anything, "room-1", "north").Return(nil, service.ErrRoomNotFull)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/start", nil)
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "VALIDATION_ERROR", response.Code)
+}
+
+func TestGameHandler_PlaceBid_PassesIdempotencyKey(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+	assert.NoError(t, state.DealCards(domain.NewDeck()))
+	assert.NoError(t, state.PlaceBid("north", 120))
+
+	withKey := mock.MatchedBy(func(ctx context.Context) bool {
+		return service.IdempotencyKeyFrom(ctx) == "bid-1"
+	})
+	mockService.On("PlaceBid", withKey, "game-1", "north", 120).Return(state, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/bid", strings.NewReader(`{"amount":120}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "bid-1")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.GameView
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.East, response.CurrentPlayerTurn)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_PlaceBid_ReusedIdempotencyKey(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("PlaceBid", mock.Anything, "game-1", "north", 125).Return(nil, service.ErrIdempotencyKeyReused)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/bid", strings.NewReader(`{"amount":125}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "bid-1")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "CONFLICT", response.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_PlaceBid_InvalidMove(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("PlaceBid", mock.Anything, "game-1", "east", 120).
+		Return(nil, fmt.Errorf("%w: %w", service.ErrInvalidMove, domain.ErrInvalidBid))
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/bid", strings.NewReader(`{"amount":120}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "VALIDATION_ERROR", response.Code)
+}
+
+func TestGameHandler_PlaceBid_WrongPhase(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("PlaceBid", mock.Anything, "game-1", "north", 120).
+		Return(nil, fmt.Errorf("%w: %w: not in bidding phase", service.ErrInvalidMove, domain.ErrWrongPhase))
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/bid", strings.NewReader(`{"amount":120}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dto.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "CONFLICT", response.Code)
+}
+
+func TestGameHandler_ActionRequestValidation(t *testing.T) {
+	sevenCards := make([]domain.Card, 7)
+	for i := range sevenCards {
+		sevenCards[i] = domain.NewCard(domain.Clubs, domain.Rank(int(domain.Three)+i), 1)
+	}
+	kitty, err := json.Marshal(gamedto.ExchangeKittyRequest{Discard: domain.EncodeCards(sevenCards)})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"Bid without amount or pass", "bid", `{}`},
+		{"Negative bid", "bid", `{"amount":-5}`},
+		{"Trump without suit", "trump", `{}`},
+		{"Unknown trump suit", "trump", `{"suit":"Stars"}`},
+		{"Kitty without cards", "kitty", `{}`},
+		{"Kitty with too few cards", "kitty", string(kitty)},
+		{"Kitty with an unknown card code", "kitty", `{"discard":["C31","C41","C51","C61","C71","C81","C91","ZZ1"]}`},
+		{"Play without cards", "play", `{"type":"Single"}`},
+		{"Play without type", "play", `{"cards":["HK1"]}`},
+		{"Play with an unknown formation type", "play", `{"cards":["HK1"],"type":"Triple"}`},
+		{"Malformed JSON", "play", `{"cards":`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/game-1/"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response dto.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "VALIDATION_ERROR", response.Code)
+
+			mockService.AssertNotCalled(t, "PlaceBid", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "DeclareTrump", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "ExchangeKitty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "PlayCards", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGameHandler_ActionBindingErrorDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		details string
+	}{
+		{"Bid with a non-integer amount", "bid", `{"amount":"lots"}`, "amount must be an integer, got string"},
+		{"Play with an unknown card code", "play", `{"cards":["XA1"],"type":"Single"}`, `invalid card code: "XA1"`},
+		{"Trump with an unknown suit", "trump", `{"suit":"Stars"}`, "unknown suit: Stars"},
+		{"Bid without amount or pass", "bid", `{}`, "amount is required"},
+		{"Negative bid", "bid", `{"amount":-5}`, "amount must be at least 0"},
+		{"Trump without suit", "trump", `{}`, "suit is required"},
+		{"Kitty with too few cards", "kitty", `{"discard":["C31"]}`, "discard must have exactly 8 items"},
+		{"Empty body", "play", ``, "request body is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/game-1/"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response dto.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "VALIDATION_ERROR", response.Code)
+			assert.Equal(t, tt.details, response.Details)
+			assert.Equal(t, "test-trace-id", response.TraceID)
+		})
+	}
+}
+
+func TestGameHandler_PlaceBid_Pass(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+	mockService.On("PassBid", mock.Anything, "game-1", "north").Return(state, nil)
+
+	// A pass needs no amount
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/bid", strings.NewReader(`{"pass":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_Concede(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    string
+		override bool
+	}{
+		{"Player concession", "", false},
+		{"Host override", "?override=true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+			mockService.On("Concede", mock.Anything, "game-1", "north", tt.override).Return(state, nil)
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/game-1/concede"+tt.query, nil)
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_Concede_OverrideByNonHost(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("Concede", mock.Anything, "game-1", "east", true).Return(nil, service.ErrNotRoomHost)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/concede?override=true", nil)
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGameHandler_Concede_InvalidOverride(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/concede?override=maybe", nil)
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Concede", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGameHandler_DeclareTrump_MapsSuitNames(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	suits := map[string]domain.Suit{
+		"Spades":   domain.Spades,
+		"Hearts":   domain.Hearts,
+		"Clubs":    domain.Clubs,
+		"Diamonds": domain.Diamonds,
+		"No Trump": domain.NoTrump,
+	}
+	for name, suit := range suits {
+		t.Run(name, func(t *testing.T) {
+			mockService := &MockGameService{}
+			router := setupTestRouter(mockService)
+			mockService.On("DeclareTrump", mock.Anything, "game-1", "north", suit).Return(state, nil)
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/game-1/trump", strings.NewReader(`{"suit":"`+name+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_ExchangeKitty_DecodesCardCodes(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	discard := []string{"C31", "C41", "C51", "C61", "D31", "D41", "D51", "BJ2"}
+	cards, err := domain.DecodeCards(discard)
+	assert.NoError(t, err)
+
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("ExchangeKitty", mock.Anything, "game-1", "north", cards).Return(state, nil)
+
+	body, err := json.Marshal(gamedto.ExchangeKittyRequest{Discard: discard})
+	assert.NoError(t, err)
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/kitty", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_PlayCards_DecodesCardCodes(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	formation := &domain.Formation{
+		Type:  domain.Pair,
+		Cards: []domain.Card{domain.NewCard(domain.Hearts, domain.King, 1), domain.NewCard(domain.Hearts, domain.King, 2)},
+	}
+
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("PlayCards", mock.Anything, "game-1", "north", formation).Return(state, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/play", strings.NewReader(`{"cards":["HK1","HK2"],"type":"Pair"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_CallPartnerCard(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("CallPartnerCard", mock.Anything, "game-1", "north", domain.NewCard(domain.Clubs, domain.Ace, 1)).Return(state, nil)
+
+	body := `{"card":{"suit":"Clubs","rank":"A","deck_id":1}}`
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/partner-card", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_CallPartnerCard_HeldCard(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+	mockService.On("CallPartnerCard", mock.Anything, "game-1", "north", mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", service.ErrInvalidMove, domain.ErrInvalidPartnerCard))
+
+	body := `{"card":{"suit":"Spades","rank":"A","deck_id":1}}`
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/partner-card", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGameHandler_CallPartnerCard_MissingCard(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/game-1/partner-card", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CallPartnerCard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGameHandler_GetLegalMoves_Success(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	moves := []*domain.Formation{
+		domain.NewSingle(domain.NewCard(domain.Spades, domain.Three, 1)),
+		domain.NewSingle(domain.NewCard(domain.Spades, domain.Nine, 1)),
+	}
+	mockService.On("GetLegalMoves", mock.Anything, "game-1", "east").Return(moves, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/legal-moves", nil)
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response gamedto.LegalMovesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Formations, 2)
+	assert.Equal(t, domain.Nine, response.Formations[1].Cards[0].Rank)
+}
+
+func TestGameHandler_ValidateFormation(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		cards  []domain.Card
+		suit   domain.Suit
+		points int
+	}{
+		{
+			"Single",
+			`{"cards":[{"suit":"Hearts","rank":"K","deck_id":1}],"type":"Single","trump":"Spades"}`,
+			[]domain.Card{domain.NewCard(domain.Hearts, domain.King, 1)},
+			domain.Hearts, 10,
+		},
+		{
+			"PairOfJokers",
+			`{"cards":[{"is_joker":true,"joker_type":"Small Joker","deck_id":2},{"is_joker":true,"joker_type":"Small Joker","deck_id":1}],"type":"Pair","trump":"Clubs"}`,
+			[]domain.Card{domain.NewJoker(domain.SmallJoker, 1), domain.NewJoker(domain.SmallJoker, 2)},
+			domain.Clubs, 0,
+		},
+		{
+			"Tractor",
+			`{"cards":[{"suit":"Diamonds","rank":"5","deck_id":1},{"suit":"Diamonds","rank":"4","deck_id":1},{"suit":"Diamonds","rank":"5","deck_id":2},{"suit":"Diamonds","rank":"4","deck_id":2}],"type":"Tractor","trump":"No Trump"}`,
+			[]domain.Card{
+				domain.NewCard(domain.Diamonds, domain.Five, 1), domain.NewCard(domain.Diamonds, domain.Five, 2),
+				domain.NewCard(domain.Diamonds, domain.Four, 1), domain.NewCard(domain.Diamonds, domain.Four, 2),
+			},
+			domain.Diamonds, 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter(&MockGameService{})
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/validate-formation", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response gamedto.ValidateFormationResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.True(t, response.Valid, response.Error)
+			if assert.NotNil(t, response.Formation) {
+				assert.Equal(t, tt.cards, response.Formation.Cards)
+				assert.Equal(t, tt.suit, response.Formation.Suit)
+			}
+			assert.Equal(t, tt.points, response.Points)
+		})
+	}
+}
+
+func TestGameHandler_GetCardLegend(t *testing.T) {
+	router := setupTestRouter(&MockGameService{})
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/rules/cards", nil)
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var legend domain.CardLegend
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &legend))
+	assert.Len(t, legend.PointValues, int(domain.Ace-domain.Two)+1)
+	for _, value := range legend.PointValues {
+		assert.Equal(t, value.Rank.GetPointValue(), value.Points, value.Rank.String())
+	}
+
+	trumps := []domain.Card{
+		domain.NewJoker(domain.BigJoker, 1),
+		domain.NewJoker(domain.SmallJoker, 1),
+		domain.NewCard(domain.Hearts, domain.Two, 1),
+		domain.NewCard(domain.Clubs, domain.Two, 1),
+		domain.NewCard(domain.Hearts, domain.Ace, 1),
+	}
+	if !assert.GreaterOrEqual(t, len(legend.TrumpOrder), len(trumps)) {
+		return
+	}
+	for i, card := range trumps {
+		assert.Equal(t, card.GetTrumpHierarchy(domain.Hearts), legend.TrumpOrder[i].Strength, legend.TrumpOrder[i].Description)
+	}
+}
+
+func TestGameHandler_ValidateFormation_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"UnmatchedPair", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1},{"suit":"Hearts","rank":"Q","deck_id":1}],"type":"Pair","trump":"Spades"}`},
+		{"SameCardTwice", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1},{"suit":"Hearts","rank":"K","deck_id":1}],"type":"Pair","trump":"Spades"}`},
+		{"GappedTractor", `{"cards":[{"suit":"Clubs","rank":"9","deck_id":1},{"suit":"Clubs","rank":"9","deck_id":2},{"suit":"Clubs","rank":"J","deck_id":1},{"suit":"Clubs","rank":"J","deck_id":2}],"type":"Tractor","trump":"Spades"}`},
+		{"TractorOfTwos", `{"cards":[{"suit":"Clubs","rank":"2","deck_id":1},{"suit":"Clubs","rank":"2","deck_id":2},{"suit":"Clubs","rank":"3","deck_id":1},{"suit":"Clubs","rank":"3","deck_id":2}],"type":"Tractor","trump":"Spades"}`},
+		{"PairClaimedAsSingle", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1},{"suit":"Hearts","rank":"K","deck_id":2}],"type":"Single","trump":"Spades"}`},
+		{"UnknownSuitNumber", `{"cards":[{"suit":9,"rank":"K","deck_id":1}],"type":"Single","trump":"Spades"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter(&MockGameService{})
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/validate-formation", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response gamedto.ValidateFormationResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Valid)
+			assert.NotEmpty(t, response.Error)
+			assert.Nil(t, response.Formation)
+		})
+	}
+}
+
+func TestGameHandler_ValidateFormation_BadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"NoCards", `{"cards":[],"type":"Single","trump":"Spades"}`},
+		{"MissingType", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1}],"trump":"Spades"}`},
+		{"MissingTrump", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1}],"type":"Single"}`},
+		{"UnknownType", `{"cards":[{"suit":"Hearts","rank":"K","deck_id":1}],"type":"Triple","trump":"Spades"}`},
+		{"UnknownRank", `{"cards":[{"suit":"Hearts","rank":"Z","deck_id":1}],"type":"Single","trump":"Spades"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter(&MockGameService{})
+
+			req, _ := http.NewRequest("POST", "/api/v1/games/validate-formation", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGameHandler_GetLegalMoves_NonParticipant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetLegalMoves", mock.Anything, "game-1", "stranger").Return(nil, service.ErrNotParticipant)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/legal-moves", nil)
+	req.Header.Set("X-Test-User", "stranger")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGameHandler_GetAllowedActions(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetAllowedActions", mock.Anything, "game-1", "north").Return([]domain.Action{domain.ActionBid, domain.ActionPass}, nil)
+	mockService.On("GetAllowedActions", mock.Anything, "game-1", "east").Return([]domain.Action{}, nil)
+
+	tests := []struct {
+		userID string
+		body   string
+	}{
+		{"north", `{"actions":["bid","pass"]}`},
+		{"east", `{"actions":[]}`},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", "/api/v1/games/game-1/actions", nil)
+		req.Header.Set("X-Test-User", tt.userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, tt.body, w.Body.String())
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_GetAllowedActions_NonParticipant(t *testing.T) {
+	mockService := &MockGameService{}
+	router := setupTestRouter(mockService)
+
+	mockService.On("GetAllowedActions", mock.Anything, "game-1", "stranger").Return(nil, service.ErrNotParticipant)
+
+	req, _ := http.NewRequest("GET", "/api/v1/games/game-1/actions", nil)
+	req.Header.Set("X-Test-User", "stranger")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGameHandler_KickParticipant(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"Kicked", nil, http.StatusNoContent},
+		{"Non-host", service.ErrNotRoomHost, http.StatusForbidden},
+		{"Absent player", service.ErrParticipantNotFound, http.StatusNotFound},
+		{"Host kicking themselves", service.ErrCannotKickSelf, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomService := &MockRoomService{}
+			router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+			roomService.On("KickParticipant", mock.Anything, "room-1", "north", "east").Return(tt.err)
+
+			req, _ := http.NewRequest("DELETE", "/api/v1/rooms/room-1/participants/east", nil)
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			roomService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_CreateRoom(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	room := &database.Room{ID: "room-1", Name: "Friday night bridge", HostID: "north", Status: database.RoomStatusWaiting}
+	roomService.On("CreateRoom", mock.Anything, "north", "  Friday night bridge ", domain.RulePreset("")).Return(room, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms", strings.NewReader(`{"name":"  Friday night bridge "}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response database.Room
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Friday night bridge", response.Name)
+	roomService.AssertExpectations(t)
+}
+
+func TestGameHandler_CreateRoom_Preset(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	room := &database.Room{ID: "room-1", Name: "Fast", HostID: "north", Status: database.RoomStatusWaiting}
+	roomService.On("CreateRoom", mock.Anything, "north", "Fast", domain.NoTrumpFastPreset).Return(room, nil)
+	roomService.On("CreateRoom", mock.Anything, "north", "Fast", domain.RulePreset("anything_goes")).
+		Return(nil, fmt.Errorf("%w: %q", domain.ErrUnknownRulePreset, "anything_goes"))
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms", strings.NewReader(`{"name":"Fast","preset":"no_trump_fast"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req, _ = http.NewRequest("POST", "/api/v1/rooms", strings.NewReader(`{"name":"Fast","preset":"anything_goes"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dto.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Code)
+	roomService.AssertExpectations(t)
+}
+
+func TestGameHandler_Rematch(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	room := &database.Room{ID: "room-2", Name: "Test Room", HostID: "east", Status: database.RoomStatusWaiting}
+	roomService.On("Rematch", mock.Anything, "finished-game", "south").Return(room, nil)
+	roomService.On("Rematch", mock.Anything, "live-game", "south").Return(nil, service.ErrGameNotEnded)
+
+	req, _ := http.NewRequest("POST", "/api/v1/games/finished-game/rematch", nil)
+	req.Header.Set("X-Test-User", "south")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response database.Room
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "room-2", response.ID)
+
+	req, _ = http.NewRequest("POST", "/api/v1/games/live-game/rematch", nil)
+	req.Header.Set("X-Test-User", "south")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	roomService.AssertExpectations(t)
+}
+
+func TestGameHandler_CreateRoom_InvalidName(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Empty", `{"name":""}`},
+		{"Missing", `{}`},
+		{"Oversized", `{"name":"` + strings.Repeat("a", 65) + `"}`},
+		{"Blank", `{"name":"   "}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomService := &MockRoomService{}
+			router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+			// Names that pass binding are still checked by the service once trimmed
+			roomService.On("CreateRoom", mock.Anything, "north", "   ", domain.RulePreset("")).
+				Return(nil, fmt.Errorf("%w: name is required", service.ErrInvalidRoomName))
+
+			req, _ := http.NewRequest("POST", "/api/v1/rooms", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Test-User", "north")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response dto.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "VALIDATION_ERROR", response.Code)
+		})
+	}
+}
+
+func TestGameHandler_PostMessage(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	message := &gamedto.ChatMessage{ID: "message-1", RoomID: "room-1", UserID: "north", Text: "Good luck!"}
+	roomService.On("PostMessage", mock.Anything, "room-1", "north", "Good luck!").Return(message, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/messages", strings.NewReader(`{"text":"Good luck!"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response gamedto.ChatMessage
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "message-1", response.ID)
+}
+
+func TestGameHandler_PostMessage_Oversized(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	roomService.On("PostMessage", mock.Anything, "room-1", "north", mock.Anything).Return(nil, service.ErrChatMessageTooLong)
+
+	req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/messages", strings.NewReader(`{"text":"too long"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "north")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGameHandler_PostMessage_TooFrequent(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	roomService.On("PostMessage", mock.Anything, "room-1", mock.Anything, "spam").Return(&gamedto.ChatMessage{Text: "spam"}, nil)
+
+	post := func(userID string) int {
+		req, _ := http.NewRequest("POST", "/api/v1/rooms/room-1/messages", strings.NewReader(`{"text":"spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < chatBurst; i++ {
+		assert.Equal(t, http.StatusCreated, post("north"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, post("north"))
+
+	// Other players have their own allowance
+	assert.Equal(t, http.StatusCreated, post("east"))
+	roomService.AssertNumberOfCalls(t, "PostMessage", chatBurst+1)
+}
+
+func TestGameHandler_GetMessages(t *testing.T) {
+	roomService := &MockRoomService{}
+	router := setupTestRouterWithRooms(&MockGameService{}, roomService)
+
+	messages := []gamedto.ChatMessage{{ID: "message-1", Text: "one"}, {ID: "message-2", Text: "two"}}
+	roomService.On("GetMessages", mock.Anything, "room-1", "east", 10, 2).Return(messages, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/rooms/room-1/messages?limit=2&offset=10", nil)
+	req.Header.Set("X-Test-User", "east")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response gamedto.ChatMessagesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "one", response.Messages[0].Text)
+	assert.Equal(t, "two", response.Messages[1].Text)
+
+	req, _ = http.NewRequest("GET", "/api/v1/rooms/room-1/messages?limit=1000", nil)
+	req.Header.Set("X-Test-User", "east")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// recordingConn records the messages a hub writes to a player in place of a
+// real WebSocket
+type recordingConn struct {
+	messages []ws.WSMessage
+}
+
+func (c *recordingConn) WriteJSON(v interface{}) error {
+	c.messages = append(c.messages, v.(ws.WSMessage))
+	return nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func TestGameHandler_HandleWSMessage(t *testing.T) {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		message ws.WSMessage
+		setup   func(gameService *MockGameService, roomService *MockRoomService)
+		reply   string
+		code    string // Error code of an error reply
+	}{
+		{
+			name:    "Bid",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{"amount": 120}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				gameService.On("PlaceBid", mock.Anything, "game-1", "north", 120).Return(state, nil)
+			},
+			reply: ws.EventStateUpdate,
+		},
+		{
+			name:    "Pass",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{"pass": true}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				gameService.On("PassBid", mock.Anything, "game-1", "north").Return(state, nil)
+			},
+			reply: ws.EventStateUpdate,
+		},
+		{
+			name:    "Play",
+			message: ws.WSMessage{Type: ws.MessagePlay, Payload: map[string]interface{}{"cards": []string{"HK1"}, "type": "Single"}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				formation := &domain.Formation{Type: domain.Single, Cards: []domain.Card{domain.NewCard(domain.Hearts, domain.King, 1)}}
+				gameService.On("PlayCards", mock.Anything, "game-1", "north", formation).Return(state, nil)
+			},
+			reply: ws.EventStateUpdate,
+		},
+		{
+			name:    "Rejected move",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{"amount": 120}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				gameService.On("PlaceBid", mock.Anything, "game-1", "north", 120).Return(nil, service.ErrInvalidMove)
+			},
+			reply: ws.EventError,
+			code:  "VALIDATION_ERROR",
+		},
+		{
+			name:    "Out of turn",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{"amount": 120}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				gameService.On("PlaceBid", mock.Anything, "game-1", "north", 120).
+					Return(nil, fmt.Errorf("%w: %w", service.ErrInvalidMove, domain.ErrNotYourTurn))
+			},
+			reply: ws.EventError,
+			code:  "CONFLICT",
+		},
+		{
+			name:    "Internal error",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{"amount": 120}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				gameService.On("PlaceBid", mock.Anything, "game-1", "north", 120).Return(nil, errors.New("redis: connection refused"))
+			},
+			reply: ws.EventError,
+			code:  "INTERNAL_ERROR",
+		},
+		{
+			name:    "Play with an unknown card code",
+			message: ws.WSMessage{Type: ws.MessagePlay, Payload: map[string]interface{}{"cards": []string{"XA1"}, "type": "Single"}},
+			reply:   ws.EventError,
+			code:    "VALIDATION_ERROR",
+		},
+		{
+			name:    "Bid without amount or pass",
+			message: ws.WSMessage{Type: ws.MessageBid, Payload: map[string]interface{}{}},
+			reply:   ws.EventError,
+			code:    "VALIDATION_ERROR",
+		},
+		{
+			name:    "Chat",
+			message: ws.WSMessage{Type: ws.MessageChat, RoomID: "room-1", Payload: map[string]interface{}{"text": "Good luck!"}},
+			setup: func(gameService *MockGameService, roomService *MockRoomService) {
+				roomService.On("PostMessage", mock.Anything, "room-1", "north", "Good luck!").Return(&gamedto.ChatMessage{ID: "message-1"}, nil)
+			},
+		},
+		{
+			name:    "Chat without room",
+			message: ws.WSMessage{Type: ws.MessageChat, Payload: map[string]interface{}{"text": "Good luck!"}},
+			reply:   ws.EventError,
+			code:    "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService := &MockGameService{}
+			roomService := &MockRoomService{}
+			if tt.setup != nil {
+				tt.setup(gameService, roomService)
+			}
+
+			hub := ws.NewHub(nil)
+			conn := &recordingConn{}
+			hub.Register("game-1", "north", conn)
+			handler := NewGameHandler(gameService, roomService, hub)
+
+			handler.HandleWSMessage(context.Background(), "game-1", "north", tt.message)
+
+			gameService.AssertExpectations(t)
+			roomService.AssertExpectations(t)
+			if tt.reply == "" {
+				assert.Empty(t, conn.messages)
+				return
+			}
+			if assert.Len(t, conn.messages, 1) {
+				assert.Equal(t, tt.reply, conn.messages[0].Type)
+			}
+			if tt.code != "" {
+				payload := conn.messages[0].Payload.(ws.ErrorPayload)
+				assert.Equal(t, tt.code, payload.Code)
+				assert.NotContains(t, payload.Details, "redis", "internal errors are not sent to players")
+			}
+		})
+	}
+}
+
+func TestGameHandler_HandleWSMessage_RateLimitsChat(t *testing.T) {
+	roomService := &MockRoomService{}
+	roomService.On("PostMessage", mock.Anything, "room-1", "north", "hi").Return(&gamedto.ChatMessage{ID: "message-1"}, nil)
+
+	hub := ws.NewHub(nil)
+	conn := &recordingConn{}
+	hub.Register("game-1", "north", conn)
+	handler := NewGameHandler(&MockGameService{}, roomService, hub)
+
+	message := ws.WSMessage{Type: ws.MessageChat, RoomID: "room-1", Payload: map[string]interface{}{"text": "hi"}}
+	for i := 0; i < chatBurst+1; i++ {
+		handler.HandleWSMessage(context.Background(), "game-1", "north", message)
+	}
+
+	roomService.AssertNumberOfCalls(t, "PostMessage", chatBurst)
+	if assert.Len(t, conn.messages, 1) {
+		assert.Equal(t, ws.EventError, conn.messages[0].Type)
+		assert.Equal(t, "RATE_LIMIT_EXCEEDED", conn.messages[0].Payload.(ws.ErrorPayload).Code)
+	}
+}
+
+func TestGameHandler_ConnectWebSocket_EndsConnectionState(t *testing.T) {
+	gameService := &MockGameService{}
+	gameService.On("ResumeGame", mock.Anything, "game-1", "north").Return(&domain.GameView{ID: "game-1"}, nil)
+
+	// The context a message is handled with is captured to check it ends with the connection
+	handled := make(chan context.Context, 1)
+	roomService := &MockRoomService{}
+	roomService.On("PostMessage", mock.Anything, "room-1", "north", "hi").Run(func(args mock.Arguments) {
+		handled <- args.Get(0).(context.Context)
+	}).Return(&gamedto.ChatMessage{ID: "message-1"}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	hub := ws.NewHub(nil)
+	handler := NewGameHandler(gameService, roomService, hub)
+	hub.SetMessageHandler(handler.HandleWSMessage)
+	handler.RegisterWebSocketRoutes(router.Group("/api/v1"))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/games/game-1/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"X-Test-User": {"north"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var welcome ws.WSMessage
+	assert.NoError(t, conn.ReadJSON(&welcome))
+	assert.NoError(t, conn.WriteJSON(ws.WSMessage{Type: ws.MessageChat, RoomID: "room-1", Payload: map[string]string{"text": "hi"}}))
+
+	var ctx context.Context
+	select {
+	case ctx = <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("chat message was not handled")
+	}
+	assert.NoError(t, ctx.Err())
+
+	conn.Close()
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, 10*time.Millisecond,
+		"the message context is cancelled when the connection closes")
+	assert.Eventually(t, func() bool {
+		handler.wsChat.mu.Lock()
+		defer handler.wsChat.mu.Unlock()
+		return len(handler.wsChat.limiters) == 0
+	}, time.Second, 10*time.Millisecond, "the chat limiter is dropped with the connection")
+}
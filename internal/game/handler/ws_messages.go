@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"chinese-bridge-game/internal/game/domain"
+	gamedto "chinese-bridge-game/internal/game/dto"
+	"chinese-bridge-game/internal/game/ws"
+	"chinese-bridge-game/pkg/apierror"
+
+	"github.com/gin-gonic/gin/binding"
+	"golang.org/x/time/rate"
+)
+
+// errChatRateLimited is returned when a player sends chat messages over their
+// WebSocket faster than the HTTP chat endpoint allows
+var errChatRateLimited = errors.New("too many chat messages, please try again later")
+
+// errInvalidWSMessage is returned for a message whose payload is not a valid
+// request
+var errInvalidWSMessage = errors.New("invalid message")
+
+// wsErrors maps the errors of messages sent over WebSockets to the codes the
+// matching HTTP endpoints respond with
+var wsErrors = gameErrors.Extend(
+	apierror.Mapping{Err: errInvalidWSMessage, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid message", ShowDetails: true},
+	apierror.Mapping{Err: ws.ErrUnknownMessageType, Status: http.StatusBadRequest, Code: apierror.CodeValidation, Message: "Invalid message", ShowDetails: true},
+	apierror.Mapping{Err: errChatRateLimited, Status: http.StatusTooManyRequests, Code: "RATE_LIMIT_EXCEEDED", Message: "Too many chat messages, please try again later"},
+)
+
+// wsChatLimiters rate limits each user's chat messages sent over WebSockets
+type wsChatLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// allow reports whether the user may send another chat message now
+func (l *wsChatLimiters) allow(userID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limiters == nil {
+		l.limiters = make(map[string]*rate.Limiter)
+	}
+	limiter, exists := l.limiters[userID]
+	if !exists {
+		limiter = rate.NewLimiter(rate.Limit(chatMessagesPerSecond), chatBurst)
+		l.limiters[userID] = limiter
+	}
+	return limiter.Allow()
+}
+
+// forget drops the user's limiter once they have no connection left
+func (l *wsChatLimiters) forget(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.limiters, userID)
+}
+
+// HandleWSMessage routes a message a player sent over their game's WebSocket
+// to the game or room service, as the matching HTTP endpoint would. The player
+// is sent their view of the game after a bid or play, or an error frame if
+// the message is rejected. Register it with the hub's SetMessageHandler.
+func (h *GameHandler) HandleWSMessage(ctx context.Context, gameID, userID string, message ws.WSMessage) {
+	var state *domain.GameState
+	var err error
+	switch message.Type {
+	case ws.MessageBid:
+		state, err = h.wsPlaceBid(ctx, gameID, userID, message)
+	case ws.MessagePlay:
+		state, err = h.wsPlayCards(ctx, gameID, userID, message)
+	case ws.MessageChat:
+		err = h.wsPostMessage(ctx, userID, message)
+	default:
+		err = fmt.Errorf("%w: %q", ws.ErrUnknownMessageType, message.Type)
+	}
+	if err != nil {
+		h.sendWSError(gameID, userID, err)
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	view, err := state.ViewFor(userID)
+	if err != nil {
+		h.sendWSError(gameID, userID, err)
+		return
+	}
+	h.sendWS(gameID, userID, ws.WSMessage{
+		Type:    ws.EventStateUpdate,
+		GameID:  gameID,
+		UserID:  userID,
+		Payload: view,
+	})
+}
+
+// wsPlaceBid places the bid or pass in a bid message
+func (h *GameHandler) wsPlaceBid(ctx context.Context, gameID, userID string, message ws.WSMessage) (*domain.GameState, error) {
+	var req gamedto.PlaceBidRequest
+	if err := decodeWSPayload(message, &req); err != nil {
+		return nil, err
+	}
+	if req.Pass {
+		return h.gameService.PassBid(ctx, gameID, userID)
+	}
+	return h.gameService.PlaceBid(ctx, gameID, userID, req.Amount)
+}
+
+// wsPlayCards plays the formation in a play message
+func (h *GameHandler) wsPlayCards(ctx context.Context, gameID, userID string, message ws.WSMessage) (*domain.GameState, error) {
+	var req gamedto.PlayCardsRequest
+	if err := decodeWSPayload(message, &req); err != nil {
+		return nil, err
+	}
+	formation, err := req.Formation()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidWSMessage, err)
+	}
+	return h.gameService.PlayCards(ctx, gameID, userID, formation)
+}
+
+// wsPostMessage posts a chat message to the room named by the message, which
+// the room service broadcasts to the room's players
+func (h *GameHandler) wsPostMessage(ctx context.Context, userID string, message ws.WSMessage) error {
+	if message.RoomID == "" {
+		return fmt.Errorf("%w: room_id is required", errInvalidWSMessage)
+	}
+	var req gamedto.ChatMessageRequest
+	if err := decodeWSPayload(message, &req); err != nil {
+		return err
+	}
+	if !h.wsChat.allow(userID) {
+		return errChatRateLimited
+	}
+	_, err := h.roomService.PostMessage(ctx, message.RoomID, userID, req.Text)
+	return err
+}
+
+// decodeWSPayload decodes a message's payload into a request and validates it
+// with the request's binding rules
+func decodeWSPayload(message ws.WSMessage, req interface{}) error {
+	data, err := json.Marshal(message.Payload)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidWSMessage, err)
+	}
+	if err := json.Unmarshal(data, req); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidWSMessage, bindingErrorDetails(req, err))
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidWSMessage, bindingErrorDetails(req, err))
+	}
+	return nil
+}
+
+// sendWSError sends the player an error frame with the code and message the
+// matching HTTP endpoint would respond with. Internal errors are logged and
+// sent without their details.
+func (h *GameHandler) sendWSError(gameID, userID string, err error) {
+	status, response := wsErrors.Map(err, "Failed to handle message")
+	if status >= http.StatusInternalServerError {
+		slog.Error("Failed to handle WebSocket message", "user_id", userID, "game_id", gameID, "error", err)
+		response.Details = ""
+	}
+	h.sendWS(gameID, userID, ws.NewErrorMessage(response.Code, response.Message, response.Details))
+}
+
+// sendWS sends a message to a player's WebSocket, logging failures
+func (h *GameHandler) sendWS(gameID, userID string, message ws.WSMessage) {
+	if err := h.hub.SendToUser(userID, message); err != nil && !errors.Is(err, ws.ErrNotConnected) {
+		log.Printf("Failed to send message to user %s in game %s: %v", userID, gameID, err)
+	}
+}
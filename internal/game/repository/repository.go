@@ -1,19 +1,60 @@
 package repository
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+
 	"gorm.io/gorm"
 )
 
 type GameRepository interface {
-	// Interface methods will be defined in later tasks
+	CreateRoom(ctx context.Context, room *database.Room) error
+	AddRoomParticipant(ctx context.Context, participant *database.RoomParticipant) error
+	GetRoomByID(ctx context.Context, id string) (*database.Room, error)
+	GetIdleRooms(ctx context.Context, status string, idleSince time.Time, limit int) ([]database.Room, error)
+	UpdateRoomStatus(ctx context.Context, id, status string) error
+	TransitionRoomStatus(ctx context.Context, id, from, to string) (bool, error)
+	DeleteRoom(ctx context.Context, id string) error
+	UpdateRoomPlayerCount(ctx context.Context, id string, count int) error
+	RemoveRoomParticipant(ctx context.Context, roomID, userID string) error
+	CreateGame(ctx context.Context, game *database.Game) error
+	AddGameParticipant(ctx context.Context, participant *database.GameParticipant) error
+	GetGameByID(ctx context.Context, id string) (*database.Game, error)
+	GetGameByRoomID(ctx context.Context, roomID string) (*database.Game, error)
+	UpdateGame(ctx context.Context, game *database.Game) error
+	LinkRematchRoom(ctx context.Context, gameID, roomID string) (bool, error)
+	UpdateGameParticipant(ctx context.Context, participant *database.GameParticipant) error
+	GetUserStats(ctx context.Context, userID string) (*database.UserStats, error)
+	CreateUserStats(ctx context.Context, stats *database.UserStats) error
+	UpdateUserStats(ctx context.Context, stats *database.UserStats) error
+	WithTransaction(ctx context.Context, fn func(repo GameRepository) error) error
 }
 
 type gameRepository struct {
-	db *gorm.DB
+	database.Repository
 }
 
 func NewGameRepository(db *gorm.DB) GameRepository {
 	return &gameRepository{
-		db: db,
+		Repository: database.NewGormRepository(db),
 	}
-}
\ No newline at end of file
+}
+
+// NewCachedGameRepository creates a game repository that invalidates a
+// player's cached profile whenever their stats are updated
+func NewCachedGameRepository(db *gorm.DB, cache database.Cache, logger *slog.Logger) GameRepository {
+	return &gameRepository{
+		Repository: database.NewCachedUserRepository(database.NewGormRepository(db), cache, logger),
+	}
+}
+
+// WithTransaction runs fn against a game repository bound to a single
+// transaction, committing if fn returns nil and rolling back otherwise
+func (r *gameRepository) WithTransaction(ctx context.Context, fn func(repo GameRepository) error) error {
+	return r.Repository.WithTransaction(ctx, func(tx database.Repository) error {
+		return fn(&gameRepository{Repository: tx})
+	})
+}
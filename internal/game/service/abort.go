@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"gorm.io/datatypes"
+)
+
+// ErrGameOver is returned when aborting a game that has already ended
+var ErrGameOver = errors.New("game is already over")
+
+// AbortGame terminates a game that cannot finish, e.g. because it is stuck or
+// every player has left. The game is recorded as aborted without a winner and
+// is not counted in anyone's statistics. Its room is released and the players
+// are told the game was aborted.
+func (s *gameService) AbortGame(ctx context.Context, gameID string) (*domain.GameState, error) {
+	state, err := s.updateState(ctx, gameID, func(state *domain.GameState) error {
+		if err := state.Abort(); err != nil {
+			return fmt.Errorf("%w: %w", ErrGameOver, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, player := range state.Players {
+		s.stopGracePeriod(gameID, player.ID)
+	}
+
+	if err := s.saveAbortedGame(ctx, state); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateRoomStatus(ctx, state.RoomID, database.RoomStatusFinished); err != nil {
+		return nil, fmt.Errorf("failed to release room: %w", err)
+	}
+
+	s.broadcast(state, ws.WSMessage{
+		Type:   ws.EventGameAborted,
+		GameID: state.ID,
+		RoomID: state.RoomID,
+	})
+	return state, nil
+}
+
+// saveAbortedGame records the final state of an aborted game
+func (s *gameService) saveAbortedGame(ctx context.Context, state *domain.GameState) error {
+	game, err := s.getGame(ctx, state.ID)
+	if err != nil {
+		return err
+	}
+
+	gameData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode game state: %w", err)
+	}
+
+	endedAt := time.Now()
+	game.Aborted = true
+	game.EndedAt = &endedAt
+	game.GameData = datatypes.JSON(gameData)
+
+	if err := s.repo.UpdateGame(ctx, game); err != nil {
+		return fmt.Errorf("failed to update game: %w", err)
+	}
+	return nil
+}
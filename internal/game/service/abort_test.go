@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameService_AbortGame(t *testing.T) {
+	repo := &MockGameRepository{}
+	store := newMemoryStateStore()
+	notifier := newRecordingNotifier()
+	service := NewGameService(repo, store, nil, nil, notifier, &config.Config{}).(*gameService)
+	ctx := context.Background()
+
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	game := &database.Game{ID: "game-1", RoomID: "room-1"}
+	repo.On("GetGameByID", ctx, "game-1").Return(game, nil)
+	repo.On("UpdateGame", ctx, mock.MatchedBy(func(game *database.Game) bool {
+		return game.Aborted && game.EndedAt != nil && game.WinnerTeam == nil && len(game.GameData) > 0
+	})).Return(nil)
+	repo.On("UpdateRoomStatus", ctx, "room-1", database.RoomStatusFinished).Return(nil)
+
+	state, err := service.AbortGame(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhaseAborted, state.Phase)
+
+	saved, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhaseAborted, saved.Phase)
+
+	repo.AssertExpectations(t)
+	// An aborted game does not count towards anyone's stats
+	repo.AssertNotCalled(t, "GetUserStats", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreateUserStats", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdateUserStats", mock.Anything, mock.Anything)
+
+	for _, playerID := range []string{"north", "east", "south", "west"} {
+		messages := notifier.received(playerID)
+		require.Len(t, messages, 1)
+		assert.Equal(t, ws.EventGameAborted, messages[0].Type)
+	}
+
+	_, err = service.AbortGame(ctx, "game-1")
+	assert.ErrorIs(t, err, ErrGameOver)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	gamedto "chinese-bridge-game/internal/game/dto"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxChatMessageLength caps the characters in a single chat message
+	MaxChatMessageLength = 500
+	// MaxChatHistory is how many recent messages each room keeps
+	MaxChatHistory = 100
+)
+
+var (
+	// ErrEmptyChatMessage is returned when a message has no text once cleaned up
+	ErrEmptyChatMessage = errors.New("chat message is empty")
+	// ErrChatMessageTooLong is returned when a message exceeds MaxChatMessageLength
+	ErrChatMessageTooLong = fmt.Errorf("chat message exceeds %d characters", MaxChatMessageLength)
+	// ErrNotRoomParticipant is returned when someone outside a room uses its chat
+	ErrNotRoomParticipant = errors.New("only players in the room can use its chat")
+)
+
+// ChatStore keeps the recent chat history of each room
+type ChatStore interface {
+	AddChatMessage(ctx context.Context, roomID string, message interface{}, maxMessages int) error
+	GetChatMessages(ctx context.Context, roomID string, offset, limit int) ([]string, error)
+}
+
+// PostMessage adds a message to the room's chat and broadcasts it to the
+// room's players. Control characters and surrounding whitespace are stripped.
+func (s *roomService) PostMessage(ctx context.Context, roomID, userID, text string) (*gamedto.ChatMessage, error) {
+	text, err := cleanChatText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := findParticipant(room, userID)
+	if sender == nil {
+		return nil, ErrNotRoomParticipant
+	}
+
+	message := &gamedto.ChatMessage{
+		ID:       uuid.New().String(),
+		RoomID:   roomID,
+		UserID:   userID,
+		UserName: sender.User.Name,
+		Text:     text,
+		SentAt:   time.Now(),
+	}
+	if err := s.chat.AddChatMessage(ctx, roomID, message, MaxChatHistory); err != nil {
+		return nil, fmt.Errorf("failed to save chat message: %w", err)
+	}
+
+	participants := make([]string, 0, len(room.Participants))
+	for _, participant := range room.Participants {
+		participants = append(participants, participant.UserID)
+	}
+	s.notify(participants, ws.WSMessage{
+		Type:    ws.EventChatMessage,
+		RoomID:  roomID,
+		UserID:  userID,
+		Payload: message,
+	})
+	return message, nil
+}
+
+// GetMessages returns up to limit of the room's messages in the order they
+// were sent, skipping the offset most recent ones
+func (s *roomService) GetMessages(ctx context.Context, roomID, userID string, offset, limit int) ([]gamedto.ChatMessage, error) {
+	room, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findParticipant(room, userID) == nil {
+		return nil, ErrNotRoomParticipant
+	}
+
+	data, err := s.chat.GetChatMessages(ctx, roomID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chat messages: %w", err)
+	}
+
+	messages := make([]gamedto.ChatMessage, 0, len(data))
+	for _, item := range data {
+		var message gamedto.ChatMessage
+		if err := json.Unmarshal([]byte(item), &message); err != nil {
+			return nil, fmt.Errorf("failed to decode chat message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// cleanChatText strips control characters and surrounding whitespace and
+// checks the message length
+func cleanChatText(text string) (string, error) {
+	text = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text))
+
+	if text == "" {
+		return "", ErrEmptyChatMessage
+	}
+	if utf8.RuneCountInString(text) > MaxChatMessageLength {
+		return "", ErrChatMessageTooLong
+	}
+	return text, nil
+}
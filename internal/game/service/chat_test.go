@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChatStore is an in-memory ChatStore that, like Redis, keeps only the
+// most recent messages of each room
+type memoryChatStore struct {
+	mu    sync.Mutex
+	rooms map[string][]string
+}
+
+func newMemoryChatStore() *memoryChatStore {
+	return &memoryChatStore{rooms: make(map[string][]string)}
+}
+
+func (s *memoryChatStore) AddChatMessage(ctx context.Context, roomID string, message interface{}, maxMessages int) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := append(s.rooms[roomID], string(data))
+	if len(messages) > maxMessages {
+		messages = messages[len(messages)-maxMessages:]
+	}
+	s.rooms[roomID] = messages
+	return nil
+}
+
+func (s *memoryChatStore) GetChatMessages(ctx context.Context, roomID string, offset, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := s.rooms[roomID]
+	end := len(messages) - offset
+	if end <= 0 {
+		return []string{}, nil
+	}
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+	return append([]string(nil), messages[start:end]...), nil
+}
+
+func TestRoomService_PostMessage(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east"), nil)
+
+	message, err := service.PostMessage(ctx, "room-1", "east", "  good\x07 luck!\n")
+	require.NoError(t, err)
+	assert.Equal(t, "good luck!", message.Text, "control characters and whitespace are stripped")
+	assert.Equal(t, "Player east", message.UserName)
+
+	for _, userID := range []string{"north", "east"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventChatMessage, messages[0].Type)
+		assert.Equal(t, message, messages[0].Payload)
+	}
+}
+
+func TestRoomService_GetMessagesInOrder(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+	ctx := context.Background()
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east"), nil)
+
+	for _, text := range []string{"one", "two", "three", "four"} {
+		_, err := service.PostMessage(ctx, "room-1", "north", text)
+		require.NoError(t, err)
+	}
+
+	messages, err := service.GetMessages(ctx, "room-1", "east", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 4)
+	for i, text := range []string{"one", "two", "three", "four"} {
+		assert.Equal(t, text, messages[i].Text)
+	}
+
+	// The second page of two holds the two oldest messages
+	messages, err = service.GetMessages(ctx, "room-1", "east", 2, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "one", messages[0].Text)
+	assert.Equal(t, "two", messages[1].Text)
+}
+
+func TestRoomService_PostMessage_Rejected(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east"), nil)
+
+	_, err := service.PostMessage(ctx, "room-1", "north", strings.Repeat("a", MaxChatMessageLength+1))
+	assert.ErrorIs(t, err, ErrChatMessageTooLong)
+
+	_, err = service.PostMessage(ctx, "room-1", "north", " \t\n")
+	assert.ErrorIs(t, err, ErrEmptyChatMessage)
+
+	_, err = service.PostMessage(ctx, "room-1", "stranger", "hello")
+	assert.ErrorIs(t, err, ErrNotRoomParticipant)
+
+	_, err = service.GetMessages(ctx, "room-1", "stranger", 0, 10)
+	assert.ErrorIs(t, err, ErrNotRoomParticipant)
+
+	assert.Empty(t, notifier.received("east"))
+
+	// A message of exactly the maximum length, counted in characters, is accepted
+	_, err = service.PostMessage(ctx, "room-1", "north", strings.Repeat("é", MaxChatMessageLength))
+	assert.NoError(t, err)
+}
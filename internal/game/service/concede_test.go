@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameService_Concede_WaitsForTeammate(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	state, err := service.Concede(ctx, "game-1", "east", false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhasePlaying, state.Phase, "west has not conceded yet")
+	assert.Equal(t, []domain.PlayerPosition{domain.East}, state.Concessions)
+
+	// Conceding again changes nothing
+	state, err = service.Concede(ctx, "game-1", "east", false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhasePlaying, state.Phase)
+	assert.Len(t, state.Concessions, 1)
+}
+
+func TestGameService_Concede_OutsidePlayIsInvalid(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	require.NoError(t, store.SaveGameState(ctx, state))
+
+	_, err = service.Concede(ctx, "game-1", "east", false)
+	assert.ErrorIs(t, err, ErrInvalidMove)
+	assert.ErrorIs(t, err, domain.ErrWrongPhase)
+}
+
+func TestGameService_Concede_OverrideRequiresHost(t *testing.T) {
+	mockRepo := &MockGameRepository{}
+	store := newMemoryStateStore()
+	service := NewGameService(mockRepo, store, nil, nil, newRecordingNotifier(), &config.Config{})
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+
+	_, err := service.Concede(ctx, "game-1", "east", true)
+	assert.ErrorIs(t, err, ErrNotRoomHost)
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Empty(t, state.Concessions)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+)
+
+// ErrNoDealSeed is returned for games started before deal seeds were recorded
+var ErrNoDealSeed = errors.New("game has no recorded deal seed")
+
+// DealRecord is the seed a game's deck was shuffled with and the hands and
+// kitty it dealt
+type DealRecord struct {
+	GameID string                   `json:"game_id"`
+	Seed   int64                    `json:"seed,string"`
+	Hands  map[string][]domain.Card `json:"hands"` // Each player's dealt hand, by player ID
+	Kitty  []domain.Card            `json:"kitty"`
+}
+
+// GetDealRecord returns the seed a game was dealt from together with the deal
+// it reproduces, so complaints about a rigged deal can be checked
+func (s *gameService) GetDealRecord(ctx context.Context, gameID string) (*DealRecord, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.DealSeed == nil {
+		return nil, ErrNoDealSeed
+	}
+
+	participants := append([]database.GameParticipant(nil), game.Participants...)
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].Position < participants[j].Position
+	})
+	playerIDs := make([]string, len(participants))
+	playerNames := make([]string, len(participants))
+	for i, participant := range participants {
+		playerIDs[i] = participant.UserID
+		playerNames[i] = participant.User.Name
+	}
+
+	state, err := domain.NewGameState(game.ID, game.RoomID, playerIDs, playerNames, domain.DefaultRules())
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild game: %w", err)
+	}
+	if err := state.DealCardsFromSeed(*game.DealSeed); err != nil {
+		return nil, fmt.Errorf("failed to reproduce deal: %w", err)
+	}
+
+	record := &DealRecord{
+		GameID: game.ID,
+		Seed:   *game.DealSeed,
+		Hands:  make(map[string][]domain.Card, len(state.Players)),
+		Kitty:  state.Kitty,
+	}
+	for _, player := range state.Players {
+		record.Hands[player.ID] = player.Hand
+	}
+	return record, nil
+}
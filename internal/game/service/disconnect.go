@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+)
+
+// maxAutoActions bounds the number of consecutive automatic moves made in one pass.
+// A full game has fewer moves than this, so hitting it means the game is stuck.
+const maxAutoActions = 200
+
+// HandleDisconnect marks a player as disconnected and starts their grace period.
+// If they have not reconnected when it expires, their seat is handed to a bot
+// or their turns are played automatically, depending on configuration.
+func (s *gameService) HandleDisconnect(ctx context.Context, gameID, userID string) error {
+	state, err := s.updateState(ctx, gameID, func(state *domain.GameState) error {
+		if state.GetPlayer(userID) == nil {
+			return ErrNotParticipant
+		}
+		if state.IsOver() {
+			return errNoChange
+		}
+		return state.MarkDisconnected(userID, time.Now(), s.config.Game.DisconnectGracePeriod)
+	})
+	if err != nil || state.IsOver() {
+		return err
+	}
+
+	s.broadcast(state, ws.WSMessage{
+		Type:   ws.EventPlayerDisconnected,
+		GameID: gameID,
+		UserID: userID,
+		Payload: map[string]interface{}{
+			"grace_period_seconds": int(s.config.Game.DisconnectGracePeriod.Seconds()),
+		},
+	})
+
+	s.startGracePeriod(gameID, userID)
+	return nil
+}
+
+// ResumeGame returns a participant to a game in progress, cancelling any pending
+// disconnect fallback, and returns their view of the current state
+func (s *gameService) ResumeGame(ctx context.Context, gameID, userID string) (*domain.GameView, error) {
+	reconnected := false
+	state, err := s.updateState(ctx, gameID, func(state *domain.GameState) error {
+		player := state.GetPlayer(userID)
+		if player == nil {
+			return ErrNotParticipant
+		}
+		reconnected = player.IsDisconnected()
+		if !reconnected {
+			return errNoChange
+		}
+		return state.MarkConnected(userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.stopGracePeriod(gameID, userID)
+	// Timers do not survive a restart, so a returning player rearms the clock
+	s.scheduleTurnExpiry(state)
+
+	if reconnected {
+		s.broadcast(state, ws.WSMessage{
+			Type:   ws.EventPlayerReconnect,
+			GameID: gameID,
+			UserID: userID,
+		})
+	}
+
+	return state.ViewFor(userID)
+}
+
+// startGracePeriod schedules the fallback for a disconnected player
+func (s *gameService) startGracePeriod(gameID, userID string) {
+	key := gameID + ":" + userID
+
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	if timer, exists := s.graceTimers[key]; exists {
+		timer.Stop()
+	}
+	s.graceTimers[key] = time.AfterFunc(s.config.Game.DisconnectGracePeriod, func() {
+		s.timersMu.Lock()
+		delete(s.graceTimers, key)
+		s.timersMu.Unlock()
+
+		if err := s.expireGracePeriod(context.Background(), gameID, userID); err != nil {
+			log.Printf("Failed to apply disconnect fallback for user %s in game %s: %v", userID, gameID, err)
+		}
+	})
+}
+
+// stopGracePeriod cancels a pending disconnect fallback
+func (s *gameService) stopGracePeriod(gameID, userID string) {
+	key := gameID + ":" + userID
+
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	if timer, exists := s.graceTimers[key]; exists {
+		timer.Stop()
+		delete(s.graceTimers, key)
+	}
+}
+
+// expireGracePeriod applies the configured fallback once a player's grace period ends
+func (s *gameService) expireGracePeriod(ctx context.Context, gameID, userID string) error {
+	replaced, acted := false, false
+	state, err := s.updateState(ctx, gameID, func(state *domain.GameState) error {
+		player := state.GetPlayer(userID)
+		if player == nil || !player.IsDisconnected() || state.IsOver() {
+			return errNoChange
+		}
+
+		if s.config.Game.BotsEnabled && !player.IsBot {
+			if err := state.ReplaceWithBot(userID); err != nil {
+				return err
+			}
+			replaced = true
+		}
+
+		s.runAutoActions(state)
+		acted = true
+		return nil
+	})
+	if err != nil || !acted {
+		return err
+	}
+
+	if replaced {
+		s.broadcast(state, ws.WSMessage{
+			Type:   ws.EventPlayerReplaced,
+			GameID: gameID,
+			UserID: userID,
+		})
+	}
+	return s.finalizeIfEnded(ctx, state)
+}
+
+// isAutoControlled reports whether the server should act for a player
+func (s *gameService) isAutoControlled(player *domain.Player) bool {
+	if player.IsBot {
+		return true
+	}
+	return player.IsDisconnected() && time.Since(*player.DisconnectedAt) >= s.config.Game.DisconnectGracePeriod
+}
+
+// runAutoActions plays for bots and disconnected players while it is their turn
+func (s *gameService) runAutoActions(state *domain.GameState) {
+	for i := 0; i < maxAutoActions && !state.IsOver(); i++ {
+		player := state.GetCurrentPlayer()
+		if player == nil || !s.isAutoControlled(player) {
+			return
+		}
+
+		if err := state.AutoAct(); err != nil {
+			log.Printf("Automatic action for player %s in game %s failed: %v", player.ID, state.ID, err)
+			return
+		}
+	}
+}
+
+// broadcast sends a message to every connected player in the game
+func (s *gameService) broadcast(state *domain.GameState, message ws.WSMessage) {
+	if s.notifier == nil {
+		return
+	}
+
+	for _, player := range state.Players {
+		if player.ID == message.UserID {
+			continue
+		}
+		if err := s.notifier.SendToUser(player.ID, message); err != nil && !errors.Is(err, ws.ErrNotConnected) {
+			log.Printf("Failed to notify player %s in game %s: %v", player.ID, state.ID, err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGracePeriod = 20 * time.Millisecond
+
+// recordingNotifier records the messages sent to each user
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages map[string][]ws.WSMessage
+}
+
+func newRecordingNotifier() *recordingNotifier {
+	return &recordingNotifier{messages: make(map[string][]ws.WSMessage)}
+}
+
+func (n *recordingNotifier) SendToUser(userID string, message interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages[userID] = append(n.messages[userID], message.(ws.WSMessage))
+	return nil
+}
+
+func (n *recordingNotifier) received(userID string) []ws.WSMessage {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]ws.WSMessage(nil), n.messages[userID]...)
+}
+
+func setupDisconnectTestService(botsEnabled bool) (*gameService, *memoryStateStore, *recordingNotifier) {
+	store := newMemoryStateStore()
+	notifier := newRecordingNotifier()
+	cfg := &config.Config{
+		Game: config.GameConfig{
+			DisconnectGracePeriod: testGracePeriod,
+			BotsEnabled:           botsEnabled,
+		},
+	}
+
+	service := NewGameService(&MockGameRepository{}, store, nil, nil, notifier, cfg).(*gameService)
+	return service, store, notifier
+}
+
+// newPlayingGame returns a game dealt from an unshuffled deck where North won
+// the bidding, declared Hearts, exchanged the kitty and is about to lead
+func newPlayingGame(t *testing.T) *domain.GameState {
+	t.Helper()
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+
+	require.NoError(t, state.DealCards(domain.NewDeck()))
+	require.NoError(t, state.PlaceBid("north", 120))
+	for _, playerID := range []string{"east", "south", "west"} {
+		require.NoError(t, state.PassBid(playerID))
+	}
+	require.NoError(t, state.DeclareTrump("north", domain.Hearts))
+
+	discards := make([]domain.Card, 8)
+	copy(discards, state.Players[domain.North].Hand[:8])
+	require.NoError(t, state.ExchangeKitty("north", discards))
+	require.Equal(t, domain.PhasePlaying, state.Phase)
+	return state
+}
+
+func TestGameService_HandleDisconnect_AutoPlaysAfterGracePeriod(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	// North drops while it is their turn to lead
+	require.NoError(t, service.HandleDisconnect(ctx, "game-1", "north"))
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"north"}, state.GetDisconnectedPlayers())
+	assert.Equal(t, 25, state.Players[domain.North].GetHandSize(), "no move before the grace period ends")
+
+	view, err := state.ViewFor("east")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusReconnecting, view.Players[domain.North].Status, "the seat is held during the grace period")
+
+	for _, userID := range []string{"east", "south", "west"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventPlayerDisconnected, messages[0].Type)
+		assert.Equal(t, "north", messages[0].UserID)
+	}
+	assert.Empty(t, notifier.received("north"))
+
+	assert.Eventually(t, func() bool {
+		state, err := store.GetGameState(ctx, "game-1")
+		return err == nil && state.CurrentTrick != nil && state.CurrentTrick.HasPlayerPlayed(domain.North)
+	}, time.Second, 5*time.Millisecond)
+
+	state, err = store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	north := state.Players[domain.North]
+	assert.Equal(t, 24, north.GetHandSize())
+	assert.True(t, north.IsDisconnected())
+	assert.False(t, north.IsBot, "bots are disabled")
+	assert.Equal(t, domain.East, state.CurrentPlayerTurn, "connected players still play for themselves")
+}
+
+func TestGameService_HandleDisconnect_ReplacesWithBotAfterGracePeriod(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(true)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	// East drops while waiting for North to lead
+	require.NoError(t, service.HandleDisconnect(ctx, "game-1", "east"))
+
+	assert.Eventually(t, func() bool {
+		state, err := store.GetGameState(ctx, "game-1")
+		return err == nil && state.Players[domain.East].IsBot
+	}, time.Second, 5*time.Millisecond)
+
+	messages := notifier.received("north")
+	require.Len(t, messages, 2)
+	assert.Equal(t, ws.EventPlayerReplaced, messages[1].Type)
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusReplacedByBot, state.Players[domain.East].ConnectionStatus(time.Now()))
+
+	// Once North leads, the bot follows immediately and play moves on to South
+	state, err = service.PlayCards(ctx, "game-1", "north", domain.NewSingle(domain.NewCard(domain.Spades, domain.Ten, 1)))
+	require.NoError(t, err)
+
+	assert.True(t, state.CurrentTrick.HasPlayerPlayed(domain.East))
+	assert.Equal(t, 24, state.Players[domain.East].GetHandSize())
+	assert.Equal(t, domain.South, state.CurrentPlayerTurn)
+}
+
+func TestGameService_HandleDisconnect_UnknownPlayerOrGame(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	assert.ErrorIs(t, service.HandleDisconnect(ctx, "game-1", "stranger"), ErrNotParticipant)
+	assert.ErrorIs(t, service.HandleDisconnect(ctx, "missing-game", "north"), ErrGameNotFound)
+}
+
+func TestGameService_HandleDisconnect_GameOver(t *testing.T) {
+	for _, phase := range []domain.GamePhase{domain.PhaseEnded, domain.PhaseAborted} {
+		t.Run(phase.String(), func(t *testing.T) {
+			service, store, notifier := setupDisconnectTestService(false)
+			ctx := context.Background()
+			state := newPlayingGame(t)
+			state.Phase = phase
+			require.NoError(t, store.SaveGameState(ctx, state))
+
+			require.NoError(t, service.HandleDisconnect(ctx, "game-1", "north"))
+
+			state, err := store.GetGameState(ctx, "game-1")
+			require.NoError(t, err)
+			assert.Empty(t, state.GetDisconnectedPlayers())
+			assert.Empty(t, notifier.received("east"))
+		})
+	}
+}
+
+func TestGameService_ResumeGame_CancelsFallback(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(true)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	require.NoError(t, service.HandleDisconnect(ctx, "game-1", "north"))
+
+	view, err := service.ResumeGame(ctx, "game-1", "north")
+	require.NoError(t, err)
+	assert.Equal(t, domain.North, view.Position)
+	assert.Len(t, view.Hand, 25)
+	assert.False(t, view.Players[domain.North].Disconnected)
+	assert.Equal(t, domain.StatusConnected, view.Players[domain.North].Status)
+
+	messages := notifier.received("east")
+	require.Len(t, messages, 2)
+	assert.Equal(t, ws.EventPlayerReconnect, messages[1].Type)
+
+	// The grace period passes without the player being replaced or moved for
+	time.Sleep(3 * testGracePeriod)
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Empty(t, state.GetDisconnectedPlayers())
+	assert.False(t, state.Players[domain.North].IsBot)
+	assert.Equal(t, 25, state.Players[domain.North].GetHandSize())
+}
+
+func TestGameService_ResumeGame_NonParticipant(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	_, err := service.ResumeGame(ctx, "game-1", "stranger")
+	assert.ErrorIs(t, err, ErrNotParticipant)
+}
+
+func TestGameService_PlayCards_NotifiesTrickWon(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(false)
+	ctx := context.Background()
+
+	state := newPlayingGame(t)
+	for _, play := range []struct {
+		playerID string
+		card     domain.Card
+	}{
+		{"north", domain.NewCard(domain.Spades, domain.Ten, 1)},
+		{"east", domain.NewCard(domain.Clubs, domain.Three, 1)},
+		{"south", domain.NewCard(domain.Spades, domain.Ten, 2)},
+	} {
+		_, err := state.PlayCards(play.playerID, domain.NewSingle(play.card))
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.SaveGameState(ctx, state))
+
+	_, err := service.PlayCards(ctx, "game-1", "west", domain.NewSingle(domain.NewCard(domain.Hearts, domain.Queen, 2)))
+	require.NoError(t, err)
+
+	for _, userID := range []string{"north", "east", "south", "west"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventTrickWon, messages[0].Type)
+		assert.Equal(t, &domain.TrickResult{TrickNumber: 1, Winner: domain.West, WinnerID: "west", Points: 20}, messages[0].Payload)
+	}
+}
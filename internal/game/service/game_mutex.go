@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// gameMutexes serializes this instance's updates to each game. Goroutines
+// working on the same game, such as player requests and disconnect timers,
+// queue here instead of racing each other to save, so only one of them at a
+// time waits on the shared game lock. The zero value is ready to use.
+type gameMutexes struct {
+	mu    sync.Mutex
+	games map[string]*gameMutex
+}
+
+// gameMutex is the lock for a single game
+type gameMutex struct {
+	held  chan struct{} // Holds a value while the game is locked
+	users int           // Goroutines holding or waiting for the lock
+}
+
+// lock waits until the game is free or ctx ends, returning the function that
+// unlocks it. Games are forgotten once nobody holds or waits for them.
+func (m *gameMutexes) lock(ctx context.Context, gameID string) (unlock func(), err error) {
+	m.mu.Lock()
+	if m.games == nil {
+		m.games = make(map[string]*gameMutex)
+	}
+	game, ok := m.games[gameID]
+	if !ok {
+		game = &gameMutex{held: make(chan struct{}, 1)}
+		m.games[gameID] = game
+	}
+	game.users++
+	m.mu.Unlock()
+
+	select {
+	case game.held <- struct{}{}:
+	case <-ctx.Done():
+		m.leave(gameID, game)
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-game.held
+		m.leave(gameID, game)
+	}, nil
+}
+
+// leave drops a holder or waiter of the game's lock
+func (m *gameMutexes) leave(gameID string, game *gameMutex) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	game.users--
+	if game.users == 0 {
+		delete(m.games, gameID)
+	}
+}
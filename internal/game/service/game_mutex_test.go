@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameMutexes_LockWaitsForHolder(t *testing.T) {
+	var locks gameMutexes
+	ctx := context.Background()
+
+	unlock, err := locks.lock(ctx, "game-1")
+	require.NoError(t, err)
+
+	// Another game is not blocked
+	unlockOther, err := locks.lock(ctx, "game-2")
+	require.NoError(t, err)
+	unlockOther()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = locks.lock(waitCtx, "game-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+	unlock, err = locks.lock(ctx, "game-1")
+	require.NoError(t, err)
+	unlock()
+
+	assert.Empty(t, locks.games, "unused games should be forgotten")
+}
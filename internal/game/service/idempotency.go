@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+)
+
+// IdempotencyStore remembers the results of requests made with an idempotency
+// key so that a retried request gets the original result
+type IdempotencyStore interface {
+	SetIdempotentResult(ctx context.Context, key string, result interface{}, ttl time.Duration) error
+	GetIdempotentResult(ctx context.Context, key string) (string, error)
+}
+
+// ErrIdempotencyKeyReused is returned when an idempotency key already used for
+// one request comes with a different one
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+
+// idempotentResult is the stored result of a request, along with the
+// fingerprint of the request that produced it
+type idempotentResult struct {
+	Request string            `json:"request"`
+	State   *domain.GameState `json:"state"`
+}
+
+// requestFingerprint identifies a request by its action and arguments
+func requestFingerprint(action string, args ...interface{}) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s%v", action, args)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context carrying the client's idempotency key
+// for a mutating request
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFrom returns the idempotency key carried by ctx, if any
+func IdempotencyKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// idempotencyKey returns the key under which the result of the request in ctx
+// is stored, or "" if the request carries no idempotency key. Keys are scoped
+// to the game or room and the user so clients cannot collide.
+func (s *gameService) idempotencyKey(ctx context.Context, scope, userID string) string {
+	key := IdempotencyKeyFrom(ctx)
+	if key == "" || s.idempotency == nil {
+		return ""
+	}
+	return scope + ":" + userID + ":" + key
+}
+
+// replayResult returns the state recorded for an earlier request with the same
+// idempotency key, or nil if there was none. The key may only be reused for
+// the request it was first sent with.
+func (s *gameService) replayResult(ctx context.Context, scope, userID, request string) (*domain.GameState, error) {
+	key := s.idempotencyKey(ctx, scope, userID)
+	if key == "" {
+		return nil, nil
+	}
+
+	data, err := s.idempotency.GetIdempotentResult(ctx, key)
+	if err != nil {
+		if errors.Is(err, database.ErrCacheMiss) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+
+	var result idempotentResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotent result: %w", err)
+	}
+	if result.Request != request || result.State == nil {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return result.State, nil
+}
+
+// recordResult stores the result of a request made with an idempotency key.
+// The action has already been applied, so failures are only logged.
+func (s *gameService) recordResult(ctx context.Context, scope, userID, request string, state *domain.GameState) {
+	key := s.idempotencyKey(ctx, scope, userID)
+	if key == "" {
+		return
+	}
+
+	result := idempotentResult{Request: request, State: state}
+	if err := s.idempotency.SetIdempotentResult(ctx, key, result, database.DefaultIdempotencyTTL); err != nil {
+		log.Printf("Failed to record idempotency key %s: %v", key, err)
+	}
+}
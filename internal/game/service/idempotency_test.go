@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	results map[string]string
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{results: make(map[string]string)}
+}
+
+func (s *memoryIdempotencyStore) SetIdempotentResult(ctx context.Context, key string, result interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[key] = string(data)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) GetIdempotentResult(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, exists := s.results[key]
+	if !exists {
+		return "", database.ErrCacheMiss
+	}
+	return result, nil
+}
+
+func setupIdempotencyTestService(t *testing.T) (*gameService, *memoryStateStore) {
+	t.Helper()
+
+	store := newMemoryStateStore()
+	service := NewGameService(&MockGameRepository{}, store, nil, newMemoryIdempotencyStore(), newRecordingNotifier(), &config.Config{}).(*gameService)
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	require.NoError(t, state.DealCards(domain.NewDeck()))
+	require.NoError(t, store.SaveGameState(context.Background(), state))
+	return service, store
+}
+
+func TestGameService_PlaceBid_ReplaysSameIdempotencyKey(t *testing.T) {
+	service, store := setupIdempotencyTestService(t)
+	ctx := WithIdempotencyKey(context.Background(), "bid-1")
+
+	first, err := service.PlaceBid(ctx, "game-1", "north", 120)
+	require.NoError(t, err)
+	assert.Equal(t, domain.East, first.CurrentPlayerTurn)
+
+	// The retry returns the original result instead of bidding again
+	second, err := service.PlaceBid(ctx, "game-1", "north", 120)
+	require.NoError(t, err)
+	assert.Equal(t, domain.East, second.CurrentPlayerTurn)
+	assert.Equal(t, first.Version, second.Version)
+
+	state, err := store.GetGameState(context.Background(), "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.East, state.CurrentPlayerTurn, "the turn advances only once")
+	assert.Len(t, state.BidHistory, 1)
+	assert.Equal(t, 120, state.CurrentBid)
+}
+
+func TestGameService_ReusedIdempotencyKeyForAnotherRequestIsRejected(t *testing.T) {
+	service, store := setupIdempotencyTestService(t)
+	ctx := WithIdempotencyKey(context.Background(), "bid-1")
+
+	_, err := service.PlaceBid(ctx, "game-1", "north", 120)
+	require.NoError(t, err)
+
+	_, err = service.PlaceBid(ctx, "game-1", "north", 125)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused, "a different amount is a different request")
+
+	_, err = service.PassBid(ctx, "game-1", "north")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused, "a different action is a different request")
+
+	state, err := store.GetGameState(context.Background(), "game-1")
+	require.NoError(t, err)
+	assert.Len(t, state.BidHistory, 1)
+	assert.Equal(t, 120, state.CurrentBid)
+}
+
+func TestGameService_PlaceBid_KeysAreScopedToTheUser(t *testing.T) {
+	service, _ := setupIdempotencyTestService(t)
+	ctx := WithIdempotencyKey(context.Background(), "bid-1")
+
+	_, err := service.PlaceBid(ctx, "game-1", "north", 120)
+	require.NoError(t, err)
+
+	// Another player reusing the key still has their bid applied
+	state, err := service.PlaceBid(ctx, "game-1", "east", 115)
+	require.NoError(t, err)
+	assert.Len(t, state.BidHistory, 2)
+	assert.Equal(t, domain.South, state.CurrentPlayerTurn)
+}
+
+func TestGameService_PlaceBid_WithoutKeyIsAppliedEachTime(t *testing.T) {
+	service, _ := setupIdempotencyTestService(t)
+	ctx := context.Background()
+
+	_, err := service.PlaceBid(ctx, "game-1", "north", 120)
+	require.NoError(t, err)
+
+	_, err = service.PlaceBid(ctx, "game-1", "north", 115)
+	assert.ErrorIs(t, err, ErrInvalidMove, "it is no longer north's turn")
+}
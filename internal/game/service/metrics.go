@@ -0,0 +1,50 @@
+package service
+
+import (
+	"sync"
+
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	gamesActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "games_active",
+		Help: "Number of games in progress that this instance has updated.",
+	})
+
+	gamesCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "games_completed_total",
+		Help: "Total number of completed games by winning team.",
+	}, []string{"winner"})
+)
+
+// activeGames tracks which games in progress this instance has seen, keeping
+// the games_active gauge in step as games start and end
+type activeGames struct {
+	mu  sync.Mutex
+	ids map[string]struct{}
+}
+
+func newActiveGames() *activeGames {
+	return &activeGames{ids: make(map[string]struct{})}
+}
+
+// observe records the latest state of a game
+func (a *activeGames) observe(state *domain.GameState) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, tracked := a.ids[state.ID]
+	ended := state.IsOver()
+	switch {
+	case !tracked && !ended:
+		a.ids[state.ID] = struct{}{}
+		gamesActive.Inc()
+	case tracked && ended:
+		delete(a.ids, state.ID)
+		gamesActive.Dec()
+	}
+}
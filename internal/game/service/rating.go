@@ -0,0 +1,43 @@
+package service
+
+import (
+	"math"
+)
+
+// expectedScore returns the ELO expected score of a rating against an opponent rating
+func expectedScore(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// averageRating returns the mean rating of a team
+func averageRating(ratings []int) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, rating := range ratings {
+		total += rating
+	}
+	return float64(total) / float64(len(ratings))
+}
+
+// calculateRatingChange returns the number of rating points each winner gains and
+// each loser gives up, using the average rating of each team
+func calculateRatingChange(winnerRatings, loserRatings []int, kFactor int) int {
+	expected := expectedScore(averageRating(winnerRatings), averageRating(loserRatings))
+	return int(math.Round(float64(kFactor) * (1 - expected)))
+}
+
+// splitRatingChange scales a rating change to the sizes of the winning and
+// losing teams so that rating is conserved. Each player on the larger team
+// moves change points, and the smaller team shares the same total evenly, so
+// a declarer playing alone against three moves three times the change. Teams
+// of four players always divide it evenly.
+func splitRatingChange(change, winners, losers int) (gain, loss int) {
+	if winners == 0 || losers == 0 {
+		return 0, 0
+	}
+	total := change * max(winners, losers)
+	return total / winners, total / losers
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateRatingChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		winnerRatings []int
+		loserRatings  []int
+		kFactor       int
+		expected      int
+	}{
+		{"Equal teams split K evenly", []int{1500, 1500}, []int{1500, 1500}, 32, 16},
+		{"Underdog win earns more", []int{1400, 1400}, []int{1600, 1600}, 32, 24},
+		{"Favourite win earns less", []int{1600, 1600}, []int{1400, 1400}, 32, 8},
+		{"Team average is used", []int{1300, 1700}, []int{1500, 1500}, 32, 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := calculateRatingChange(tt.winnerRatings, tt.loserRatings, tt.kFactor)
+			assert.Equal(t, tt.expected, change)
+		})
+	}
+}
+
+func TestSplitRatingChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		winners  int
+		losers   int
+		wantGain int
+		wantLoss int
+	}{
+		{"Fixed partners", 2, 2, 16, 16},
+		{"Declarer alone wins", 1, 3, 48, 16},
+		{"Declarer alone loses", 3, 1, 16, 48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gain, loss := splitRatingChange(16, tt.winners, tt.losers)
+			assert.Equal(t, tt.wantGain, gain)
+			assert.Equal(t, tt.wantLoss, loss)
+			assert.Equal(t, gain*tt.winners, loss*tt.losers, "rating must be conserved")
+		})
+	}
+}
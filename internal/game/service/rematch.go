@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"gorm.io/gorm"
+)
+
+// ErrGameNotEnded is returned when asking for the scoreboard or a rematch of a
+// game still in progress
+var ErrGameNotEnded = errors.New("game has not ended")
+
+// Rematch seats the player in a fresh waiting room for another game with the
+// players of a finished game, with the seating rotated to start from the
+// player after the previous host. The first request opens the room, hosted by
+// the player who asked so that the host is always seated, and invites the
+// other players over WebSocket. Players are only seated once they ask for the
+// rematch themselves, so anyone who declines is left out.
+func (s *roomService) Rematch(ctx context.Context, gameID, userID string) (*database.Room, error) {
+	game, err := s.repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if game.EndedAt == nil {
+		return nil, ErrGameNotEnded
+	}
+
+	seats := rematchSeats(game)
+	seat, ok := seats[userID]
+	if !ok {
+		return nil, ErrNotParticipant
+	}
+
+	var room *database.Room
+	if game.RematchRoomID == nil {
+		room, err = s.openRematchRoom(ctx, game, userID)
+	} else {
+		room, err = s.getRoom(ctx, *game.RematchRoomID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if findParticipant(room, userID) != nil {
+		return room, nil
+	}
+	if room.Status != database.RoomStatusWaiting {
+		return nil, ErrRoomNotWaiting
+	}
+
+	participant := &database.RoomParticipant{RoomID: room.ID, UserID: userID, Position: seat}
+	if err := s.repo.AddRoomParticipant(ctx, participant); err != nil {
+		return nil, fmt.Errorf("failed to seat player: %w", err)
+	}
+	if err := s.repo.UpdateRoomPlayerCount(ctx, room.ID, len(room.Participants)+1); err != nil {
+		return nil, fmt.Errorf("failed to update player count: %w", err)
+	}
+
+	seated := make([]string, 0, len(room.Participants))
+	for _, participant := range room.Participants {
+		seated = append(seated, participant.UserID)
+	}
+	s.notify(seated, ws.WSMessage{
+		Type:   ws.EventPlayerJoined,
+		RoomID: room.ID,
+		UserID: userID,
+	})
+	return s.getRoom(ctx, room.ID)
+}
+
+// openRematchRoom creates the empty rematch room hosted by the requester,
+// links it to the finished game and invites all of the game's players to it.
+// If another player's request linked a room first, the new room is deleted
+// and theirs is returned instead.
+func (s *roomService) openRematchRoom(ctx context.Context, game *database.Game, requesterID string) (*database.Room, error) {
+	room := &database.Room{
+		Name:       game.Room.Name,
+		HostID:     requesterID,
+		MaxPlayers: domain.PlayerCount,
+		Status:     database.RoomStatusWaiting,
+		Rules:      game.Room.Rules,
+	}
+	if err := s.repo.CreateRoom(ctx, room); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	linked, err := s.repo.LinkRematchRoom(ctx, game.ID, room.ID)
+	if err != nil {
+		s.discardRematchRoom(ctx, room.ID)
+		return nil, fmt.Errorf("failed to link rematch room: %w", err)
+	}
+	if !linked {
+		s.discardRematchRoom(ctx, room.ID)
+		return s.linkedRematchRoom(ctx, game.ID)
+	}
+	game.RematchRoomID = &room.ID
+
+	players := make([]string, 0, len(game.Participants))
+	for _, participant := range game.Participants {
+		players = append(players, participant.UserID)
+	}
+	s.notify(players, ws.WSMessage{
+		Type:   ws.EventRematchOffered,
+		GameID: game.ID,
+		RoomID: room.ID,
+		UserID: requesterID,
+		Payload: map[string]interface{}{
+			"host_id": requesterID,
+		},
+	})
+	return s.getRoom(ctx, room.ID)
+}
+
+// linkedRematchRoom returns the rematch room another request linked to the game
+func (s *roomService) linkedRematchRoom(ctx context.Context, gameID string) (*database.Room, error) {
+	game, err := s.repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if game.RematchRoomID == nil {
+		return nil, fmt.Errorf("game %s has no rematch room linked", gameID)
+	}
+	return s.getRoom(ctx, *game.RematchRoomID)
+}
+
+// discardRematchRoom deletes a rematch room that was never linked to its game
+func (s *roomService) discardRematchRoom(ctx context.Context, roomID string) {
+	if err := s.repo.DeleteRoom(ctx, roomID); err != nil {
+		log.Printf("Failed to delete unused rematch room %s: %v", roomID, err)
+	}
+}
+
+// rematchSeats rotates the seating to start from the player after the game
+// room's host and returns each player's seat, counted clockwise from them
+func rematchSeats(game *database.Game) map[string]int {
+	hostPosition := 0
+	for _, participant := range game.Participants {
+		if participant.UserID == game.Room.HostID {
+			hostPosition = participant.Position
+		}
+	}
+	firstPosition := (hostPosition + 1) % domain.PlayerCount
+
+	seats := make(map[string]int, len(game.Participants))
+	for _, participant := range game.Participants {
+		seats[participant.UserID] = (participant.Position - firstPosition + domain.PlayerCount) % domain.PlayerCount
+	}
+	return seats
+}
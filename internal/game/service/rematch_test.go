@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newEndedTestGame() *database.Game {
+	endedAt := time.Now()
+	game := &database.Game{
+		ID:      "game-1",
+		RoomID:  "room-1",
+		EndedAt: &endedAt,
+		Room:    *newTestRoom("north", "east", "south", "west"),
+	}
+	for position, userID := range []string{"north", "east", "south", "west"} {
+		game.Participants = append(game.Participants, database.GameParticipant{
+			GameID:   game.ID,
+			UserID:   userID,
+			Position: position,
+		})
+	}
+	return game
+}
+
+func TestRoomService_Rematch_OpensRoom(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+
+	game := newEndedTestGame()
+	rematchRoom := &database.Room{ID: "room-2", Name: "Test Room", HostID: "south", Status: database.RoomStatusWaiting}
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(game, nil)
+	// The first player to accept hosts, so the host is always seated
+	mockRepo.On("CreateRoom", ctx, mock.MatchedBy(func(room *database.Room) bool {
+		return room.Name == "Test Room" && room.HostID == "south" && room.Status == database.RoomStatusWaiting
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*database.Room).ID = "room-2"
+	}).Return(nil)
+	mockRepo.On("LinkRematchRoom", ctx, "game-1", "room-2").Return(true, nil)
+	mockRepo.On("GetRoomByID", ctx, "room-2").Return(rematchRoom, nil)
+	// South sat opposite north, so they sit opposite east in the rematch
+	mockRepo.On("AddRoomParticipant", ctx, &database.RoomParticipant{RoomID: "room-2", UserID: "south", Position: 1}).Return(nil)
+	mockRepo.On("UpdateRoomPlayerCount", ctx, "room-2", 1).Return(nil)
+
+	room, err := service.Rematch(ctx, "game-1", "south")
+	require.NoError(t, err)
+	assert.Equal(t, "room-2", room.ID)
+	mockRepo.AssertExpectations(t)
+
+	for _, userID := range []string{"north", "east", "south", "west"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventRematchOffered, messages[0].Type)
+		assert.Equal(t, "room-2", messages[0].RoomID)
+		assert.Equal(t, "south", messages[0].Payload.(map[string]interface{})["host_id"])
+	}
+}
+
+func TestRoomService_Rematch_JoinsRoomOpenedConcurrently(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+
+	// West's request opened and linked room-2 after this request read the game
+	game := newEndedTestGame()
+	linkedGame := newEndedTestGame()
+	rematchRoomID := "room-2"
+	linkedGame.RematchRoomID = &rematchRoomID
+	rematchRoom := &database.Room{
+		ID:           "room-2",
+		HostID:       "west",
+		Status:       database.RoomStatusWaiting,
+		Participants: []database.RoomParticipant{{RoomID: "room-2", UserID: "west", Position: 2}},
+	}
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(game, nil).Once()
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(linkedGame, nil).Once()
+	mockRepo.On("CreateRoom", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*database.Room).ID = "room-3"
+	}).Return(nil)
+	mockRepo.On("LinkRematchRoom", ctx, "game-1", "room-3").Return(false, nil)
+	mockRepo.On("DeleteRoom", ctx, "room-3").Return(nil)
+	mockRepo.On("GetRoomByID", ctx, "room-2").Return(rematchRoom, nil)
+	mockRepo.On("AddRoomParticipant", ctx, &database.RoomParticipant{RoomID: "room-2", UserID: "south", Position: 1}).Return(nil)
+	mockRepo.On("UpdateRoomPlayerCount", ctx, "room-2", 2).Return(nil)
+
+	room, err := service.Rematch(ctx, "game-1", "south")
+	require.NoError(t, err)
+	assert.Equal(t, "room-2", room.ID)
+	mockRepo.AssertExpectations(t)
+
+	// The discarded room is never offered; West only hears that South joined
+	messages := notifier.received("west")
+	require.Len(t, messages, 1)
+	assert.Equal(t, ws.EventPlayerJoined, messages[0].Type)
+	assert.Empty(t, notifier.received("north"))
+}
+
+func TestRoomService_Rematch_JoinsOpenRoom(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+
+	game := newEndedTestGame()
+	rematchRoomID := "room-2"
+	game.RematchRoomID = &rematchRoomID
+	rematchRoom := &database.Room{
+		ID:           "room-2",
+		HostID:       "east",
+		Status:       database.RoomStatusWaiting,
+		Participants: []database.RoomParticipant{{RoomID: "room-2", UserID: "south", Position: 1}},
+	}
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(game, nil)
+	mockRepo.On("GetRoomByID", ctx, "room-2").Return(rematchRoom, nil)
+	mockRepo.On("AddRoomParticipant", ctx, &database.RoomParticipant{RoomID: "room-2", UserID: "east", Position: 0}).Return(nil)
+	mockRepo.On("UpdateRoomPlayerCount", ctx, "room-2", 2).Return(nil)
+
+	_, err := service.Rematch(ctx, "game-1", "east")
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything)
+
+	messages := notifier.received("south")
+	require.Len(t, messages, 1)
+	assert.Equal(t, ws.EventPlayerJoined, messages[0].Type)
+	assert.Equal(t, "east", messages[0].UserID)
+	// Players who have not accepted the rematch are not told about each arrival
+	assert.Empty(t, notifier.received("west"))
+
+	// Asking again once seated does not seat the player twice
+	rematchRoom.Participants = append(rematchRoom.Participants, database.RoomParticipant{RoomID: "room-2", UserID: "east", Position: 0})
+	_, err = service.Rematch(ctx, "game-1", "east")
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "AddRoomParticipant", 1)
+}
+
+func TestRoomService_Rematch_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		game    func() *database.Game
+		userID  string
+		wantErr error
+	}{
+		{"Game in progress", func() *database.Game {
+			game := newEndedTestGame()
+			game.EndedAt = nil
+			return game
+		}, "north", ErrGameNotEnded},
+		{"Non-participant", newEndedTestGame, "spectator", ErrNotParticipant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := setupRoomTestService()
+			ctx := context.Background()
+			mockRepo.On("GetGameByID", ctx, "game-1").Return(tt.game(), nil)
+
+			_, err := service.Rematch(ctx, "game-1", tt.userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything)
+		})
+	}
+}
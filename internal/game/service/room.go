@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	gamedto "chinese-bridge-game/internal/game/dto"
+	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+
+	"gorm.io/gorm"
+)
+
+// MaxRoomNameLength caps the characters in a room name
+const MaxRoomNameLength = 64
+
+var (
+	// ErrInvalidRoomName is returned when a room name is blank, too long or contains control characters
+	ErrInvalidRoomName = errors.New("invalid room name")
+	// ErrCannotKickSelf is returned when the host tries to kick themselves
+	ErrCannotKickSelf = errors.New("the host cannot kick themselves; leave or close the room instead")
+	// ErrParticipantNotFound is returned when the target user is not seated in the room
+	ErrParticipantNotFound = errors.New("user is not a participant in this room")
+)
+
+// RoomService manages the players seated in a room and the room's chat
+type RoomService interface {
+	CreateRoom(ctx context.Context, hostID, name string, preset domain.RulePreset) (*database.Room, error)
+	KickParticipant(ctx context.Context, roomID, hostID, targetUserID string) error
+	Rematch(ctx context.Context, gameID, userID string) (*database.Room, error)
+	PostMessage(ctx context.Context, roomID, userID, text string) (*gamedto.ChatMessage, error)
+	GetMessages(ctx context.Context, roomID, userID string, offset, limit int) ([]gamedto.ChatMessage, error)
+}
+
+type roomService struct {
+	repo     repository.GameRepository
+	chat     ChatStore
+	notifier Notifier
+}
+
+func NewRoomService(repo repository.GameRepository, chat ChatStore, notifier Notifier) RoomService {
+	return &roomService{
+		repo:     repo,
+		chat:     chat,
+		notifier: notifier,
+	}
+}
+
+// CreateRoom opens a waiting room with the host seated in the first position.
+// Games in the room are played under the preset's rules, or the server's
+// rules if no preset is given.
+func (s *roomService) CreateRoom(ctx context.Context, hostID, name string, preset domain.RulePreset) (*database.Room, error) {
+	name, err := cleanRoomName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &database.Room{
+		Name:           name,
+		HostID:         hostID,
+		MaxPlayers:     domain.PlayerCount,
+		CurrentPlayers: 1,
+		Status:         database.RoomStatusWaiting,
+	}
+	if preset != "" {
+		rules, err := preset.Rules()
+		if err != nil {
+			return nil, err
+		}
+		if room.Rules, err = json.Marshal(rules); err != nil {
+			return nil, fmt.Errorf("failed to encode rules: %w", err)
+		}
+	}
+	if err := s.repo.CreateRoom(ctx, room); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	host := &database.RoomParticipant{RoomID: room.ID, UserID: hostID, Position: 0}
+	if err := s.repo.AddRoomParticipant(ctx, host); err != nil {
+		return nil, fmt.Errorf("failed to seat host: %w", err)
+	}
+	return s.getRoom(ctx, room.ID)
+}
+
+// KickParticipant removes a player from a waiting room. Only the host may kick,
+// and the kicked player and those remaining are notified.
+func (s *roomService) KickParticipant(ctx context.Context, roomID, hostID, targetUserID string) error {
+	room, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	if room.HostID != hostID {
+		return ErrNotRoomHost
+	}
+	if targetUserID == hostID {
+		return ErrCannotKickSelf
+	}
+	if room.Status != database.RoomStatusWaiting {
+		return ErrRoomNotWaiting
+	}
+
+	if findParticipant(room, targetUserID) == nil {
+		return ErrParticipantNotFound
+	}
+	remaining := make([]string, 0, len(room.Participants))
+	for _, participant := range room.Participants {
+		if participant.UserID != targetUserID {
+			remaining = append(remaining, participant.UserID)
+		}
+	}
+
+	if err := s.repo.RemoveRoomParticipant(ctx, roomID, targetUserID); err != nil {
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+	if err := s.repo.UpdateRoomPlayerCount(ctx, roomID, len(remaining)); err != nil {
+		return fmt.Errorf("failed to update player count: %w", err)
+	}
+
+	message := ws.WSMessage{
+		Type:   ws.EventPlayerKicked,
+		RoomID: roomID,
+		UserID: targetUserID,
+	}
+	s.notify(append(remaining, targetUserID), message)
+	return nil
+}
+
+// getRoom loads a room, mapping a missing room to ErrRoomNotFound
+func (s *roomService) getRoom(ctx context.Context, roomID string) (*database.Room, error) {
+	room, err := s.repo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	return room, nil
+}
+
+// cleanRoomName trims surrounding whitespace and checks the name is not blank,
+// not too long and free of control characters
+func cleanRoomName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidRoomName)
+	}
+	if utf8.RuneCountInString(name) > MaxRoomNameLength {
+		return "", fmt.Errorf("%w: name exceeds %d characters", ErrInvalidRoomName, MaxRoomNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("%w: name contains control characters", ErrInvalidRoomName)
+	}
+	return name, nil
+}
+
+// findParticipant returns the user's seat in the room, or nil if they are not seated
+func findParticipant(room *database.Room, userID string) *database.RoomParticipant {
+	for i := range room.Participants {
+		if room.Participants[i].UserID == userID {
+			return &room.Participants[i]
+		}
+	}
+	return nil
+}
+
+// notify sends a message to each of the users that is connected
+func (s *roomService) notify(userIDs []string, message ws.WSMessage) {
+	if s.notifier == nil {
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := s.notifier.SendToUser(userID, message); err != nil && !errors.Is(err, ws.ErrNotConnected) {
+			log.Printf("Failed to notify user %s in room %s: %v", userID, message.RoomID, err)
+		}
+	}
+}
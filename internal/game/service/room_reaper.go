@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+)
+
+// roomReapBatchSize caps how many idle rooms are closed in one pass
+const roomReapBatchSize = 100
+
+// RoomCacheInvalidator drops a room's cached data
+type RoomCacheInvalidator interface {
+	InvalidateRoomData(ctx context.Context, roomID string) error
+}
+
+// RoomReaper closes waiting rooms that have seen no activity for longer than
+// the idle TTL, so abandoned rooms do not clutter the lobby
+type RoomReaper struct {
+	repo     repository.GameRepository
+	cache    RoomCacheInvalidator
+	notifier Notifier
+	idleTTL  time.Duration
+}
+
+// NewRoomReaper creates a room reaper. A nil notifier skips notifying the
+// players seated in reaped rooms.
+func NewRoomReaper(repo repository.GameRepository, cache RoomCacheInvalidator, notifier Notifier, idleTTL time.Duration) *RoomReaper {
+	return &RoomReaper{
+		repo:     repo,
+		cache:    cache,
+		notifier: notifier,
+		idleTTL:  idleTTL,
+	}
+}
+
+// ReapIdleRooms closes every waiting room idle for longer than the TTL and
+// returns how many were closed. Cache and notification failures are logged
+// without stopping the pass.
+func (r *RoomReaper) ReapIdleRooms(ctx context.Context) (int, error) {
+	rooms, err := r.repo.GetIdleRooms(ctx, database.RoomStatusWaiting, time.Now().Add(-r.idleTTL), roomReapBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get idle rooms: %w", err)
+	}
+
+	reaped := 0
+	for _, room := range rooms {
+		if err := r.repo.UpdateRoomStatus(ctx, room.ID, database.RoomStatusClosed); err != nil {
+			return reaped, fmt.Errorf("failed to close room %s: %w", room.ID, err)
+		}
+		reaped++
+
+		if err := r.cache.InvalidateRoomData(ctx, room.ID); err != nil {
+			log.Printf("Failed to invalidate cache of closed room %s: %v", room.ID, err)
+		}
+		r.notifyClosed(room)
+	}
+
+	if reaped > 0 {
+		log.Printf("Closed %d idle rooms", reaped)
+	}
+	return reaped, nil
+}
+
+// notifyClosed tells the players seated in a room that it was closed
+func (r *RoomReaper) notifyClosed(room database.Room) {
+	if r.notifier == nil {
+		return
+	}
+
+	message := ws.WSMessage{Type: ws.EventRoomClosed, RoomID: room.ID}
+	for _, participant := range room.Participants {
+		if err := r.notifier.SendToUser(participant.UserID, message); err != nil && !errors.Is(err, ws.ErrNotConnected) {
+			log.Printf("Failed to notify user %s that room %s closed: %v", participant.UserID, room.ID, err)
+		}
+	}
+}
+
+// SchedulePeriodicReaping starts closing idle rooms on every interval until
+// the context is cancelled. A zero interval disables the reaper.
+func (r *RoomReaper) SchedulePeriodicReaping(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("Idle room reaping disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Idle room reaper stopped")
+				return
+			case <-ticker.C:
+				if _, err := r.ReapIdleRooms(ctx); err != nil {
+					log.Printf("Error while reaping idle rooms: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("Started reaping rooms idle for %v every %v", r.idleTTL, interval)
+}
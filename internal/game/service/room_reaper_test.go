@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingRoomInvalidator records the rooms whose cache was invalidated
+type recordingRoomInvalidator struct {
+	rooms []string
+}
+
+func (r *recordingRoomInvalidator) InvalidateRoomData(ctx context.Context, roomID string) error {
+	r.rooms = append(r.rooms, roomID)
+	return nil
+}
+
+func TestRoomReaper_ReapIdleRooms(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.NewMigrationManager(db, nil).RunMigrations(context.Background()))
+
+	ctx := context.Background()
+	repo := repository.NewGameRepository(db)
+	users := database.NewGormRepository(db)
+
+	openRoom := func(name string) *database.Room {
+		host := &database.User{GoogleID: name + "-google", Email: name + "@example.com", Name: name}
+		require.NoError(t, users.CreateUser(ctx, host))
+		room := &database.Room{Name: name, HostID: host.ID, CurrentPlayers: 1, Status: database.RoomStatusWaiting}
+		require.NoError(t, repo.CreateRoom(ctx, room))
+		require.NoError(t, repo.AddRoomParticipant(ctx, &database.RoomParticipant{RoomID: room.ID, UserID: host.ID, Position: 0}))
+		return room
+	}
+	stale := openRoom("stale")
+	active := openRoom("active")
+	require.NoError(t, db.Model(&database.Room{}).Where("id = ?", stale.ID).
+		UpdateColumn("updated_at", time.Now().Add(-2*time.Hour)).Error)
+
+	invalidator := &recordingRoomInvalidator{}
+	notifier := newRecordingNotifier()
+	reaped, err := NewRoomReaper(repo, invalidator, notifier, time.Hour).ReapIdleRooms(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped)
+
+	room, err := repo.GetRoomByID(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.RoomStatusClosed, room.Status)
+	room, err = repo.GetRoomByID(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.RoomStatusWaiting, room.Status, "a recently active room survives")
+
+	assert.Equal(t, []string{stale.ID}, invalidator.rooms)
+	messages := notifier.received(stale.HostID)
+	require.Len(t, messages, 1)
+	assert.Equal(t, ws.EventRoomClosed, messages[0].Type)
+	assert.Empty(t, notifier.received(active.HostID))
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupRoomTestService() (*roomService, *MockGameRepository, *recordingNotifier) {
+	mockRepo := &MockGameRepository{}
+	notifier := newRecordingNotifier()
+	return NewRoomService(mockRepo, newMemoryChatStore(), notifier).(*roomService), mockRepo, notifier
+}
+
+func TestRoomService_CreateRoom(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+	ctx := context.Background()
+
+	mockRepo.On("CreateRoom", ctx, mock.MatchedBy(func(room *database.Room) bool {
+		return room.Name == "Friday night bridge" && room.HostID == "north" && room.Status == database.RoomStatusWaiting
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*database.Room).ID = "room-1"
+	}).Return(nil)
+	mockRepo.On("AddRoomParticipant", ctx, &database.RoomParticipant{RoomID: "room-1", UserID: "north", Position: 0}).Return(nil)
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north"), nil)
+
+	room, err := service.CreateRoom(ctx, "north", "  Friday night bridge\t", "")
+	require.NoError(t, err)
+	assert.Equal(t, "room-1", room.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRoomService_CreateRoom_InvalidName(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+
+	for _, name := range []string{"", "   ", strings.Repeat("名", MaxRoomNameLength+1), "Bridge\x00club", "Line\nbreak"} {
+		_, err := service.CreateRoom(context.Background(), "north", name, "")
+		assert.ErrorIs(t, err, ErrInvalidRoomName, "%q", name)
+	}
+	mockRepo.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything)
+
+	// Names at the limit are counted in characters rather than bytes
+	_, err := cleanRoomName(strings.Repeat("名", MaxRoomNameLength))
+	assert.NoError(t, err)
+}
+
+func TestRoomService_CreateRoom_WithPreset(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+	ctx := context.Background()
+
+	var created *database.Room
+	mockRepo.On("CreateRoom", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*database.Room)
+		created.ID = "room-1"
+	}).Return(nil)
+	mockRepo.On("AddRoomParticipant", ctx, mock.Anything).Return(nil)
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north"), nil)
+
+	_, err := service.CreateRoom(ctx, "north", "Fast no-trump", domain.NoTrumpFastPreset)
+	require.NoError(t, err)
+
+	expected, err := domain.NoTrumpFastPreset.Rules()
+	require.NoError(t, err)
+	var stored domain.GameRules
+	require.NoError(t, json.Unmarshal(created.Rules, &stored))
+	assert.Equal(t, expected, stored)
+}
+
+func TestRoomService_CreateRoom_UnknownPreset(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+
+	_, err := service.CreateRoom(context.Background(), "north", "House rules", "anything_goes")
+	assert.ErrorIs(t, err, domain.ErrUnknownRulePreset)
+	mockRepo.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything)
+}
+
+func TestRoomService_KickParticipant(t *testing.T) {
+	service, mockRepo, notifier := setupRoomTestService()
+	ctx := context.Background()
+
+	room := newTestRoom("north", "east", "south")
+	room.CurrentPlayers = 3
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(room, nil)
+	mockRepo.On("RemoveRoomParticipant", ctx, "room-1", "east").Return(nil)
+	mockRepo.On("UpdateRoomPlayerCount", ctx, "room-1", 2).Return(nil)
+
+	require.NoError(t, service.KickParticipant(ctx, "room-1", "north", "east"))
+	mockRepo.AssertExpectations(t)
+
+	for _, userID := range []string{"north", "east", "south"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventPlayerKicked, messages[0].Type)
+		assert.Equal(t, "room-1", messages[0].RoomID)
+		assert.Equal(t, "east", messages[0].UserID)
+	}
+}
+
+func TestRoomService_KickParticipant_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		room    *database.Room
+		hostID  string
+		target  string
+		wantErr error
+	}{
+		{"Non-host", newTestRoom("north", "east", "south"), "east", "south", ErrNotRoomHost},
+		{"Host kicking themselves", newTestRoom("north", "east"), "north", "north", ErrCannotKickSelf},
+		{"Absent user", newTestRoom("north", "east"), "north", "west", ErrParticipantNotFound},
+		{"Game already started", func() *database.Room {
+			room := newTestRoom("north", "east", "south", "west")
+			room.Status = database.RoomStatusPlaying
+			return room
+		}(), "north", "east", ErrRoomNotWaiting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, notifier := setupRoomTestService()
+			ctx := context.Background()
+			mockRepo.On("GetRoomByID", ctx, "room-1").Return(tt.room, nil)
+
+			err := service.KickParticipant(ctx, "room-1", tt.hostID, tt.target)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			mockRepo.AssertNotCalled(t, "RemoveRoomParticipant", mock.Anything, mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "UpdateRoomPlayerCount", mock.Anything, mock.Anything, mock.Anything)
+			assert.Empty(t, notifier.received(tt.target))
+		})
+	}
+}
+
+func TestRoomService_KickParticipant_RoomNotFound(t *testing.T) {
+	service, mockRepo, _ := setupRoomTestService()
+	ctx := context.Background()
+	mockRepo.On("GetRoomByID", ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.KickParticipant(ctx, "missing", "north", "east")
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+}
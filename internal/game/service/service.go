@@ -1,23 +1,696 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
 	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
 
-	"github.com/go-redis/redis/v8"
+var (
+	// ErrGameNotFound is returned when a game does not exist
+	ErrGameNotFound = errors.New("game not found")
+	// ErrNotParticipant is returned when a user acts on a game they are not playing in
+	ErrNotParticipant = errors.New("user is not a participant in this game")
+	// ErrInvalidMove is returned when a player action breaks the rules of the
+	// game. It wraps the domain error, such as domain.ErrWrongPhase, saying why.
+	ErrInvalidMove = errors.New("invalid move")
+
+	// errNoChange lets a state mutation signal that nothing needs saving
+	errNoChange = errors.New("no change")
 )
 
+// maxSaveAttempts bounds how often a state update is retried after losing a race
+const maxSaveAttempts = 5
+
+// gameLockTTL bounds how long a crashed update can block a game
+const gameLockTTL = 5 * time.Second
+
+// GameLocker serializes mutations to a single game across service instances
+type GameLocker interface {
+	AcquireGameLock(ctx context.Context, gameID string, ttl time.Duration) (release func(), err error)
+}
+
+// Notifier delivers real-time messages to connected players
+type Notifier interface {
+	SendToUser(userID string, message interface{}) error
+}
+
 type GameService interface {
-	// Interface methods will be defined in later tasks
+	StartGame(ctx context.Context, roomID, userID string) (*domain.GameState, error)
+	PlaceBid(ctx context.Context, gameID, userID string, amount int) (*domain.GameState, error)
+	PassBid(ctx context.Context, gameID, userID string) (*domain.GameState, error)
+	UndoBid(ctx context.Context, gameID, userID string) (*domain.GameState, error)
+	DeclareTrump(ctx context.Context, gameID, userID string, suit domain.Suit) (*domain.GameState, error)
+	CallPartnerCard(ctx context.Context, gameID, userID string, card domain.Card) (*domain.GameState, error)
+	ExchangeKitty(ctx context.Context, gameID, userID string, discards []domain.Card) (*domain.GameState, error)
+	PlayCards(ctx context.Context, gameID, userID string, formation *domain.Formation) (*domain.GameState, error)
+	Concede(ctx context.Context, gameID, userID string, override bool) (*domain.GameState, error)
+	GetLegalMoves(ctx context.Context, gameID, userID string) ([]*domain.Formation, error)
+	GetAllowedActions(ctx context.Context, gameID, userID string) ([]domain.Action, error)
+	GetTrickHistory(ctx context.Context, gameID, userID string) ([]map[string]interface{}, error)
+	GetBidHistory(ctx context.Context, gameID, userID string) ([]domain.AnnotatedBid, error)
+	GetGameView(ctx context.Context, gameID, userID string) (*domain.GameView, error)
+	HandleDisconnect(ctx context.Context, gameID, userID string) error
+	ResumeGame(ctx context.Context, gameID, userID string) (*domain.GameView, error)
+	FinalizeGame(ctx context.Context, state *domain.GameState) error
+	GetScoreboard(ctx context.Context, gameID string) (*domain.Scoreboard, error)
+	GetCurrentGame(ctx context.Context, roomID, userID string) (*domain.GameView, error)
+	AbortGame(ctx context.Context, gameID string) (*domain.GameState, error)
+	GetDealRecord(ctx context.Context, gameID string) (*DealRecord, error)
 }
 
 type gameService struct {
 	repo        repository.GameRepository
-	redisClient *redis.Client
+	store       GameStateStore
+	locker      GameLocker
+	idempotency IdempotencyStore
+	notifier    Notifier
+	config      *config.Config
+	active      *activeGames
+	gameLocks   gameMutexes
+
+	timersMu    sync.Mutex
+	graceTimers map[string]*time.Timer
+	turnTimers  map[string]*time.Timer
 }
 
-func NewGameService(repo repository.GameRepository, redisClient *redis.Client) GameService {
+func NewGameService(repo repository.GameRepository, store GameStateStore, locker GameLocker, idempotency IdempotencyStore, notifier Notifier, config *config.Config) GameService {
 	return &gameService{
 		repo:        repo,
-		redisClient: redisClient,
+		store:       store,
+		locker:      locker,
+		idempotency: idempotency,
+		notifier:    notifier,
+		config:      config,
+		active:      newActiveGames(),
+		graceTimers: make(map[string]*time.Timer),
+		turnTimers:  make(map[string]*time.Timer),
+	}
+}
+
+// PlaceBid places a bid for the player
+func (s *gameService) PlaceBid(ctx context.Context, gameID, userID string, amount int) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("bid", amount), func(state *domain.GameState) error {
+		return state.PlaceBid(userID, amount)
+	})
+}
+
+// PassBid passes on bidding for the player
+func (s *gameService) PassBid(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("pass"), func(state *domain.GameState) error {
+		return state.PassBid(userID)
+	})
+}
+
+// UndoBid takes back the player's last bid or pass when the rules allow it
+func (s *gameService) UndoBid(ctx context.Context, gameID, userID string) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("undo_bid"), func(state *domain.GameState) error {
+		return state.UndoLastBid(userID)
+	})
+}
+
+// DeclareTrump declares the trump suit for the declarer. A rejected
+// declaration is still saved so it counts towards the declarer's attempts.
+func (s *gameService) DeclareTrump(ctx context.Context, gameID, userID string, suit domain.Suit) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("declare_trump", suit), func(state *domain.GameState) error {
+		err := state.DeclareTrump(userID, suit)
+		if errors.Is(err, domain.ErrInvalidTrump) {
+			return savedRejection{err}
+		}
+		return err
+	})
+}
+
+// CallPartnerCard names the card whose holder becomes the declarer's partner
+func (s *gameService) CallPartnerCard(ctx context.Context, gameID, userID string, card domain.Card) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("call_partner", card), func(state *domain.GameState) error {
+		return state.CallPartnerCard(userID, card)
+	})
+}
+
+// ExchangeKitty discards cards from the declarer's hand after picking up the kitty
+func (s *gameService) ExchangeKitty(ctx context.Context, gameID, userID string, discards []domain.Card) (*domain.GameState, error) {
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("exchange_kitty", discards), func(state *domain.GameState) error {
+		return state.ExchangeKitty(userID, discards)
+	})
+}
+
+// PlayCards plays the submitted cards as a formation of the claimed type for the player
+func (s *gameService) PlayCards(ctx context.Context, gameID, userID string, formation *domain.Formation) (*domain.GameState, error) {
+	if formation == nil {
+		return nil, fmt.Errorf("%w: no formation submitted", ErrInvalidMove)
+	}
+
+	request := requestFingerprint("play", formation.Type, formation.Cards)
+	return s.applyAction(ctx, gameID, userID, request, func(state *domain.GameState) error {
+		if state.TrumpSuit == nil {
+			return fmt.Errorf("no trump suit declared")
+		}
+
+		// Rebuild the formation from the submitted cards rather than trusting
+		// the client's claimed type and suit
+		played, err := domain.BuildFormation(formation.Cards, formation.Type, *state.TrumpSuit)
+		if err != nil {
+			return err
+		}
+		_, err = state.PlayCards(userID, played)
+		return err
+	})
+}
+
+// Concede records the player's concession for their team, ending the game
+// once their team agrees. With override set, the room's host ends the game
+// for their team without waiting for their teammates.
+func (s *gameService) Concede(ctx context.Context, gameID, userID string, override bool) (*domain.GameState, error) {
+	if override {
+		if err := s.checkGameHost(ctx, gameID, userID); err != nil {
+			return nil, err
+		}
+		return s.applyAction(ctx, gameID, userID, requestFingerprint("concede", override), func(state *domain.GameState) error {
+			return state.ConcedeForTeam(userID)
+		})
+	}
+	return s.applyAction(ctx, gameID, userID, requestFingerprint("concede", override), func(state *domain.GameState) error {
+		return state.Concede(userID)
+	})
+}
+
+// checkGameHost returns ErrNotRoomHost unless the user hosts the game's room
+func (s *gameService) checkGameHost(ctx context.Context, gameID, userID string) error {
+	state, err := s.store.GetGameState(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	room, err := s.repo.GetRoomByID(ctx, state.RoomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoomNotFound
+		}
+		return fmt.Errorf("failed to get room: %w", err)
+	}
+	if room.HostID != userID {
+		return ErrNotRoomHost
+	}
+	return nil
+}
+
+// GetLegalMoves returns the formations the player may play in the current trick
+func (s *gameService) GetLegalMoves(ctx context.Context, gameID, userID string) ([]*domain.Formation, error) {
+	state, err := s.store.GetGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.GetPlayer(userID) == nil {
+		return nil, ErrNotParticipant
+	}
+
+	moves, err := state.LegalMoves(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMove, err)
+	}
+	return moves, nil
+}
+
+// GetAllowedActions returns the kinds of action the player may take now
+func (s *gameService) GetAllowedActions(ctx context.Context, gameID, userID string) ([]domain.Action, error) {
+	state, err := s.store.GetGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.GetPlayer(userID) == nil {
+		return nil, ErrNotParticipant
+	}
+	return state.AllowedActions(userID)
+}
+
+// GetTrickHistory returns the tricks completed so far in a game to one of its players
+func (s *gameService) GetTrickHistory(ctx context.Context, gameID, userID string) ([]map[string]interface{}, error) {
+	state, err := s.store.GetGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.GetPlayer(userID) == nil {
+		return nil, ErrNotParticipant
+	}
+	return state.GetTrickHistory(), nil
+}
+
+// GetBidHistory returns a game's auction so far to one of its players
+func (s *gameService) GetBidHistory(ctx context.Context, gameID, userID string) ([]domain.AnnotatedBid, error) {
+	state, err := s.store.GetGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.GetPlayer(userID) == nil {
+		return nil, ErrNotParticipant
+	}
+	return state.GetBidHistory(), nil
+}
+
+// applyAction applies a player action to a game's live state, then lets any
+// bots or disconnected players whose turn follows play automatically. A
+// request retried with the same idempotency key gets the original result
+// without the action being applied again; request identifies the action and
+// its arguments so a key reused for another request is rejected.
+func (s *gameService) applyAction(ctx context.Context, gameID, userID, request string, action func(state *domain.GameState) error) (*domain.GameState, error) {
+	release, err := s.lockGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if state, err := s.replayResult(ctx, gameID, userID, request); state != nil || err != nil {
+		return state, err
+	}
+
+	var rejected error
+	var tricksBefore int
+	state, err := s.updateLockedState(ctx, gameID, func(state *domain.GameState) error {
+		if state.GetPlayer(userID) == nil {
+			return ErrNotParticipant
+		}
+		tricksBefore = len(state.Tricks)
+		if err := action(state); err != nil {
+			var saved savedRejection
+			if errors.As(err, &saved) {
+				rejected = saved.err
+				return nil
+			}
+			return fmt.Errorf("%w: %w", ErrInvalidMove, err)
+		}
+		s.runAutoActions(state)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejected != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMove, rejected)
+	}
+	s.recordResult(ctx, gameID, userID, request, state)
+	s.notifyTricksWon(state, tricksBefore)
+
+	if err := s.finalizeIfEnded(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// savedRejection is returned by an action that was rejected but changed the
+// state in a way that must still be saved, such as counting a failed attempt
+type savedRejection struct {
+	err error
+}
+
+func (r savedRejection) Error() string {
+	return r.err.Error()
+}
+
+// updateState loads a game's live state, applies the mutation and saves it
+// while holding the game's lock
+func (s *gameService) updateState(ctx context.Context, gameID string, mutate func(state *domain.GameState) error) (*domain.GameState, error) {
+	release, err := s.lockGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.updateLockedState(ctx, gameID, mutate)
+}
+
+// lockGame locks the game within this instance, then acquires the game's
+// shared lock if a locker is configured
+func (s *gameService) lockGame(ctx context.Context, gameID string) (release func(), err error) {
+	unlock, err := s.gameLocks.lock(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock game %s: %w", gameID, err)
+	}
+	if s.locker == nil {
+		return unlock, nil
+	}
+
+	releaseShared, err := s.locker.AcquireGameLock(ctx, gameID, gameLockTTL)
+	if err != nil {
+		unlock()
+		return nil, fmt.Errorf("failed to lock game %s: %w", gameID, err)
+	}
+	return func() {
+		releaseShared()
+		unlock()
+	}, nil
+}
+
+// updateLockedState loads a game's live state, applies the mutation and saves
+// it. The caller must hold the game's lock. If another writer saved the game
+// in the meantime the whole read-modify-write is retried on the fresh state.
+// A mutation returning errNoChange skips the save.
+func (s *gameService) updateLockedState(ctx context.Context, gameID string, mutate func(state *domain.GameState) error) (*domain.GameState, error) {
+	for attempt := 1; ; attempt++ {
+		state, err := s.store.GetGameState(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mutate(state); err != nil {
+			if errors.Is(err, errNoChange) {
+				return state, nil
+			}
+			return nil, err
+		}
+
+		err = s.store.SaveGameState(ctx, state)
+		if err == nil {
+			s.active.observe(state)
+			s.scheduleTurnExpiry(state)
+			return state, nil
+		}
+		if !errors.Is(err, database.ErrStaleGameState) || attempt >= maxSaveAttempts {
+			return nil, err
+		}
 	}
-}
\ No newline at end of file
+}
+
+// finalizeIfEnded records the result once a game has ended and tells the
+// players how it finished
+func (s *gameService) finalizeIfEnded(ctx context.Context, state *domain.GameState) error {
+	if state.Phase != domain.PhaseEnded {
+		return nil
+	}
+	err := s.FinalizeGame(ctx, state)
+	s.notifyGameEnded(state)
+	return err
+}
+
+// notifyTricksWon sends every player the result of each trick completed after
+// the first tricksBefore, whether by the acting player or by the automatic
+// plays that followed
+func (s *gameService) notifyTricksWon(state *domain.GameState, tricksBefore int) {
+	for number := tricksBefore + 1; number <= len(state.Tricks); number++ {
+		s.broadcast(state, ws.WSMessage{
+			Type:    ws.EventTrickWon,
+			GameID:  state.ID,
+			RoomID:  state.RoomID,
+			Payload: state.GetTrickResult(number),
+		})
+	}
+}
+
+// notifyGameEnded sends every player the final scoreboard, with the winning
+// team, the margin and each player's captured points
+func (s *gameService) notifyGameEnded(state *domain.GameState) {
+	s.broadcast(state, ws.WSMessage{
+		Type:    ws.EventGameEnded,
+		GameID:  state.ID,
+		RoomID:  state.RoomID,
+		Payload: state.GetScoreboard(),
+	})
+}
+
+// FinalizeGame records the outcome of an ended game in each player's statistics,
+// including captured points, streaks and the declarer's average contract, and transfers
+// rating points from the losing team to the winning team, conserving the total
+// when the teams differ in size. Everything is written in one transaction, and a
+// game that has already been recorded is left as it is.
+func (s *gameService) FinalizeGame(ctx context.Context, state *domain.GameState) error {
+	if state.Phase != domain.PhaseEnded || state.WinnerTeam == nil {
+		return fmt.Errorf("game %s has not ended", state.ID)
+	}
+
+	recorded := false
+	err := s.repo.WithTransaction(ctx, func(repo repository.GameRepository) error {
+		game, err := findGame(ctx, repo, state.ID)
+		if err != nil {
+			return err
+		}
+		// A retried finalization must not count the result twice
+		if game.EndedAt != nil {
+			return nil
+		}
+		if err := s.recordGameResult(ctx, repo, game, state); err != nil {
+			return err
+		}
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if recorded {
+		gamesCompleted.WithLabelValues(*state.WinnerTeam).Inc()
+	}
+	return nil
+}
+
+// recordGameResult saves the game's result and updates each player's statistics
+func (s *gameService) recordGameResult(ctx context.Context, repo repository.GameRepository, game *database.Game, state *domain.GameState) error {
+	declarerWon := *state.WinnerTeam == domain.TeamDeclarer
+
+	playerStats := make(map[string]*database.UserStats, len(state.Players))
+	var winnerRatings, loserRatings []int
+	for _, player := range state.Players {
+		stats, err := s.getOrCreateStats(ctx, repo, player.ID)
+		if err != nil {
+			return err
+		}
+		playerStats[player.ID] = stats
+
+		if state.IsOnDeclarerTeam(player.Position) == declarerWon {
+			winnerRatings = append(winnerRatings, stats.Rating)
+		} else {
+			loserRatings = append(loserRatings, stats.Rating)
+		}
+	}
+
+	scoreboard := state.GetScoreboard()
+	if err := saveGameResult(ctx, repo, game, state, scoreboard); err != nil {
+		return err
+	}
+	// The room's players may go on to deal another game in it
+	if err := repo.UpdateRoomStatus(ctx, state.RoomID, database.RoomStatusWaiting); err != nil {
+		return fmt.Errorf("failed to update room status: %w", err)
+	}
+
+	pointsCaptured := make(map[string]int, len(scoreboard.Players))
+	for _, score := range scoreboard.Players {
+		pointsCaptured[score.PlayerID] = score.PointsCaptured
+	}
+
+	change := calculateRatingChange(winnerRatings, loserRatings, s.config.Rating.KFactor)
+	gain, loss := splitRatingChange(change, len(winnerRatings), len(loserRatings))
+
+	for _, player := range state.Players {
+		stats := playerStats[player.ID]
+		stats.GamesPlayed++
+		stats.TotalPoints += pointsCaptured[player.ID]
+
+		won := state.IsOnDeclarerTeam(player.Position) == declarerWon
+		if won {
+			stats.GamesWon++
+			stats.Rating += gain
+		} else {
+			stats.Rating -= loss
+		}
+		updateStreak(stats, won)
+
+		if state.Declarer != nil && player.Position == *state.Declarer {
+			stats.GamesAsDeclarer++
+			// Running average of the contracts taken as declarer
+			stats.AverageBid += (float64(state.Contract) - stats.AverageBid) / float64(stats.GamesAsDeclarer)
+			if declarerWon {
+				stats.DeclarerWins++
+			}
+		}
+
+		if err := repo.UpdateUserStats(ctx, stats); err != nil {
+			return fmt.Errorf("failed to update stats for player %s: %w", player.ID, err)
+		}
+	}
+	return nil
+}
+
+// updateStreak extends the player's run of wins or losses, or starts a new one
+// when the result breaks it, and records their best winning run
+func updateStreak(stats *database.UserStats, won bool) {
+	switch {
+	case won && stats.CurrentStreak > 0:
+		stats.CurrentStreak++
+	case won:
+		stats.CurrentStreak = 1
+	case stats.CurrentStreak < 0:
+		stats.CurrentStreak--
+	default:
+		stats.CurrentStreak = -1
+	}
+	if stats.CurrentStreak > stats.BestStreak {
+		stats.BestStreak = stats.CurrentStreak
+	}
+}
+
+// GetScoreboard returns the per-player captured points of a finished game,
+// or ErrGameNotEnded while it is still being played
+func (s *gameService) GetScoreboard(ctx context.Context, gameID string) (*domain.Scoreboard, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt == nil {
+		return nil, ErrGameNotEnded
+	}
+
+	state, err := recordedState(game)
+	if err != nil {
+		return nil, err
+	}
+	return state.GetScoreboard(), nil
+}
+
+// GetCurrentGame returns the most recent game started in a room as seen by
+// the requesting player. Live games are read from the state store and
+// finished ones from their recorded state.
+func (s *gameService) GetCurrentGame(ctx context.Context, roomID, userID string) (*domain.GameView, error) {
+	game, err := s.repo.GetGameByRoomID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	return s.viewGame(ctx, game, userID)
+}
+
+// GetGameView returns a game as seen by one of its players. Live games are
+// read from the state store and finished ones from their recorded state.
+func (s *gameService) GetGameView(ctx context.Context, gameID, userID string) (*domain.GameView, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return s.viewGame(ctx, game, userID)
+}
+
+// viewGame loads a game's state and returns it as seen by one of its players
+func (s *gameService) viewGame(ctx context.Context, game *database.Game, userID string) (*domain.GameView, error) {
+	var state *domain.GameState
+	var err error
+	if game.EndedAt != nil {
+		state, err = recordedState(game)
+	} else {
+		state, err = s.store.GetGameState(ctx, game.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.GetPlayer(userID) == nil {
+		return nil, ErrNotParticipant
+	}
+	return state.ViewFor(userID)
+}
+
+// recordedState decodes the game state saved with a finished game
+func recordedState(game *database.Game) (*domain.GameState, error) {
+	if len(game.GameData) == 0 {
+		return nil, fmt.Errorf("game %s has no recorded state", game.ID)
+	}
+
+	var state domain.GameState
+	if err := json.Unmarshal(game.GameData, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode game state: %w", err)
+	}
+	return &state, nil
+}
+
+// getGame loads a game record, translating missing records to ErrGameNotFound
+func (s *gameService) getGame(ctx context.Context, gameID string) (*database.Game, error) {
+	return findGame(ctx, s.repo, gameID)
+}
+
+// findGame loads a game record through repo, translating missing records to
+// ErrGameNotFound
+func findGame(ctx context.Context, repo repository.GameRepository, gameID string) (*database.Game, error) {
+	game, err := repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	return game, nil
+}
+
+// saveGameResult persists the final game state and each participant's captured points
+func saveGameResult(ctx context.Context, repo repository.GameRepository, game *database.Game, state *domain.GameState, scoreboard *domain.Scoreboard) error {
+	gameData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode game state: %w", err)
+	}
+
+	now := time.Now()
+
+	game.Contract = state.Contract
+	game.FinalScore = scoreboard.DefendersPoints
+	game.WinnerTeam = state.WinnerTeam
+	game.GameData = datatypes.JSON(gameData)
+	game.EndedAt = &now
+	if state.Declarer != nil {
+		declarer := state.GetPlayerByPosition(*state.Declarer)
+		game.DeclarerID = &declarer.ID
+	}
+	if state.TrumpSuit != nil {
+		trumpSuit := state.TrumpSuit.String()
+		game.TrumpSuit = &trumpSuit
+	}
+
+	if err := repo.UpdateGame(ctx, game); err != nil {
+		return fmt.Errorf("failed to update game: %w", err)
+	}
+
+	for _, score := range scoreboard.Players {
+		participant := &database.GameParticipant{
+			GameID:         state.ID,
+			UserID:         score.PlayerID,
+			Position:       int(score.Position),
+			Role:           score.Role,
+			PointsCaptured: score.PointsCaptured,
+		}
+		if err := repo.UpdateGameParticipant(ctx, participant); err != nil {
+			return fmt.Errorf("failed to update participant %s: %w", score.PlayerID, err)
+		}
+	}
+
+	return nil
+}
+
+// getOrCreateStats loads a player's statistics, creating them at the base rating
+// for players who have never finished a game
+func (s *gameService) getOrCreateStats(ctx context.Context, repo repository.GameRepository, userID string) (*database.UserStats, error) {
+	stats, err := repo.GetUserStats(ctx, userID)
+	if err == nil {
+		return stats, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get stats for player %s: %w", userID, err)
+	}
+
+	stats = &database.UserStats{
+		UserID: userID,
+		Rating: s.config.Rating.BaseRating,
+	}
+	if err := repo.CreateUserStats(ctx, stats); err != nil {
+		return nil, fmt.Errorf("failed to create stats for player %s: %w", userID, err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,623 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockGameRepository is a mock implementation of GameRepository
+type MockGameRepository struct {
+	mock.Mock
+}
+
+func (m *MockGameRepository) GetRoomByID(ctx context.Context, id string) (*database.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Room), args.Error(1)
+}
+
+func (m *MockGameRepository) GetIdleRooms(ctx context.Context, status string, idleSince time.Time, limit int) ([]database.Room, error) {
+	args := m.Called(ctx, status, idleSince, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.Room), args.Error(1)
+}
+
+func (m *MockGameRepository) UpdateRoomStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) TransitionRoomStatus(ctx context.Context, id, from, to string) (bool, error) {
+	args := m.Called(ctx, id, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGameRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) UpdateRoomPlayerCount(ctx context.Context, id string, count int) error {
+	args := m.Called(ctx, id, count)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) CreateRoom(ctx context.Context, room *database.Room) error {
+	args := m.Called(ctx, room)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) AddRoomParticipant(ctx context.Context, participant *database.RoomParticipant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) RemoveRoomParticipant(ctx context.Context, roomID, userID string) error {
+	args := m.Called(ctx, roomID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) CreateGame(ctx context.Context, game *database.Game) error {
+	args := m.Called(ctx, game)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) AddGameParticipant(ctx context.Context, participant *database.GameParticipant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) GetGameByID(ctx context.Context, id string) (*database.Game, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) GetGameByRoomID(ctx context.Context, roomID string) (*database.Game, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) UpdateGame(ctx context.Context, game *database.Game) error {
+	args := m.Called(ctx, game)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) LinkRematchRoom(ctx context.Context, gameID, roomID string) (bool, error) {
+	args := m.Called(ctx, gameID, roomID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGameRepository) UpdateGameParticipant(ctx context.Context, participant *database.GameParticipant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) GetUserStats(ctx context.Context, userID string) (*database.UserStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.UserStats), args.Error(1)
+}
+
+func (m *MockGameRepository) CreateUserStats(ctx context.Context, stats *database.UserStats) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
+// WithTransaction runs fn against the mock itself, so a transaction's calls
+// are matched against the same expectations
+func (m *MockGameRepository) WithTransaction(ctx context.Context, fn func(repo repository.GameRepository) error) error {
+	return fn(m)
+}
+
+func (m *MockGameRepository) UpdateUserStats(ctx context.Context, stats *database.UserStats) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
+func setupTestService() (*gameService, *MockGameRepository) {
+	mockRepo := &MockGameRepository{}
+	cfg := &config.Config{
+		Rating: config.RatingConfig{
+			BaseRating: 1500,
+			KFactor:    32,
+		},
+	}
+
+	service := &gameService{
+		repo:   mockRepo,
+		config: cfg,
+	}
+
+	return service, mockRepo
+}
+
+// newEndedGame returns a finished game where North declared and the given team won
+func newEndedGame(t *testing.T, winnerTeam string) *domain.GameState {
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+
+	declarer := domain.North
+	state.Declarer = &declarer
+	state.Contract = 100
+	state.WinnerTeam = &winnerTeam
+	state.Phase = domain.PhaseEnded
+	return state
+}
+
+// expectGameResultSaved sets up the game record reads and writes made when finalizing a game
+func expectGameResultSaved(mockRepo *MockGameRepository, ctx context.Context, gameID string) {
+	mockRepo.On("GetGameByID", ctx, gameID).Return(&database.Game{ID: gameID, RoomID: "room-1"}, nil)
+	mockRepo.On("UpdateGame", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateGameParticipant", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateRoomStatus", ctx, "room-1", database.RoomStatusWaiting).Return(nil)
+}
+
+func TestGameService_FinalizeGame_NewPlayersStartAtBaseRating(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	state := newEndedGame(t, "declarer")
+
+	updated := make(map[string]*database.UserStats)
+	expectGameResultSaved(mockRepo, ctx, state.ID)
+	mockRepo.On("GetUserStats", ctx, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("CreateUserStats", ctx, mock.MatchedBy(func(stats *database.UserStats) bool {
+		return stats.Rating == 1500
+	})).Return(nil)
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stats := args.Get(1).(*database.UserStats)
+		updated[stats.UserID] = stats
+	}).Return(nil)
+
+	err := service.FinalizeGame(ctx, state)
+	require.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "CreateUserStats", 4)
+	assert.Equal(t, 1516, updated["north"].Rating)
+	assert.Equal(t, 1516, updated["south"].Rating)
+	assert.Equal(t, 1484, updated["east"].Rating)
+	assert.Equal(t, 1484, updated["west"].Rating)
+	assert.Equal(t, 1, updated["north"].GamesAsDeclarer)
+	assert.Equal(t, 1, updated["north"].DeclarerWins)
+	assert.Equal(t, 0, updated["south"].GamesAsDeclarer)
+}
+
+func TestGameService_FinalizeGame_SymmetricRatingTransfer(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	state := newEndedGame(t, "defenders")
+
+	initial := map[string]int{"north": 1620, "east": 1480, "south": 1550, "west": 1390}
+	updated := make(map[string]*database.UserStats)
+	expectGameResultSaved(mockRepo, ctx, state.ID)
+	for userID, rating := range initial {
+		mockRepo.On("GetUserStats", ctx, userID).Return(&database.UserStats{
+			UserID:      userID,
+			GamesPlayed: 3,
+			Rating:      rating,
+		}, nil)
+	}
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stats := args.Get(1).(*database.UserStats)
+		updated[stats.UserID] = stats
+	}).Return(nil)
+
+	err := service.FinalizeGame(ctx, state)
+	require.NoError(t, err)
+
+	gained := (updated["east"].Rating - initial["east"]) + (updated["west"].Rating - initial["west"])
+	lost := (initial["north"] - updated["north"].Rating) + (initial["south"] - updated["south"].Rating)
+	assert.Greater(t, gained, 0)
+	assert.Equal(t, gained, lost)
+
+	for userID, stats := range updated {
+		assert.Equal(t, 4, stats.GamesPlayed, userID)
+	}
+	assert.Equal(t, 1, updated["east"].GamesWon)
+	assert.Equal(t, 0, updated["north"].GamesWon)
+	assert.Equal(t, 0, updated["north"].DeclarerWins)
+	mockRepo.AssertNotCalled(t, "CreateUserStats", mock.Anything, mock.Anything)
+}
+
+func TestGameService_FinalizeGame_ConservesRatingForLoneDeclarer(t *testing.T) {
+	for _, winnerTeam := range []string{domain.TeamDeclarer, domain.TeamDefenders} {
+		t.Run(winnerTeam, func(t *testing.T) {
+			service, mockRepo := setupTestService()
+			ctx := context.Background()
+
+			// North called a card nobody else held, so plays alone against three
+			state := newEndedGame(t, winnerTeam)
+			state.Rules.Partnership = domain.CalledCard
+
+			updated := make(map[string]*database.UserStats)
+			expectGameResultSaved(mockRepo, ctx, state.ID)
+			mockRepo.On("GetUserStats", ctx, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+			mockRepo.On("CreateUserStats", ctx, mock.Anything).Return(nil)
+			mockRepo.On("UpdateUserStats", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				stats := args.Get(1).(*database.UserStats)
+				updated[stats.UserID] = stats
+			}).Return(nil)
+
+			require.NoError(t, service.FinalizeGame(ctx, state))
+
+			total := 0
+			for _, stats := range updated {
+				total += stats.Rating
+			}
+			assert.Equal(t, 4*1500, total, "rating must be conserved")
+			assert.NotEqual(t, 1500, updated["north"].Rating)
+			assert.Equal(t, updated["east"].Rating, updated["south"].Rating)
+		})
+	}
+}
+
+func TestGameService_FinalizeGame_RecordsGameOnce(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	north := &database.UserStats{UserID: "north", Rating: 1500}
+	expectGameResultSaved(mockRepo, ctx, "game-1")
+	mockRepo.On("GetUserStats", ctx, "north").Return(north, nil)
+	mockRepo.On("GetUserStats", ctx, mock.Anything).Return(&database.UserStats{Rating: 1500}, nil)
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Return(nil)
+
+	// Finalizing the same game again, e.g. after a retry, leaves it as recorded
+	state := newEndedGame(t, "declarer")
+	require.NoError(t, service.FinalizeGame(ctx, state))
+	require.NoError(t, service.FinalizeGame(ctx, state))
+
+	assert.Equal(t, 1, north.GamesPlayed)
+	assert.Equal(t, 1516, north.Rating)
+	mockRepo.AssertNumberOfCalls(t, "UpdateGame", 1)
+}
+
+func TestGameService_FinalizeGame_TracksStreaks(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	stats := map[string]*database.UserStats{
+		"north": {UserID: "north", Rating: 1500},
+		"east":  {UserID: "east", Rating: 1500, BestStreak: 5},
+		"south": {UserID: "south", Rating: 1500},
+		"west":  {UserID: "west", Rating: 1500},
+	}
+	for userID, userStats := range stats {
+		mockRepo.On("GetUserStats", ctx, userID).Return(userStats, nil)
+	}
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Return(nil)
+
+	// North and South win twice, then lose
+	for i, winner := range []string{"declarer", "declarer", "defenders"} {
+		state := newEndedGame(t, winner)
+		state.ID = fmt.Sprintf("game-%d", i+1)
+		expectGameResultSaved(mockRepo, ctx, state.ID)
+		require.NoError(t, service.FinalizeGame(ctx, state))
+	}
+
+	assert.Equal(t, -1, stats["north"].CurrentStreak, "a loss resets the winning streak")
+	assert.Equal(t, 2, stats["north"].BestStreak)
+	assert.Equal(t, 2, stats["south"].BestStreak)
+	assert.Equal(t, 1, stats["east"].CurrentStreak)
+	assert.Equal(t, 5, stats["east"].BestStreak, "a shorter streak keeps the best one")
+}
+
+func TestUpdateStreak(t *testing.T) {
+	stats := &database.UserStats{}
+	for _, tt := range []struct {
+		won         bool
+		wantCurrent int
+		wantBest    int
+	}{
+		{true, 1, 1},
+		{true, 2, 2},
+		{true, 3, 3},
+		{false, -1, 3},
+		{false, -2, 3},
+		{true, 1, 3},
+	} {
+		updateStreak(stats, tt.won)
+		assert.Equal(t, tt.wantCurrent, stats.CurrentStreak)
+		assert.Equal(t, tt.wantBest, stats.BestStreak)
+	}
+}
+
+func TestGameService_FinalizeGame_RequiresEndedGame(t *testing.T) {
+	service, _ := setupTestService()
+	state := newEndedGame(t, "declarer")
+	state.Phase = domain.PhasePlaying
+
+	err := service.FinalizeGame(context.Background(), state)
+	assert.Error(t, err)
+}
+
+func TestGameService_FinalizeGame_RecordsCapturedPoints(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	trump := domain.Spades
+	declarer := domain.North
+	state.TrumpSuit = &trump
+	state.Declarer = &declarer
+	state.Contract = 100
+
+	trick := domain.NewTrick("game-1_trick_1", domain.North)
+	plays := map[domain.PlayerPosition]domain.Card{
+		domain.North: domain.NewCard(domain.Hearts, domain.King, 1),
+		domain.East:  domain.NewCard(domain.Hearts, domain.Five, 1),
+		domain.South: domain.NewCard(domain.Hearts, domain.Ten, 1),
+		domain.West:  domain.NewCard(domain.Hearts, domain.Ace, 1),
+	}
+	for _, position := range trick.GetPlayOrder() {
+		require.NoError(t, trick.AddPlay(position, domain.NewSingle(plays[position]), trump))
+	}
+	state.Tricks = append(state.Tricks, *trick)
+	state.CalculateFinalScore()
+
+	var savedGame *database.Game
+	participants := make(map[string]*database.GameParticipant)
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(&database.Game{ID: "game-1", RoomID: "room-1"}, nil)
+	mockRepo.On("UpdateGame", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		savedGame = args.Get(1).(*database.Game)
+	}).Return(nil)
+	mockRepo.On("UpdateGameParticipant", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		participant := args.Get(1).(*database.GameParticipant)
+		participants[participant.UserID] = participant
+	}).Return(nil)
+	mockRepo.On("UpdateRoomStatus", ctx, "room-1", database.RoomStatusWaiting).Return(nil)
+	mockRepo.On("GetUserStats", ctx, mock.Anything).Return(&database.UserStats{Rating: 1500}, nil)
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Return(nil)
+
+	err = service.FinalizeGame(ctx, state)
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "UpdateRoomStatus", ctx, "room-1", database.RoomStatusWaiting)
+
+	require.NotNil(t, savedGame)
+	assert.Equal(t, 25, savedGame.FinalScore)
+	assert.Equal(t, "declarer", *savedGame.WinnerTeam)
+	assert.Equal(t, "north", *savedGame.DeclarerID)
+	assert.NotNil(t, savedGame.EndedAt)
+
+	require.Len(t, participants, 4)
+	assert.Equal(t, 25, participants["west"].PointsCaptured)
+	assert.Equal(t, 0, participants["north"].PointsCaptured)
+	assert.Equal(t, domain.RoleDeclarer, participants["north"].Role)
+	assert.Equal(t, domain.RolePartner, participants["south"].Role)
+	assert.Equal(t, domain.RoleDefender, participants["west"].Role)
+}
+
+func TestGameService_FinalizeIfEnded_BroadcastsGameEnded(t *testing.T) {
+	service, mockRepo := setupTestService()
+	notifier := newRecordingNotifier()
+	service.notifier = notifier
+	ctx := context.Background()
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	trump := domain.Spades
+	declarer := domain.North
+	state.TrumpSuit = &trump
+	state.Declarer = &declarer
+	state.Contract = 100
+
+	trick := domain.NewTrick("game-1_trick_1", domain.North)
+	plays := map[domain.PlayerPosition]domain.Card{
+		domain.North: domain.NewCard(domain.Hearts, domain.King, 1),
+		domain.East:  domain.NewCard(domain.Hearts, domain.Five, 1),
+		domain.South: domain.NewCard(domain.Hearts, domain.Ten, 1),
+		domain.West:  domain.NewCard(domain.Hearts, domain.Ace, 1),
+	}
+	for _, position := range trick.GetPlayOrder() {
+		require.NoError(t, trick.AddPlay(position, domain.NewSingle(plays[position]), trump))
+	}
+	state.Tricks = append(state.Tricks, *trick)
+	state.CalculateFinalScore()
+
+	expectGameResultSaved(mockRepo, ctx, "game-1")
+	mockRepo.On("GetUserStats", ctx, mock.Anything).Return(&database.UserStats{Rating: 1500}, nil)
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Return(nil)
+
+	require.NoError(t, service.finalizeIfEnded(ctx, state))
+
+	for _, userID := range []string{"north", "east", "south", "west"} {
+		messages := notifier.received(userID)
+		require.Len(t, messages, 1, userID)
+		assert.Equal(t, ws.EventGameEnded, messages[0].Type)
+		assert.Equal(t, "game-1", messages[0].GameID)
+
+		scoreboard, ok := messages[0].Payload.(*domain.Scoreboard)
+		require.True(t, ok, userID)
+		require.NotNil(t, scoreboard.WinnerTeam)
+		assert.Equal(t, domain.TeamDeclarer, *scoreboard.WinnerTeam)
+		require.NotNil(t, scoreboard.Margin)
+		assert.Equal(t, 75, *scoreboard.Margin)
+		assert.Equal(t, 25, scoreboard.DefendersPoints)
+		assert.Equal(t, 100, scoreboard.Contract)
+		assert.Len(t, scoreboard.Players, 4)
+	}
+}
+
+func TestGameService_FinalizeGame_TracksDeclarerAnalytics(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	// Each player's stats are updated in place across games
+	stored := make(map[string]*database.UserStats)
+	for _, userID := range []string{"north", "east", "south", "west"} {
+		stored[userID] = &database.UserStats{UserID: userID, Rating: 1500}
+		mockRepo.On("GetUserStats", ctx, userID).Return(stored[userID], nil)
+	}
+	mockRepo.On("UpdateUserStats", ctx, mock.Anything).Return(nil)
+
+	// North declares three games, making two of the contracts
+	games := []struct {
+		contract   int
+		winnerTeam string
+	}{
+		{100, "declarer"},
+		{130, "defenders"},
+		{160, "declarer"},
+	}
+	for i, game := range games {
+		state := newEndedGame(t, game.winnerTeam)
+		state.ID = fmt.Sprintf("game-%d", i+1)
+		state.Contract = game.contract
+		expectGameResultSaved(mockRepo, ctx, state.ID)
+		require.NoError(t, service.FinalizeGame(ctx, state))
+	}
+
+	north := stored["north"]
+	assert.Equal(t, 3, north.GamesPlayed)
+	assert.Equal(t, 3, north.GamesAsDeclarer)
+	assert.Equal(t, 2, north.DeclarerWins)
+	assert.InDelta(t, 130.0, north.AverageBid, 0.001)
+
+	south := stored["south"]
+	assert.Equal(t, 2, south.GamesWon)
+	assert.Equal(t, 0, south.GamesAsDeclarer)
+	assert.Zero(t, south.AverageBid, "partners do not take the contract")
+}
+
+func TestGameService_GetScoreboard(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	state := newEndedGame(t, "declarer")
+	state.Scores["east"] = 30
+	gameData, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	endedAt := time.Now()
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(&database.Game{ID: "game-1", GameData: gameData, EndedAt: &endedAt}, nil)
+	mockRepo.On("GetGameByID", ctx, "live").Return(&database.Game{ID: "live"}, nil)
+	mockRepo.On("GetGameByID", ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	scoreboard, err := service.GetScoreboard(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, "game-1", scoreboard.GameID)
+	assert.Equal(t, "declarer", *scoreboard.WinnerTeam)
+	assert.Len(t, scoreboard.Players, 4)
+
+	_, err = service.GetScoreboard(ctx, "live")
+	assert.ErrorIs(t, err, ErrGameNotEnded, "the result of a game in progress is not known yet")
+
+	_, err = service.GetScoreboard(ctx, "missing")
+	assert.ErrorIs(t, err, ErrGameNotFound)
+}
+
+func TestGameService_GetGameView(t *testing.T) {
+	repo := &MockGameRepository{}
+	store := newMemoryStateStore()
+	service := NewGameService(repo, store, nil, nil, newRecordingNotifier(), &config.Config{})
+	ctx := context.Background()
+
+	state := newPlayingGame(t)
+	require.NoError(t, store.SaveGameState(ctx, state))
+	repo.On("GetGameByID", ctx, "game-1").Return(&database.Game{ID: "game-1", RoomID: "room-1"}, nil)
+
+	view, err := service.GetGameView(ctx, "game-1", "east")
+	require.NoError(t, err)
+	assert.Equal(t, domain.East, view.Position)
+	assert.Equal(t, state.Players[domain.East].Hand, view.Hand)
+	assert.Empty(t, view.Kitty, "Only the declarer sees the kitty")
+
+	_, err = service.GetGameView(ctx, "game-1", "stranger")
+	assert.ErrorIs(t, err, ErrNotParticipant)
+}
+
+func TestGameService_PlayCards_RejectsMislabelledFormation(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	bigJokers := []domain.Card{domain.NewJoker(domain.BigJoker, 1), domain.NewJoker(domain.BigJoker, 2)}
+	mislabelled := []*domain.Formation{
+		{Type: domain.Tractor, Cards: bigJokers},
+		{Type: domain.Single, Cards: bigJokers},
+		{Type: domain.Pair, Cards: []domain.Card{domain.NewCard(domain.Spades, domain.Ten, 1)}},
+	}
+	for _, formation := range mislabelled {
+		_, err := service.PlayCards(ctx, "game-1", "north", formation)
+		assert.ErrorIs(t, err, ErrInvalidMove, formation.String())
+	}
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Nil(t, state.CurrentTrick, "rejected plays never reach the trick")
+	assert.Equal(t, 25, state.Players[domain.North].GetHandSize())
+
+	// The claimed suit is ignored in favour of the one the cards actually form
+	claimed := &domain.Formation{Type: domain.Single, Cards: []domain.Card{domain.NewCard(domain.Spades, domain.Ten, 1)}, Suit: domain.Diamonds}
+	state, err = service.PlayCards(ctx, "game-1", "north", claimed)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Spades, state.CurrentTrick.Plays[domain.North].Suit)
+}
+
+func TestGameService_DeclareTrump_SavesRejectedAttempt(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+
+	rules := domain.DefaultRules()
+	rules.TrumpMustBeHeld = true
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		rules)
+	require.NoError(t, err)
+	require.NoError(t, state.DealCards(domain.NewDeck()))
+	require.NoError(t, state.PlaceBid("north", 120))
+	for _, playerID := range []string{"east", "south", "west"} {
+		require.NoError(t, state.PassBid(playerID))
+	}
+	require.NoError(t, store.SaveGameState(ctx, state))
+
+	// North holds no Clubs from an unshuffled deck
+	_, err = service.DeclareTrump(ctx, "game-1", "north", domain.Clubs)
+	assert.ErrorIs(t, err, ErrInvalidMove)
+	assert.ErrorIs(t, err, domain.ErrInvalidTrump)
+
+	state, err = store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhaseTrumpDeclaration, state.Phase)
+	assert.Equal(t, 1, state.RejectedTrumps, "the rejected attempt is counted")
+
+	state, err = service.DeclareTrump(ctx, "game-1", "north", domain.Hearts)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhaseKittyExchange, state.Phase)
+	assert.Equal(t, domain.Hearts, *state.TrumpSuit)
+}
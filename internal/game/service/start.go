@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/repository"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRoomNotFound is returned when a room does not exist
+	ErrRoomNotFound = errors.New("room not found")
+	// ErrNotRoomHost is returned when someone other than the host tries to manage the room
+	ErrNotRoomHost = errors.New("only the room host can manage the room")
+	// ErrRoomNotFull is returned when starting a game without four players seated
+	ErrRoomNotFull = errors.New("room needs exactly 4 players to start")
+	// ErrRoomNotWaiting is returned when starting a game in a room that is already playing or closed
+	ErrRoomNotWaiting = errors.New("room is not waiting for a game to start")
+)
+
+// startRequest identifies a request to start a room's game, which takes no arguments
+var startRequest = requestFingerprint("start")
+
+// StartGame deals a new game for a full room and moves the room to playing.
+// Only the room's host may start it.
+func (s *gameService) StartGame(ctx context.Context, roomID, userID string) (*domain.GameState, error) {
+	if state, err := s.replayResult(ctx, roomID, userID, startRequest); state != nil || err != nil {
+		return state, err
+	}
+
+	room, err := s.repo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	if room.HostID != userID {
+		return nil, ErrNotRoomHost
+	}
+	if room.Status != database.RoomStatusWaiting {
+		return nil, ErrRoomNotWaiting
+	}
+	if len(room.Participants) != 4 {
+		return nil, ErrRoomNotFull
+	}
+
+	// Seat players by their position in the room
+	participants := append([]database.RoomParticipant(nil), room.Participants...)
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].Position < participants[j].Position
+	})
+	playerIDs := make([]string, len(participants))
+	playerNames := make([]string, len(participants))
+	for i, participant := range participants {
+		playerIDs[i] = participant.UserID
+		playerNames[i] = participant.User.Name
+	}
+
+	rules, err := s.roomRules(room)
+	if err != nil {
+		return nil, err
+	}
+	state, err := domain.NewGameState(uuid.New().String(), room.ID, playerIDs, playerNames, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game: %w", err)
+	}
+	if err := s.rotateStartingPosition(ctx, state); err != nil {
+		return nil, err
+	}
+	// The seed is recorded with the game so a disputed deal can be reproduced
+	seed := domain.NewDealSeed()
+	deck := domain.NewDeck()
+	deck.ShuffleWithSeed(seed)
+	deal := state.DealCards
+	if s.config.Game.RecordDealOrder {
+		deal = state.DealCardsRecordingOrder
+	}
+	if err := deal(deck); err != nil {
+		return nil, fmt.Errorf("failed to deal cards: %w", err)
+	}
+
+	// Claim the room before creating the game, so that of two starts racing
+	// past the status check above only one deals a game
+	claimed, err := s.repo.TransitionRoomStatus(ctx, room.ID, database.RoomStatusWaiting, database.RoomStatusPlaying)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update room status: %w", err)
+	}
+	if !claimed {
+		return nil, ErrRoomNotWaiting
+	}
+
+	// The records are only kept once the live state is saved, so a failed
+	// start leaves no partial game behind
+	err = s.repo.WithTransaction(ctx, func(repo repository.GameRepository) error {
+		if err := createGameRecord(ctx, repo, state, seed); err != nil {
+			return err
+		}
+		if err := s.store.SaveGameState(ctx, state); err != nil {
+			return fmt.Errorf("failed to save game state: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.releaseRoom(ctx, room.ID)
+		return nil, err
+	}
+	s.active.observe(state)
+	s.scheduleTurnExpiry(state)
+	s.recordResult(ctx, roomID, userID, startRequest, state)
+
+	s.notifyGameStarted(state)
+	return state, nil
+}
+
+// releaseRoom returns a room claimed by a start that failed to waiting, so the
+// host can try again
+func (s *gameService) releaseRoom(ctx context.Context, roomID string) {
+	if _, err := s.repo.TransitionRoomStatus(ctx, roomID, database.RoomStatusPlaying, database.RoomStatusWaiting); err != nil {
+		slog.Warn("Failed to reopen room after a failed start", "room_id", roomID, "error", err)
+	}
+}
+
+// gameRules returns the rules new games are played under
+func (s *gameService) gameRules() domain.GameRules {
+	rules := domain.DefaultRules()
+	if s.config.Game.SeatRotation != "" {
+		rules.SeatRotation = domain.SeatRotation(s.config.Game.SeatRotation)
+	}
+	rules.ConcedeAlone = s.config.Game.ConcedeAlone
+	return rules
+}
+
+// roomRules returns the rules the room's games are played under: those it was
+// created with, or the server's rules if it has none
+func (s *gameService) roomRules(room *database.Room) (domain.GameRules, error) {
+	if len(room.Rules) == 0 {
+		return s.gameRules(), nil
+	}
+	var rules domain.GameRules
+	if err := json.Unmarshal(room.Rules, &rules); err != nil {
+		return domain.GameRules{}, fmt.Errorf("failed to decode rules of room %s: %w", room.ID, err)
+	}
+	return rules, nil
+}
+
+// rotateStartingPosition picks who bids first from the previous game in the
+// room, if the rules rotate the starting seat and the room has played one
+func (s *gameService) rotateStartingPosition(ctx context.Context, state *domain.GameState) error {
+	if !state.Rules.SeatRotation.Rotates() {
+		return nil
+	}
+
+	game, err := s.repo.GetGameByRoomID(ctx, state.RoomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get previous game: %w", err)
+	}
+	if len(game.GameData) == 0 {
+		return nil
+	}
+
+	previous, err := recordedState(game)
+	if err != nil {
+		return err
+	}
+	return state.SetStartingPosition(state.Rules.NextStartingPosition(previous))
+}
+
+// createGameRecord stores the game, the seed it was dealt from and its
+// participants in the database
+func createGameRecord(ctx context.Context, repo repository.GameRepository, state *domain.GameState, seed int64) error {
+	startedAt := time.Now()
+	game := &database.Game{
+		ID:        state.ID,
+		RoomID:    state.RoomID,
+		StartedAt: &startedAt,
+		DealSeed:  &seed,
+	}
+	if err := repo.CreateGame(ctx, game); err != nil {
+		return fmt.Errorf("failed to create game: %w", err)
+	}
+
+	for _, player := range state.Players {
+		participant := &database.GameParticipant{
+			GameID:   state.ID,
+			UserID:   player.ID,
+			Position: int(player.Position),
+		}
+		if err := repo.AddGameParticipant(ctx, participant); err != nil {
+			return fmt.Errorf("failed to add participant %s: %w", player.ID, err)
+		}
+	}
+	return nil
+}
+
+// notifyGameStarted sends each player their own view of the newly dealt game
+func (s *gameService) notifyGameStarted(state *domain.GameState) {
+	if s.notifier == nil {
+		return
+	}
+
+	for _, player := range state.Players {
+		view, err := state.ViewFor(player.ID)
+		if err != nil {
+			continue
+		}
+		message := ws.WSMessage{
+			Type:    ws.EventGameStarted,
+			GameID:  state.ID,
+			RoomID:  state.RoomID,
+			UserID:  player.ID,
+			Payload: view,
+		}
+		if err := s.notifier.SendToUser(player.ID, message); err != nil && !errors.Is(err, ws.ErrNotConnected) {
+			slog.Warn("Failed to notify player of game start", "user_id", player.ID, "game_id", state.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+	"chinese-bridge-game/internal/game/ws"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newTestRoom returns a waiting room hosted by "north" with the given players
+// seated at their index
+func newTestRoom(userIDs ...string) *database.Room {
+	room := &database.Room{
+		ID:     "room-1",
+		Name:   "Test Room",
+		HostID: "north",
+		Status: database.RoomStatusWaiting,
+	}
+	for position, userID := range userIDs {
+		room.Participants = append(room.Participants, database.RoomParticipant{
+			RoomID:   room.ID,
+			UserID:   userID,
+			Position: position,
+			User:     database.User{ID: userID, Name: "Player " + userID},
+		})
+	}
+	return room
+}
+
+func TestGameService_StartGame(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+
+	// Participants are listed out of seating order
+	room := newTestRoom("north", "east", "south", "west")
+	room.Participants[0], room.Participants[2] = room.Participants[2], room.Participants[0]
+
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(room, nil)
+	mockRepo.On("CreateGame", ctx, mock.MatchedBy(func(game *database.Game) bool {
+		return game.RoomID == "room-1" && game.StartedAt != nil
+	})).Return(nil)
+	mockRepo.On("AddGameParticipant", ctx, mock.Anything).Return(nil)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+
+	state, err := service.StartGame(ctx, "room-1", "north")
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "AddGameParticipant", 4)
+
+	assert.Equal(t, domain.PhaseBidding, state.Phase)
+	for position, userID := range []string{"north", "east", "south", "west"} {
+		player := state.Players[position]
+		assert.Equal(t, userID, player.ID)
+		assert.Equal(t, "Player "+userID, player.Name)
+		assert.Equal(t, 25, player.GetHandSize())
+	}
+	assert.NoError(t, state.VerifyCardIntegrity())
+
+	stored, err := store.GetGameState(ctx, state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhaseBidding, stored.Phase)
+
+	messages := notifier.received("east")
+	require.Len(t, messages, 1)
+	assert.Equal(t, ws.EventGameStarted, messages[0].Type)
+	view := messages[0].Payload.(*domain.GameView)
+	assert.Equal(t, domain.East, view.Position)
+	assert.Len(t, view.Hand, 25)
+}
+
+func TestGameService_StartGame_UsesRoomPreset(t *testing.T) {
+	tests := []struct {
+		preset        domain.RulePreset
+		allowsNoTrump bool
+	}{
+		{domain.ClassicPreset, false},
+		{domain.NoTrumpFastPreset, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.preset), func(t *testing.T) {
+			service, _, _ := setupDisconnectTestService(false)
+			mockRepo := service.repo.(*MockGameRepository)
+			ctx := context.Background()
+
+			rules, err := tt.preset.Rules()
+			require.NoError(t, err)
+			room := newTestRoom("north", "east", "south", "west")
+			room.Rules, err = json.Marshal(rules)
+			require.NoError(t, err)
+
+			mockRepo.On("GetRoomByID", ctx, "room-1").Return(room, nil)
+			mockRepo.On("CreateGame", ctx, mock.Anything).Return(nil)
+			mockRepo.On("AddGameParticipant", ctx, mock.Anything).Return(nil)
+			mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+
+			state, err := service.StartGame(ctx, "room-1", "north")
+			require.NoError(t, err)
+			assert.Equal(t, rules, state.Rules)
+
+			// Only the no-trump preset lets the declarer declare no trump
+			require.NoError(t, state.PlaceBid("north", 120))
+			for _, playerID := range []string{"east", "south", "west"} {
+				require.NoError(t, state.PassBid(playerID))
+			}
+			err = state.DeclareTrump("north", domain.NoTrump)
+			if tt.allowsNoTrump {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGameService_StartGame_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		room     *database.Room
+		userID   string
+		expected error
+	}{
+		{"NotHost", newTestRoom("north", "east", "south", "west"), "east", ErrNotRoomHost},
+		{"UnderFilled", newTestRoom("north", "east", "south"), "north", ErrRoomNotFull},
+		{"AlreadyPlaying", func() *database.Room {
+			room := newTestRoom("north", "east", "south", "west")
+			room.Status = database.RoomStatusPlaying
+			return room
+		}(), "north", ErrRoomNotWaiting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := setupDisconnectTestService(false)
+			mockRepo := service.repo.(*MockGameRepository)
+			ctx := context.Background()
+			mockRepo.On("GetRoomByID", ctx, "room-1").Return(tt.room, nil)
+
+			_, err := service.StartGame(ctx, "room-1", tt.userID)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "UpdateRoomStatus", mock.Anything, mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "TransitionRoomStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGameService_StartGame_LosesRaceToConcurrentStart(t *testing.T) {
+	service, _, _ := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+
+	// The room was waiting when read, but another start claimed it first
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(false, nil)
+
+	_, err := service.StartGame(ctx, "room-1", "north")
+	assert.ErrorIs(t, err, ErrRoomNotWaiting)
+	mockRepo.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything)
+}
+
+func TestGameService_StartGame_ReopensRoomWhenGameNotCreated(t *testing.T) {
+	service, _, _ := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+	mockRepo.On("CreateGame", ctx, mock.Anything).Return(assert.AnError)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusPlaying, database.RoomStatusWaiting).Return(true, nil)
+
+	_, err := service.StartGame(ctx, "room-1", "north")
+	assert.ErrorIs(t, err, assert.AnError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGameService_StartGame_ReopensRoomWhenParticipantNotAdded(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+
+	var gameID string
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+	mockRepo.On("CreateGame", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		gameID = args.Get(1).(*database.Game).ID
+	}).Return(nil)
+	mockRepo.On("AddGameParticipant", ctx, mock.Anything).Return(nil).Twice()
+	mockRepo.On("AddGameParticipant", ctx, mock.Anything).Return(assert.AnError)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusPlaying, database.RoomStatusWaiting).Return(true, nil)
+
+	_, err := service.StartGame(ctx, "room-1", "north")
+	assert.ErrorIs(t, err, assert.AnError)
+	mockRepo.AssertExpectations(t)
+
+	// The transaction is rolled back before the game goes live
+	_, err = store.GetGameState(ctx, gameID)
+	assert.Error(t, err)
+}
+
+func TestGameService_StartGame_RoomNotFound(t *testing.T) {
+	service, _, _ := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+	mockRepo.On("GetRoomByID", ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.StartGame(ctx, "missing", "north")
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+}
+
+func TestGameService_StartGame_RecordsDealSeed(t *testing.T) {
+	service, _, _ := setupDisconnectTestService(false)
+	mockRepo := service.repo.(*MockGameRepository)
+	ctx := context.Background()
+
+	var created *database.Game
+	mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+	mockRepo.On("CreateGame", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*database.Game)
+	}).Return(nil)
+	mockRepo.On("AddGameParticipant", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created.Participants = append(created.Participants, *args.Get(1).(*database.GameParticipant))
+	}).Return(nil)
+	mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+
+	state, err := service.StartGame(ctx, "room-1", "north")
+	require.NoError(t, err)
+	require.NotNil(t, created.DealSeed)
+
+	// The stored seed reproduces the hands and kitty that were dealt
+	mockRepo.On("GetGameByID", ctx, state.ID).Return(created, nil)
+	record, err := service.GetDealRecord(ctx, state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *created.DealSeed, record.Seed)
+	for _, player := range state.Players {
+		assert.Equal(t, player.Hand, record.Hands[player.ID], player.ID)
+	}
+	assert.Equal(t, state.Kitty, record.Kitty)
+}
+
+func TestGameService_StartGame_RotatesStartingSeat(t *testing.T) {
+	tests := []struct {
+		name       string
+		rotation   domain.SeatRotation
+		winnerTeam string
+		expected   domain.PlayerPosition
+	}{
+		{"Fixed", domain.FixedStart, domain.TeamDefenders, domain.North},
+		{"Clockwise", domain.RotateClockwise, domain.TeamDefenders, domain.East},
+		{"LoserStartsAfterFailedContract", domain.LoserStarts, domain.TeamDefenders, domain.South},
+		{"LoserStartsAfterMadeContract", domain.LoserStarts, domain.TeamDeclarer, domain.West},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := setupDisconnectTestService(false)
+			service.config.Game.SeatRotation = string(tt.rotation)
+			mockRepo := service.repo.(*MockGameRepository)
+			ctx := context.Background()
+
+			mockRepo.On("GetRoomByID", ctx, "room-1").Return(newTestRoom("north", "east", "south", "west"), nil)
+			mockRepo.On("CreateGame", ctx, mock.Anything).Return(nil)
+			mockRepo.On("AddGameParticipant", ctx, mock.Anything).Return(nil)
+			mockRepo.On("TransitionRoomStatus", ctx, "room-1", database.RoomStatusWaiting, database.RoomStatusPlaying).Return(true, nil)
+			mockRepo.On("GetGameByRoomID", ctx, "room-1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+			first, err := service.StartGame(ctx, "room-1", "north")
+			require.NoError(t, err)
+			assert.Equal(t, domain.North, first.CurrentPlayerTurn)
+
+			// South declared the first game, which the given team won
+			declarer := domain.South
+			first.Declarer = &declarer
+			first.WinnerTeam = &tt.winnerTeam
+			first.Phase = domain.PhaseEnded
+			gameData, err := json.Marshal(first)
+			require.NoError(t, err)
+			mockRepo.On("GetGameByRoomID", ctx, "room-1").Return(&database.Game{ID: first.ID, RoomID: "room-1", GameData: gameData}, nil)
+
+			second, err := service.StartGame(ctx, "room-1", "north")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, second.StartingPosition)
+			assert.Equal(t, tt.expected, second.CurrentPlayerTurn)
+			assert.Equal(t, domain.PhaseBidding, second.Phase)
+		})
+	}
+}
+
+func TestGameService_GetDealRecord_WithoutSeed(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	mockRepo.On("GetGameByID", ctx, "game-1").Return(&database.Game{ID: "game-1"}, nil)
+
+	_, err := service.GetDealRecord(ctx, "game-1")
+	assert.ErrorIs(t, err, ErrNoDealSeed)
+}
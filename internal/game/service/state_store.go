@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+)
+
+// GameStateStore holds the live state of games in progress. Saves are
+// optimistic: SaveGameState returns database.ErrStaleGameState if the state
+// changed since it was loaded, and bumps state.Version on success.
+type GameStateStore interface {
+	GetGameState(ctx context.Context, gameID string) (*domain.GameState, error)
+	SaveGameState(ctx context.Context, state *domain.GameState) error
+}
+
+// redisGameStateStore keeps live game state in the Redis cache
+type redisGameStateStore struct {
+	cache database.Cache
+}
+
+// NewRedisGameStateStore creates a game state store backed by the Redis cache
+func NewRedisGameStateStore(cache database.Cache) GameStateStore {
+	return &redisGameStateStore{cache: cache}
+}
+
+func (s *redisGameStateStore) GetGameState(ctx context.Context, gameID string) (*domain.GameState, error) {
+	data, err := s.cache.GetFullGameState(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, database.ErrCacheMiss) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to load game state: %w", err)
+	}
+
+	var state domain.GameState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, fmt.Errorf("failed to decode game state: %w", err)
+	}
+	return &state, nil
+}
+
+func (s *redisGameStateStore) SaveGameState(ctx context.Context, state *domain.GameState) error {
+	expectedVersion := state.Version
+	state.Version++
+
+	if err := s.cache.SetFullGameState(ctx, state.ID, state, expectedVersion, database.DefaultGameStateTTL); err != nil {
+		state.Version = expectedVersion
+		if errors.Is(err, database.ErrStaleGameState) {
+			return err
+		}
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStateStore is an in-memory GameStateStore that, like Redis, hands out
+// copies and rejects saves of a state that has changed since it was loaded
+type memoryStateStore struct {
+	mu        sync.Mutex
+	states    map[string][]byte
+	gets      int
+	conflicts int // Saves rejected as stale
+	onGet     func()
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{states: make(map[string][]byte)}
+}
+
+func (s *memoryStateStore) GetGameState(ctx context.Context, gameID string) (*domain.GameState, error) {
+	s.mu.Lock()
+	data, exists := s.states[gameID]
+	s.gets++
+	onGet := s.onGet
+	s.mu.Unlock()
+
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+	var state domain.GameState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	if onGet != nil {
+		onGet()
+	}
+	return &state, nil
+}
+
+func (s *memoryStateStore) SaveGameState(ctx context.Context, state *domain.GameState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	storedVersion := 0
+	if data, exists := s.states[state.ID]; exists {
+		var stored domain.GameState
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return err
+		}
+		storedVersion = stored.Version
+	}
+	if storedVersion != state.Version {
+		s.conflicts++
+		return database.ErrStaleGameState
+	}
+
+	state.Version++
+	data, err := json.Marshal(state)
+	if err != nil {
+		state.Version--
+		return err
+	}
+	s.states[state.ID] = data
+	return nil
+}
+
+func TestGameService_PlayCards_ConcurrentPlaysDoNotClobber(t *testing.T) {
+	service, store, notifier := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	// A second instance sharing the store, as plays on one instance wait
+	// for each other
+	other := NewGameService(&MockGameRepository{}, store, nil, nil, notifier, service.config)
+	services := []GameService{service, other}
+
+	// Hold both requests until each has read the same version of the state
+	var loaded sync.WaitGroup
+	loaded.Add(2)
+	var mu sync.Mutex
+	arrivals := 0
+	store.onGet = func() {
+		mu.Lock()
+		index := arrivals
+		arrivals++
+		mu.Unlock()
+		if index < 2 {
+			loaded.Done()
+			loaded.Wait()
+		}
+	}
+
+	cards := []domain.Card{
+		domain.NewCard(domain.Spades, domain.Ten, 1),
+		domain.NewCard(domain.Spades, domain.Jack, 1),
+	}
+	errs := make([]error, len(cards))
+	var wg sync.WaitGroup
+	for i, card := range cards {
+		wg.Add(1)
+		go func(i int, card domain.Card) {
+			defer wg.Done()
+			_, errs[i] = services[i].PlayCards(ctx, "game-1", "north", domain.NewSingle(card))
+		}(i, card)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		// The loser reloads and is told it is no longer their turn
+		assert.NotErrorIs(t, err, database.ErrStaleGameState)
+		assert.Contains(t, err.Error(), "not player's turn")
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 3, store.gets, "the losing play should be retried exactly once")
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Version)
+	assert.Equal(t, 24, state.Players[domain.North].GetHandSize())
+	assert.Len(t, state.CurrentTrick.Plays, 1)
+	assert.Equal(t, domain.East, state.CurrentPlayerTurn)
+}
+
+// retryUntilTurn repeats a player's action until it is their turn to take it
+func retryUntilTurn(ctx context.Context, action func() error) error {
+	for {
+		err := action()
+		if !errors.Is(err, domain.ErrNotYourTurn) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestGameService_ConcurrentActionsOnOneInstanceAreSerialized(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	require.NoError(t, state.DealCards(domain.NewDeck()))
+	require.NoError(t, store.SaveGameState(ctx, state))
+
+	players := []string{"north", "east", "south", "west"}
+
+	// Everyone bids at once: North bids and the others pass in turn
+	var wg sync.WaitGroup
+	for _, playerID := range players {
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+			assert.NoError(t, retryUntilTurn(ctx, func() error {
+				var err error
+				if playerID == "north" {
+					_, err = service.PlaceBid(ctx, "game-1", playerID, 120)
+				} else {
+					_, err = service.PassBid(ctx, "game-1", playerID)
+				}
+				return err
+			}))
+		}(playerID)
+	}
+	wg.Wait()
+
+	_, err = service.DeclareTrump(ctx, "game-1", "north", domain.Hearts)
+	require.NoError(t, err)
+	state, err = store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	_, err = service.ExchangeKitty(ctx, "game-1", "north", state.Players[domain.North].Hand[:8])
+	require.NoError(t, err)
+
+	// Everyone plays two tricks at once, each playing when their turn comes
+	for _, playerID := range players {
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+			for played := 0; played < 2; {
+				moves, err := service.GetLegalMoves(ctx, "game-1", playerID)
+				if !assert.NoError(t, err) {
+					return
+				}
+				if len(moves) == 0 {
+					if ctx.Err() != nil {
+						t.Error(ctx.Err())
+						return
+					}
+					time.Sleep(time.Millisecond)
+					continue
+				}
+				_, err = service.PlayCards(ctx, "game-1", playerID, moves[0])
+				if !assert.NoError(t, err) {
+					return
+				}
+				played++
+			}
+		}(playerID)
+	}
+	wg.Wait()
+
+	state, err = store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PhasePlaying, state.Phase)
+	assert.Len(t, state.Tricks, 2)
+	assert.Nil(t, state.CurrentTrick)
+	for _, player := range state.Players {
+		assert.Equal(t, 23, player.GetHandSize(), "%s should have played two cards", player.ID)
+	}
+	assert.Equal(t, 1+4+2+8, state.Version, "every action should be saved exactly once")
+	assert.Zero(t, store.conflicts, "actions on one instance should never race to save")
+}
+
+func TestGameService_UpdateState_GivesUpAfterRepeatedConflicts(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	_, err := service.updateState(ctx, "game-1", func(state *domain.GameState) error {
+		state.Version-- // Simulate another writer winning every race
+		return nil
+	})
+	assert.ErrorIs(t, err, database.ErrStaleGameState)
+	assert.Equal(t, maxSaveAttempts, store.gets)
+}
+
+// countingLocker records lock acquisitions and can be made to fail
+type countingLocker struct {
+	mu       sync.Mutex
+	acquired int
+	released int
+	err      error
+}
+
+func (l *countingLocker) AcquireGameLock(ctx context.Context, gameID string, ttl time.Duration) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, l.err
+	}
+	l.acquired++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.released++
+	}, nil
+}
+
+func TestGameService_UpdateState_HoldsGameLock(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	locker := &countingLocker{}
+	service.locker = locker
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newPlayingGame(t)))
+
+	_, err := service.PlayCards(ctx, "game-1", "north", domain.NewSingle(domain.NewCard(domain.Spades, domain.Ten, 1)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, locker.acquired)
+	assert.Equal(t, 1, locker.released)
+
+	locker.err = database.ErrLockNotAcquired
+	_, err = service.PlayCards(ctx, "game-1", "east", domain.NewSingle(domain.NewCard(domain.Clubs, domain.Three, 1)))
+	assert.True(t, errors.Is(err, database.ErrLockNotAcquired))
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.East, state.CurrentPlayerTurn, "no update without the lock")
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"chinese-bridge-game/internal/game/domain"
+)
+
+// scheduleTurnExpiry arms a timer for the current turn's deadline, replacing
+// the timer of any earlier turn in the game. When it fires the server acts for
+// the player who ran out of time.
+func (s *gameService) scheduleTurnExpiry(state *domain.GameState) {
+	gameID := state.ID
+
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	if timer, exists := s.turnTimers[gameID]; exists {
+		timer.Stop()
+		delete(s.turnTimers, gameID)
+	}
+	if state.TurnDeadline == nil || state.IsOver() {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(*state.TurnDeadline), func() {
+		s.timersMu.Lock()
+		if s.turnTimers[gameID] == timer {
+			delete(s.turnTimers, gameID)
+		}
+		s.timersMu.Unlock()
+
+		if err := s.expireTurn(context.Background(), gameID); err != nil {
+			log.Printf("Failed to act for the player out of time in game %s: %v", gameID, err)
+		}
+	})
+	s.turnTimers[gameID] = timer
+}
+
+// expireTurn plays the default action for the current player if their time to
+// act has run out, then lets any bots or disconnected players who follow act
+func (s *gameService) expireTurn(ctx context.Context, gameID string) error {
+	acted := false
+	var tricksBefore int
+	state, err := s.updateState(ctx, gameID, func(state *domain.GameState) error {
+		if state.IsOver() || !state.IsTurnExpired(time.Now()) {
+			return errNoChange
+		}
+
+		tricksBefore = len(state.Tricks)
+		if err := state.AutoAct(); err != nil {
+			return err
+		}
+		s.runAutoActions(state)
+		acted = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !acted {
+		// The player acted in time, or the wall clock lags the timer
+		s.scheduleTurnExpiry(state)
+		return nil
+	}
+
+	s.notifyTricksWon(state, tricksBefore)
+	return s.finalizeIfEnded(ctx, state)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBiddingGame returns a game dealt from an unshuffled deck with North to bid
+func newBiddingGame(t *testing.T) *domain.GameState {
+	t.Helper()
+
+	state, err := domain.NewGameState("game-1", "room-1",
+		[]string{"north", "east", "south", "west"},
+		[]string{"North", "East", "South", "West"},
+		domain.DefaultRules())
+	require.NoError(t, err)
+	require.NoError(t, state.DealCards(domain.NewDeck()))
+	require.Equal(t, domain.PhaseBidding, state.Phase)
+	return state
+}
+
+func TestGameService_TurnExpiry_PassesForPlayerOutOfTime(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+
+	state := newBiddingGame(t)
+	deadline := time.Now().Add(10 * time.Millisecond)
+	state.TurnDeadline = &deadline
+	require.NoError(t, store.SaveGameState(ctx, state))
+	service.scheduleTurnExpiry(state)
+
+	assert.Eventually(t, func() bool {
+		state, err := store.GetGameState(ctx, "game-1")
+		return err == nil && len(state.BidHistory) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.True(t, state.BidHistory[0].IsPassed)
+	assert.True(t, state.BidHistory[0].IsAuto)
+	assert.Equal(t, domain.East, state.CurrentPlayerTurn)
+	require.NotNil(t, state.TurnDeadline)
+	assert.True(t, state.TurnDeadline.After(time.Now()), "East gets a fresh clock")
+}
+
+func TestGameService_TurnExpiry_IgnoresTurnStillInTime(t *testing.T) {
+	service, store, _ := setupDisconnectTestService(false)
+	ctx := context.Background()
+	require.NoError(t, store.SaveGameState(ctx, newBiddingGame(t)))
+
+	require.NoError(t, service.expireTurn(ctx, "game-1"))
+
+	state, err := store.GetGameState(ctx, "game-1")
+	require.NoError(t, err)
+	assert.Empty(t, state.BidHistory)
+	assert.Equal(t, domain.North, state.CurrentPlayerTurn)
+}
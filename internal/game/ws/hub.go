@@ -0,0 +1,203 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"chinese-bridge-game/pkg/apierror"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrNotConnected is returned when sending to a user without a live connection
+var ErrNotConnected = errors.New("user is not connected")
+
+// Conn is the transport the hub uses to deliver messages to a single client
+type Conn interface {
+	WriteJSON(v interface{}) error
+	Close() error
+}
+
+// DisconnectHandler is called when a player's connection to a game drops
+type DisconnectHandler func(gameID, userID string)
+
+// MessageHandler is called with each valid message a player sends. The
+// context is cancelled once the player's connection closes.
+type MessageHandler func(ctx context.Context, gameID, userID string, message WSMessage)
+
+// client is a registered connection for one user in one game
+type client struct {
+	gameID string
+	conn   Conn
+	mu     sync.Mutex // Serializes writes to the connection
+}
+
+// Hub tracks each user's WebSocket connection and routes messages to them
+type Hub struct {
+	mu           sync.RWMutex
+	clients      map[string]*client
+	onDisconnect DisconnectHandler
+	onMessage    MessageHandler
+	upgrader     websocket.Upgrader
+}
+
+// NewHub creates a new WebSocket hub. Browsers may only open WebSockets from
+// the service's own origin or one of allowedOrigins, since the upgrade is not
+// subject to CORS.
+func NewHub(allowedOrigins []string) *Hub {
+	return &Hub{
+		clients: make(map[string]*client),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(allowedOrigins),
+		},
+	}
+}
+
+// checkOrigin accepts requests without an Origin header, which browsers always
+// send, requests from the host they are made to and requests from one of the
+// allowed origins
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if allowed[strings.ToLower(origin)] {
+			return true
+		}
+		parsed, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(parsed.Host, r.Host)
+	}
+}
+
+// SetDisconnectHandler registers the callback invoked when a player disconnects
+func (h *Hub) SetDisconnectHandler(handler DisconnectHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onDisconnect = handler
+}
+
+// SetMessageHandler registers the callback invoked with messages from players
+func (h *Hub) SetMessageHandler(handler MessageHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onMessage = handler
+}
+
+// Register associates a connection with a user in a game, replacing any
+// previous connection the user had
+func (h *Hub) Register(gameID, userID string, conn Conn) {
+	h.mu.Lock()
+	previous := h.clients[userID]
+	h.clients[userID] = &client{gameID: gameID, conn: conn}
+	h.mu.Unlock()
+
+	if previous != nil && previous.conn != conn {
+		previous.conn.Close()
+	}
+}
+
+// Unregister removes a user's connection and reports the disconnect. It is a
+// no-op if the connection has already been replaced by a newer one.
+func (h *Hub) Unregister(userID string, conn Conn) {
+	h.mu.Lock()
+	current, exists := h.clients[userID]
+	if !exists || current.conn != conn {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.clients, userID)
+	handler := h.onDisconnect
+	h.mu.Unlock()
+
+	conn.Close()
+	if handler != nil {
+		handler(current.gameID, userID)
+	}
+}
+
+// IsConnected checks if a user has a live connection
+func (h *Hub) IsConnected(userID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	_, exists := h.clients[userID]
+	return exists
+}
+
+// SendToUser delivers a message to a single user
+func (h *Hub) SendToUser(userID string, message interface{}) error {
+	h.mu.RLock()
+	c, exists := h.clients[userID]
+	h.mu.RUnlock()
+	if !exists {
+		return ErrNotConnected
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(message)
+}
+
+// ServeWS upgrades an HTTP request to a WebSocket for a player in a game, sends
+// the welcome message and blocks until the connection closes, at which point
+// the player is unregistered. Messages the player sends are passed to the
+// message handler, or answered with an error frame if they are invalid.
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, gameID, userID string, welcome interface{}) error {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	h.Register(gameID, userID, conn)
+	defer h.Unregister(userID, conn)
+
+	if welcome != nil {
+		if err := h.SendToUser(userID, welcome); err != nil {
+			return err
+		}
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("WebSocket for user %s in game %s closed: %v", userID, gameID, err)
+			}
+			return nil
+		}
+		h.handleMessage(ctx, gameID, userID, data)
+	}
+}
+
+// handleMessage validates a message from a player and passes it on
+func (h *Hub) handleMessage(ctx context.Context, gameID, userID string, data []byte) {
+	message, err := DecodeClientMessage(data)
+	if err != nil {
+		frame := NewErrorMessage(apierror.CodeValidation, "Invalid message", err.Error())
+		if err := h.SendToUser(userID, frame); err != nil {
+			log.Printf("Failed to send error frame to user %s in game %s: %v", userID, gameID, err)
+		}
+		return
+	}
+
+	h.mu.RLock()
+	handler := h.onMessage
+	h.mu.RUnlock()
+	if handler != nil {
+		handler(ctx, gameID, userID, message)
+	}
+}
@@ -0,0 +1,151 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConn records messages written to it in place of a real WebSocket
+type fakeConn struct {
+	mu       sync.Mutex
+	messages []interface{}
+	closed   bool
+}
+
+func (c *fakeConn) WriteJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, v)
+	return nil
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func TestHub_SendToUser(t *testing.T) {
+	hub := NewHub(nil)
+	conn := &fakeConn{}
+	hub.Register("game-1", "user-1", conn)
+
+	message := WSMessage{Type: EventCardsPlayed, GameID: "game-1", UserID: "user-2"}
+	require.NoError(t, hub.SendToUser("user-1", message))
+
+	assert.True(t, hub.IsConnected("user-1"))
+	assert.Equal(t, []interface{}{message}, conn.messages)
+	assert.ErrorIs(t, hub.SendToUser("user-2", message), ErrNotConnected)
+}
+
+func TestHub_UnregisterReportsDisconnect(t *testing.T) {
+	hub := NewHub(nil)
+	conn := &fakeConn{}
+
+	var disconnectedGame, disconnectedUser string
+	hub.SetDisconnectHandler(func(gameID, userID string) {
+		disconnectedGame, disconnectedUser = gameID, userID
+	})
+
+	hub.Register("game-1", "user-1", conn)
+	hub.Unregister("user-1", conn)
+
+	assert.False(t, hub.IsConnected("user-1"))
+	assert.True(t, conn.closed)
+	assert.Equal(t, "game-1", disconnectedGame)
+	assert.Equal(t, "user-1", disconnectedUser)
+}
+
+func TestHub_ReplacedConnectionDoesNotReportDisconnect(t *testing.T) {
+	hub := NewHub(nil)
+	oldConn := &fakeConn{}
+	newConn := &fakeConn{}
+
+	disconnects := 0
+	hub.SetDisconnectHandler(func(gameID, userID string) {
+		disconnects++
+	})
+
+	hub.Register("game-1", "user-1", oldConn)
+	hub.Register("game-1", "user-1", newConn)
+	assert.True(t, oldConn.closed)
+
+	// The old connection's read loop ending must not drop the new connection
+	hub.Unregister("user-1", oldConn)
+	assert.True(t, hub.IsConnected("user-1"))
+	assert.Equal(t, 0, disconnects)
+}
+
+func TestHub_HandleMessage(t *testing.T) {
+	hub := NewHub(nil)
+	conn := &fakeConn{}
+	hub.Register("game-1", "user-1", conn)
+
+	ctx := context.Background()
+	var received []WSMessage
+	hub.SetMessageHandler(func(ctx context.Context, gameID, userID string, message WSMessage) {
+		received = append(received, message)
+	})
+
+	hub.handleMessage(ctx, "game-1", "user-1", []byte(`{"v": 1, "type": "chat", "payload": {"text": "hi"}}`))
+	require.Len(t, received, 1)
+	assert.Equal(t, MessageChat, received[0].Type)
+	assert.Empty(t, conn.messages)
+
+	// An unknown type is answered with an error frame instead of being passed on
+	hub.handleMessage(ctx, "game-1", "user-1", []byte(`{"v": 1, "type": "teleport", "payload": {}}`))
+	assert.Len(t, received, 1)
+	require.Len(t, conn.messages, 1)
+	frame := conn.messages[0].(WSMessage)
+	assert.Equal(t, EventError, frame.Type)
+	assert.Equal(t, ProtocolVersion, frame.Version)
+	assert.Equal(t, "VALIDATION_ERROR", frame.Payload.(ErrorPayload).Code)
+}
+
+func TestHub_ServeWSChecksOrigin(t *testing.T) {
+	hub := NewHub([]string{"https://play.example.com"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.ServeWS(w, r, "game-1", "user-1", nil)
+	}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		name    string
+		origin  string
+		allowed bool
+	}{
+		{"Allowed origin", "https://play.example.com", true},
+		{"Same origin", server.URL, true},
+		{"No origin", "", true},
+		{"Other origin", "https://evil.example.com", false},
+		{"Allowed host over another scheme", "http://play.example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+			if tt.allowed {
+				require.NoError(t, err)
+				conn.Close()
+				return
+			}
+			assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+			require.NotNil(t, resp)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		})
+	}
+}
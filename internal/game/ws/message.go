@@ -0,0 +1,110 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ProtocolVersion is the version of the message envelope, sent as "v" in
+// every message so clients can detect breaking changes
+const ProtocolVersion = 1
+
+// Errors returned when decoding a message from a client
+var (
+	// ErrUnsupportedVersion is returned for a message in another protocol version
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+	// ErrUnknownMessageType is returned for a message type clients may not send
+	ErrUnknownMessageType = errors.New("unknown message type")
+)
+
+// WSMessage is the JSON message exchanged with WebSocket clients
+type WSMessage struct {
+	Version int         `json:"v"`
+	Type    string      `json:"type"`
+	GameID  string      `json:"game_id,omitempty"`
+	RoomID  string      `json:"room_id,omitempty"`
+	UserID  string      `json:"user_id"`
+	Payload interface{} `json:"payload"`
+}
+
+// wireMessage has WSMessage's fields without its MarshalJSON method
+type wireMessage WSMessage
+
+// MarshalJSON encodes the message, stamping it with the current protocol
+// version if it does not carry one
+func (m WSMessage) MarshalJSON() ([]byte, error) {
+	if m.Version == 0 {
+		m.Version = ProtocolVersion
+	}
+	return json.Marshal(wireMessage(m))
+}
+
+// Event types
+const (
+	EventPlayerJoined       = "player_joined"
+	EventPlayerLeft         = "player_left"
+	EventPlayerKicked       = "player_kicked"
+	EventGameStarted        = "game_started"
+	EventBidMade            = "bid_made"
+	EventTrumpDeclared      = "trump_declared"
+	EventCardsPlayed        = "cards_played"
+	EventTrickWon           = "trick_won"
+	EventGameEnded          = "game_ended"
+	EventGameAborted        = "game_aborted"
+	EventPlayerReconnect    = "player_reconnect"
+	EventPlayerDisconnected = "player_disconnected"
+	EventPlayerReplaced     = "player_replaced"
+	EventStateUpdate        = "state_update"
+	EventChatMessage        = "chat_message"
+	EventRematchOffered     = "rematch_offered"
+	EventRoomClosed         = "room_closed"
+	EventError              = "error"
+)
+
+// Message types clients may send
+const (
+	MessageBid  = "bid"
+	MessagePlay = "play"
+	MessageChat = "chat"
+)
+
+// clientMessageTypes are the message types accepted from clients
+var clientMessageTypes = map[string]bool{
+	MessageBid:  true,
+	MessagePlay: true,
+	MessageChat: true,
+}
+
+// DecodeClientMessage parses a message sent by a client, rejecting messages
+// in another protocol version or of a type clients may not send
+func DecodeClientMessage(data []byte) (WSMessage, error) {
+	var message WSMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return WSMessage{}, fmt.Errorf("invalid message: %w", err)
+	}
+	if message.Version != ProtocolVersion {
+		return WSMessage{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, message.Version)
+	}
+	if !clientMessageTypes[message.Type] {
+		return WSMessage{}, fmt.Errorf("%w: %q", ErrUnknownMessageType, message.Type)
+	}
+	return message, nil
+}
+
+// ErrorPayload is the payload of an error frame. Code and Message match the
+// error response of the equivalent HTTP request.
+type ErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+// NewErrorMessage builds the error frame sent to a client whose message was rejected
+func NewErrorMessage(code, message, details string) WSMessage {
+	return WSMessage{
+		Version: ProtocolVersion,
+		Type:    EventError,
+		Payload: ErrorPayload{Code: code, Message: message, Details: details},
+	}
+}
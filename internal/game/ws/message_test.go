@@ -0,0 +1,50 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSMessage_EncodesVersionedEnvelope(t *testing.T) {
+	for _, messageType := range []string{EventStateUpdate, MessageBid, MessagePlay, EventError, MessageChat, EventGameEnded} {
+		data, err := json.Marshal(WSMessage{Type: messageType, GameID: "game-1", Payload: map[string]int{"amount": 120}})
+		require.NoError(t, err, messageType)
+
+		var envelope map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &envelope), messageType)
+		assert.Equal(t, float64(ProtocolVersion), envelope["v"], messageType)
+		assert.Equal(t, messageType, envelope["type"], messageType)
+		assert.Equal(t, map[string]interface{}{"amount": float64(120)}, envelope["payload"], messageType)
+	}
+}
+
+func TestDecodeClientMessage(t *testing.T) {
+	for _, messageType := range []string{MessageBid, MessagePlay, MessageChat} {
+		data, err := json.Marshal(WSMessage{Type: messageType, Payload: map[string]string{"text": "hi"}})
+		require.NoError(t, err)
+
+		message, err := DecodeClientMessage(data)
+		require.NoError(t, err, messageType)
+		assert.Equal(t, ProtocolVersion, message.Version)
+		assert.Equal(t, messageType, message.Type)
+		assert.Equal(t, map[string]interface{}{"text": "hi"}, message.Payload)
+	}
+}
+
+func TestDecodeClientMessage_Rejected(t *testing.T) {
+	_, err := DecodeClientMessage([]byte(`{"v": 1, "type": "teleport", "payload": {}}`))
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+
+	// Clients may not send events that only the server emits
+	_, err = DecodeClientMessage([]byte(`{"v": 1, "type": "game_ended", "payload": {}}`))
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+
+	_, err = DecodeClientMessage([]byte(`{"v": 2, "type": "bid", "payload": {}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = DecodeClientMessage([]byte(`not json`))
+	assert.Error(t, err)
+}
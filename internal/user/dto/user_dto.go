@@ -0,0 +1,50 @@
+package dto
+
+// PublicProfile is the information about a user that other players may see
+type PublicProfile struct {
+	ID     string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name   string `json:"name" example:"John Doe"`
+	Avatar string `json:"avatar" example:"https://lh3.googleusercontent.com/..."`
+}
+
+// UpdateProfileRequest represents a change to the caller's profile. Fields
+// left out are not changed.
+type UpdateProfileRequest struct {
+	Name   *string `json:"name,omitempty" example:"John Doe"`
+	Avatar *string `json:"avatar,omitempty" example:"https://lh3.googleusercontent.com/..."`
+}
+
+// BatchProfilesRequest represents the request for several users' public profiles
+type BatchProfilesRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1"`
+}
+
+// BatchProfilesResponse represents the public profiles found for a batch request
+type BatchProfilesResponse struct {
+	Users []PublicProfile `json:"users"`
+}
+
+// LeaderboardEntry is a player's place on a leaderboard
+type LeaderboardEntry struct {
+	Rank         int    `json:"rank" example:"1"`
+	UserID       string `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name         string `json:"name" example:"John Doe"`
+	Avatar       string `json:"avatar" example:"https://lh3.googleusercontent.com/..."`
+	GamesPlayed  int    `json:"games_played" example:"40"`
+	GamesWon     int    `json:"games_won" example:"25"`
+	DeclarerWins int    `json:"declarer_wins" example:"9"`
+	TotalPoints  int    `json:"total_points" example:"4200"`
+	Rating       int    `json:"rating" example:"1620"`
+}
+
+// LeaderboardResponse represents the top players by the requested criteria
+type LeaderboardResponse struct {
+	Criteria string             `json:"criteria" example:"points"`
+	Players  []LeaderboardEntry `json:"players"`
+}
+
+// CacheWarmupResponse reports how much was loaded into the cache by a warmup
+type CacheWarmupResponse struct {
+	LeaderboardEntries int `json:"leaderboard_entries" example:"100"`
+	ActiveRooms        int `json:"active_rooms" example:"12"`
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/common/database"
+	userdto "chinese-bridge-game/internal/user/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler serves operational endpoints restricted to admins
+type AdminHandler struct {
+	warmup database.CacheWarmupStrategy
+}
+
+func NewAdminHandler(warmup database.CacheWarmupStrategy) *AdminHandler {
+	return &AdminHandler{
+		warmup: warmup,
+	}
+}
+
+// RegisterRoutes adds the admin routes, which the caller must guard with admin-only middleware
+func (h *AdminHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	{
+		admin.POST("/cache/warmup", h.WarmupCache)
+	}
+}
+
+// WarmupCache godoc
+// @Summary Warm up caches
+// @Description Reload the leaderboard and waiting rooms into the cache, e.g. after a large stats change. Admin only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} userdto.CacheWarmupResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/cache/warmup [post]
+func (h *AdminHandler) WarmupCache(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	leaderboardEntries, err := h.warmup.WarmupLeaderboard(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to warm up leaderboard cache",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	activeRooms, err := h.warmup.WarmupActiveRooms(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to warm up room cache",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, userdto.CacheWarmupResponse{
+		LeaderboardEntries: leaderboardEntries,
+		ActiveRooms:        activeRooms,
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"chinese-bridge-game/internal/common/database"
+	userdto "chinese-bridge-game/internal/user/dto"
+	"chinese-bridge-game/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// leaderboardCache records the leaderboard written to it. Other cache methods
+// are not used by the warmup and panic if called.
+type leaderboardCache struct {
+	database.Cache
+	leaderboard *database.CachedLeaderboard
+}
+
+func (c *leaderboardCache) SetLeaderboard(ctx context.Context, leaderboardData interface{}, ttl time.Duration) error {
+	leaderboard := leaderboardData.(database.CachedLeaderboard)
+	c.leaderboard = &leaderboard
+	return nil
+}
+
+func setupAdminRouter(t *testing.T) (*gin.Engine, *leaderboardCache) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.NewMigrationManager(db, nil).RunMigrations(context.Background()))
+
+	repo := database.NewGormRepository(db)
+	ctx := context.Background()
+	for i, name := range []string{"Alice", "Bob"} {
+		user := &database.User{GoogleID: name, Email: name + "@example.com", Name: name}
+		require.NoError(t, repo.CreateUser(ctx, user))
+		require.NoError(t, repo.CreateUserStats(ctx, &database.UserStats{UserID: user.ID, GamesPlayed: 10, GamesWon: 5 + i, Rating: 1500}))
+	}
+
+	cache := &leaderboardCache{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("trace_id", "test-trace-id")
+		c.Set("user_id", "test-user")
+		c.Set("user_role", c.GetHeader("X-Test-Role"))
+		c.Next()
+	})
+
+	admin := router.Group("/api/v1")
+	admin.Use(middleware.RequireRole(database.RoleAdmin))
+	NewAdminHandler(database.NewCacheWarmupStrategy(cache, repo, nil)).RegisterRoutes(admin)
+	return router, cache
+}
+
+func TestAdminHandler_WarmupCache(t *testing.T) {
+	router, cache := setupAdminRouter(t)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/cache/warmup", nil)
+	req.Header.Set("X-Test-Role", database.RoleAdmin)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response userdto.CacheWarmupResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.LeaderboardEntries)
+	assert.Equal(t, 0, response.ActiveRooms)
+
+	require.NotNil(t, cache.leaderboard)
+	require.Len(t, cache.leaderboard.Players, 2)
+	assert.Equal(t, "Bob", cache.leaderboard.Players[0].Name)
+	assert.Equal(t, 6, cache.leaderboard.Players[0].GamesWon)
+}
+
+func TestAdminHandler_WarmupCacheRequiresAdmin(t *testing.T) {
+	router, cache := setupAdminRouter(t)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/cache/warmup", nil)
+	req.Header.Set("X-Test-Role", database.RolePlayer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Nil(t, cache.leaderboard)
+}
@@ -1,9 +1,18 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/common/database"
+	userdto "chinese-bridge-game/internal/user/dto"
 	"chinese-bridge-game/internal/user/service"
+	"chinese-bridge-game/pkg/buildinfo"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type UserHandler struct {
@@ -22,7 +31,12 @@ func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
 		users.GET("/profile", h.GetProfile)
 		users.PUT("/profile", h.UpdateProfile)
 		users.GET("/stats", h.GetStats)
+		users.GET("/stats/analytics", h.GetStatsAnalytics)
+		users.GET("/stats/streak", h.GetStreak)
 		users.GET("/history", h.GetHistory)
+		users.GET("/head-to-head/:opponentId", h.GetHeadToHead)
+		users.POST("/batch", h.GetPublicProfiles)
+		users.GET("/leaderboard", h.GetLeaderboard)
 	}
 }
 
@@ -30,8 +44,65 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Get profile endpoint"})
 }
 
+// UpdateProfile godoc
+// @Summary Update profile
+// @Description Change the caller's display name or avatar. Names are checked for length, allowed characters and denied terms.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body userdto.UpdateProfileRequest true "Profile changes"
+// @Success 200 {object} userdto.PublicProfile
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/profile [put]
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Update profile endpoint"})
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req userdto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	profile, err := h.userService.UpdateProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidName):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Name not allowed",
+				Details: err.Error(),
+				TraceID: c.GetString("trace_id"),
+			})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "User not found",
+				TraceID: c.GetString("trace_id"),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to update profile",
+				Details: err.Error(),
+				TraceID: c.GetString("trace_id"),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
 }
 
 func (h *UserHandler) GetStats(c *gin.Context) {
@@ -42,11 +113,219 @@ func (h *UserHandler) GetHistory(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Get history endpoint"})
 }
 
+// GetPublicProfiles godoc
+// @Summary Get public profiles
+// @Description Get the public profiles of several users in one call. Duplicate and unknown IDs are ignored.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body userdto.BatchProfilesRequest true "User IDs"
+// @Success 200 {object} userdto.BatchProfilesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/batch [post]
+func (h *UserHandler) GetPublicProfiles(c *gin.Context) {
+	var req userdto.BatchProfilesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	profiles, err := h.userService.GetPublicProfiles(c.Request.Context(), req.UserIDs)
+	if err != nil {
+		if errors.Is(err, service.ErrBatchTooLarge) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Too many user IDs",
+				Details: err.Error(),
+				TraceID: c.GetString("trace_id"),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get profiles",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, userdto.BatchProfilesResponse{Users: profiles})
+}
+
+// GetStatsAnalytics godoc
+// @Summary Get stats analytics
+// @Description Get the caller's overall win rate, declarer win rate and average contract
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.StatsAnalytics
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/stats/analytics [get]
+func (h *UserHandler) GetStatsAnalytics(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.userService.GetStatsAnalytics(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get stats analytics",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetStreak godoc
+// @Summary Get win/loss streak
+// @Description Get the caller's current streak, positive for consecutive wins and negative for consecutive losses, and their best winning streak
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.Streak
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/stats/streak [get]
+func (h *UserHandler) GetStreak(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	streak, err := h.userService.GetStreak(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get streak",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, streak)
+}
+
+// GetHeadToHead godoc
+// @Summary Get head-to-head record
+// @Description Get how often the caller has partnered and opposed another player, and who won when opposed
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param opponentId path string true "Opponent user ID"
+// @Success 200 {object} database.HeadToHead
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/head-to-head/{opponentId} [get]
+func (h *UserHandler) GetHeadToHead(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	record, err := h.userService.GetHeadToHead(c.Request.Context(), userID, c.Param("opponentId"))
+	if err != nil {
+		if errors.Is(err, service.ErrSameUser) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Cannot compare a player with themselves",
+				TraceID: c.GetString("trace_id"),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get head-to-head record",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// defaultLeaderboardSize is how many players the leaderboard shows unless a limit is given
+const defaultLeaderboardSize = 10
+
+// GetLeaderboard godoc
+// @Summary Get leaderboard
+// @Description Get the top players ranked by games won, games won as declarer or total points
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param by query string false "Ranking criteria" Enums(wins, declarer_wins, points) default(wins)
+// @Param limit query int false "Number of players" default(10)
+// @Success 200 {object} userdto.LeaderboardResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/leaderboard [get]
+func (h *UserHandler) GetLeaderboard(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLeaderboardSize)))
+	if err != nil || limit < 1 || limit > database.LeaderboardSize {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid limit parameter",
+			Details: "Limit must be between 1 and " + strconv.Itoa(database.LeaderboardSize),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	leaderboard, err := h.userService.GetLeaderboard(c.Request.Context(), c.DefaultQuery("by", service.LeaderboardByWins), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownCriteria) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid ranking criteria",
+				Details: err.Error(),
+				TraceID: c.GetString("trace_id"),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get leaderboard",
+			Details: err.Error(),
+			TraceID: c.GetString("trace_id"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+// requireUser returns the authenticated user's ID, responding with 401 if there is none
+func (h *UserHandler) requireUser(c *gin.Context) (string, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    "AUTHENTICATION_ERROR",
+			Message: "User not authenticated",
+			TraceID: c.GetString("trace_id"),
+		})
+		return "", false
+	}
+	return userID, true
+}
+
 func (h *UserHandler) HealthCheck(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status": "healthy",
-		"service": "user-service",
-	})
+	c.JSON(200, buildinfo.NewHealth("healthy", "user-service"))
 }
 
 func (h *UserHandler) ReadyCheck(c *gin.Context) {
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/user/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserRepository is a mock implementation of the user repository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*database.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserFields(ctx context.Context, id string, fields map[string]interface{}) error {
+	args := m.Called(ctx, id, fields)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]database.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserStats(ctx context.Context, userID string) (*database.UserStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.UserStats), args.Error(1)
+}
+
+func (m *MockUserRepository) GetHeadToHead(ctx context.Context, userA, userB string) (database.HeadToHead, error) {
+	args := m.Called(ctx, userA, userB)
+	return args.Get(0).(database.HeadToHead), args.Error(1)
+}
+
+func (m *MockUserRepository) GetTopPlayersByWins(ctx context.Context, limit int) ([]database.UserStats, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]database.UserStats), args.Error(1)
+}
+
+func (m *MockUserRepository) GetTopPlayersByDeclarerWins(ctx context.Context, limit int) ([]database.UserStats, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]database.UserStats), args.Error(1)
+}
+
+func (m *MockUserRepository) GetTopPlayersByPoints(ctx context.Context, limit int) ([]database.UserStats, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]database.UserStats), args.Error(1)
+}
+
+func setupTestRouter(repo *MockUserRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Authenticate requests as the user named in the test header
+	router.Use(func(c *gin.Context) {
+		c.Set("trace_id", "test-trace-id")
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+
+	leaderboard := database.NewLeaderboardReader(database.NewInMemoryCache(), nil, nil)
+	handler := NewUserHandler(service.NewUserService(repo, nil, leaderboard, nil))
+	handler.RegisterRoutes(router.Group("/api/v1"))
+	return router
+}
+
+func postBatch(router *gin.Engine, userIDs []string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string][]string{"user_ids": userIDs})
+	req, _ := http.NewRequest("POST", "/api/v1/users/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "viewer")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUserHandler_GetPublicProfiles(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	repo.On("GetUsersByIDs", mock.Anything, []string{"user-1", "user-2", "missing"}).Return([]database.User{
+		{ID: "user-1", GoogleID: "google-1", Email: "one@example.com", Name: "One", Avatar: "https://example.com/1.jpg"},
+		{ID: "user-2", GoogleID: "google-2", Email: "two@example.com", Name: "Two"},
+	}, nil)
+
+	// Duplicates are collapsed before querying
+	requested := []string{"user-1", "user-2", "user-1", "missing"}
+	w := postBatch(router, requested)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertExpectations(t)
+
+	var response struct {
+		Users []map[string]interface{} `json:"users"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.LessOrEqual(t, len(response.Users), len(requested))
+	require.Len(t, response.Users, 2)
+	assert.Equal(t, "One", response.Users[0]["name"])
+	assert.Equal(t, "https://example.com/1.jpg", response.Users[0]["avatar"])
+
+	for _, user := range response.Users {
+		assert.ElementsMatch(t, []string{"id", "name", "avatar"}, keys(user), "only public fields are returned")
+	}
+	assert.NotContains(t, w.Body.String(), "one@example.com")
+	assert.NotContains(t, w.Body.String(), "google-1")
+}
+
+func TestUserHandler_GetPublicProfilesTooMany(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	userIDs := make([]string, service.MaxProfileBatchSize+1)
+	for i := range userIDs {
+		userIDs[i] = fmt.Sprintf("user-%d", i)
+	}
+	w := postBatch(router, userIDs)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	repo.AssertNotCalled(t, "GetUsersByIDs", mock.Anything, mock.Anything)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Code)
+}
+
+func TestUserHandler_GetPublicProfilesEmpty(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	w := postBatch(router, []string{})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func putProfile(router *gin.Engine, body map[string]string) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest("PUT", "/api/v1/users/profile", bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "user-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUserHandler_UpdateProfile(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	repo.On("UpdateUserFields", mock.Anything, "user-1", map[string]interface{}{"name": "Jade Dragon"}).Return(nil)
+	repo.On("GetUserByID", mock.Anything, "user-1").Return(&database.User{ID: "user-1", Name: "Jade Dragon", Email: "one@example.com"}, nil)
+
+	w := putProfile(router, map[string]string{"name": "Jade Dragon"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertExpectations(t)
+
+	var profile map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+	assert.Equal(t, "Jade Dragon", profile["name"])
+	assert.NotContains(t, w.Body.String(), "one@example.com")
+}
+
+func TestUserHandler_UpdateProfileRejectsName(t *testing.T) {
+	for name, displayName := range map[string]string{
+		"too long":   strings.Repeat("a", service.MaxNameLength+1),
+		"denylisted": "The Admin",
+		"spaced out": "s.h.i.t",
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := new(MockUserRepository)
+			router := setupTestRouter(repo)
+
+			w := putProfile(router, map[string]string{"name": displayName})
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			repo.AssertNotCalled(t, "UpdateUserFields", mock.Anything, mock.Anything, mock.Anything)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "VALIDATION_ERROR", response.Code)
+			assert.NotEmpty(t, response.Details, "the reason is returned")
+		})
+	}
+}
+
+func TestUserHandler_GetStreak(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+	repo.On("GetUserStats", mock.Anything, "user-1").Return(&database.UserStats{UserID: "user-1", CurrentStreak: -2, BestStreak: 4}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/users/stats/streak", nil)
+	req.Header.Set("X-Test-User", "user-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var streak service.Streak
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &streak))
+	assert.Equal(t, service.Streak{CurrentStreak: -2, BestStreak: 4}, streak)
+}
+
+func TestUserHandler_GetLeaderboardByPoints(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	repo.On("GetTopPlayersByPoints", mock.Anything, 2).Return([]database.UserStats{
+		{UserID: "user-2", TotalPoints: 2400, User: database.User{Name: "Two"}},
+		{UserID: "user-1", TotalPoints: 1500, User: database.User{Name: "One"}},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/users/leaderboard?by=points&limit=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertExpectations(t)
+
+	var response struct {
+		Criteria string `json:"criteria"`
+		Players  []struct {
+			Rank        int    `json:"rank"`
+			Name        string `json:"name"`
+			TotalPoints int    `json:"total_points"`
+		} `json:"players"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "points", response.Criteria)
+	require.Len(t, response.Players, 2)
+	assert.Equal(t, 1, response.Players[0].Rank)
+	assert.Equal(t, "Two", response.Players[0].Name)
+	assert.Equal(t, 2400, response.Players[0].TotalPoints)
+}
+
+func TestUserHandler_GetLeaderboardUnknownCriteria(t *testing.T) {
+	repo := new(MockUserRepository)
+	router := setupTestRouter(repo)
+
+	req, _ := http.NewRequest("GET", "/api/v1/users/leaderboard?by=style", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func keys(m map[string]interface{}) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
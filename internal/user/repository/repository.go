@@ -1,19 +1,39 @@
 package repository
 
 import (
+	"context"
+	"log/slog"
+
+	"chinese-bridge-game/internal/common/database"
+
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	// Interface methods will be defined in later tasks
+	GetUserByID(ctx context.Context, id string) (*database.User, error)
+	UpdateUserFields(ctx context.Context, id string, fields map[string]interface{}) error
+	GetUsersByIDs(ctx context.Context, ids []string) ([]database.User, error)
+	GetUserStats(ctx context.Context, userID string) (*database.UserStats, error)
+	GetHeadToHead(ctx context.Context, userA, userB string) (database.HeadToHead, error)
+	GetTopPlayersByWins(ctx context.Context, limit int) ([]database.UserStats, error)
+	GetTopPlayersByDeclarerWins(ctx context.Context, limit int) ([]database.UserStats, error)
+	GetTopPlayersByPoints(ctx context.Context, limit int) ([]database.UserStats, error)
 }
 
 type userRepository struct {
-	db *gorm.DB
+	database.Repository
 }
 
 func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{
-		db: db,
+		Repository: database.NewGormRepository(db),
 	}
-}
\ No newline at end of file
+}
+
+// NewCachedUserRepository creates a user repository that reads user profiles
+// through the cache and invalidates them when the user changes
+func NewCachedUserRepository(db *gorm.DB, cache database.Cache, logger *slog.Logger) UserRepository {
+	return &userRepository{
+		Repository: database.NewCachedUserRepository(database.NewGormRepository(db), cache, logger),
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/user/dto"
+)
+
+// Criteria players can be ranked by on the leaderboard
+const (
+	LeaderboardByWins         = "wins"
+	LeaderboardByDeclarerWins = "declarer_wins"
+	LeaderboardByPoints       = "points"
+)
+
+// ErrUnknownCriteria is returned when ranking players by an unsupported criteria
+var ErrUnknownCriteria = errors.New("unknown leaderboard criteria")
+
+// GetLeaderboard returns up to limit players ranked by the given criteria,
+// capped at database.LeaderboardSize. Rankings are served from the cache so
+// popular leaderboards do not query the database on every request.
+func (s *userService) GetLeaderboard(ctx context.Context, criteria string, limit int) (*dto.LeaderboardResponse, error) {
+	var query database.RankingLoader
+	switch criteria {
+	case LeaderboardByWins:
+		query = s.repo.GetTopPlayersByWins
+	case LeaderboardByDeclarerWins:
+		query = s.repo.GetTopPlayersByDeclarerWins
+	case LeaderboardByPoints:
+		query = s.repo.GetTopPlayersByPoints
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCriteria, criteria)
+	}
+
+	if limit <= 0 || limit > database.LeaderboardSize {
+		limit = database.LeaderboardSize
+	}
+	stats, err := s.leaderboard.GetRanking(ctx, criteria, limit, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	players := make([]dto.LeaderboardEntry, 0, len(stats))
+	for i, stat := range stats {
+		players = append(players, dto.LeaderboardEntry{
+			Rank:         i + 1,
+			UserID:       stat.UserID,
+			Name:         stat.User.Name,
+			Avatar:       stat.User.Avatar,
+			GamesPlayed:  stat.GamesPlayed,
+			GamesWon:     stat.GamesWon,
+			DeclarerWins: stat.DeclarerWins,
+			TotalPoints:  stat.TotalPoints,
+			Rating:       stat.Rating,
+		})
+	}
+	return &dto.LeaderboardResponse{Criteria: criteria, Players: players}, nil
+}
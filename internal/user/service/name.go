@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidName is returned when a display name is rejected, wrapped with the reason
+var ErrInvalidName = errors.New("invalid name")
+
+// NameValidator decides whether a user may choose a display name
+type NameValidator interface {
+	// ValidateName returns an error wrapping ErrInvalidName with the reason
+	// the name was rejected, or nil if it is allowed
+	ValidateName(name string) error
+}
+
+// Limits on display names applied by the default name validator
+const (
+	MinNameLength = 2
+	MaxNameLength = 32
+)
+
+// defaultDeniedTerms are rejected anywhere in a name, ignoring case, spacing
+// and punctuation, to catch profanity and names impersonating staff
+var defaultDeniedTerms = []string{
+	"admin", "moderator", "official", "support", "system",
+	"fuck", "shit", "bitch",
+}
+
+// DefaultNameValidator allows names of letters, digits, spaces and a little
+// punctuation within the length limits, that contain no denied term
+type DefaultNameValidator struct {
+	DeniedTerms []string
+}
+
+// NewDefaultNameValidator creates a name validator with the default denylist
+func NewDefaultNameValidator() *DefaultNameValidator {
+	return &DefaultNameValidator{DeniedTerms: defaultDeniedTerms}
+}
+
+// ValidateName checks the name's length, characters and denied terms
+func (v *DefaultNameValidator) ValidateName(name string) error {
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("%w: name cannot start or end with a space", ErrInvalidName)
+	}
+
+	length := utf8.RuneCountInString(name)
+	if length < MinNameLength || length > MaxNameLength {
+		return fmt.Errorf("%w: name must be between %d and %d characters", ErrInvalidName, MinNameLength, MaxNameLength)
+	}
+
+	var folded strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			folded.WriteRune(unicode.ToLower(r))
+		case r == ' ' || r == '-' || r == '_' || r == '.' || r == '\'':
+		default:
+			return fmt.Errorf("%w: name cannot contain %q", ErrInvalidName, r)
+		}
+	}
+
+	// Separators are dropped so spacing a term out does not get it through
+	for _, term := range v.DeniedTerms {
+		if strings.Contains(folded.String(), term) {
+			return fmt.Errorf("%w: name contains a term that is not allowed", ErrInvalidName)
+		}
+	}
+	return nil
+}
@@ -1,23 +1,189 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"chinese-bridge-game/internal/common/database"
+	"chinese-bridge-game/internal/user/dto"
 	"chinese-bridge-game/internal/user/repository"
 
 	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSameUser is returned when comparing a player against themselves
+	ErrSameUser = errors.New("cannot compare a player with themselves")
+	// ErrBatchTooLarge is returned when requesting more profiles than MaxProfileBatchSize
+	ErrBatchTooLarge = fmt.Errorf("cannot fetch more than %d profiles at once", MaxProfileBatchSize)
 )
 
+// MaxProfileBatchSize caps how many profiles can be fetched in one request
+const MaxProfileBatchSize = 50
+
+// StatsAnalytics summarizes how well a player does overall and as declarer
+type StatsAnalytics struct {
+	GamesPlayed     int     `json:"games_played"`
+	GamesWon        int     `json:"games_won"`
+	WinRate         float64 `json:"win_rate"`
+	GamesAsDeclarer int     `json:"games_as_declarer"`
+	DeclarerWins    int     `json:"declarer_wins"`
+	DeclarerWinRate float64 `json:"declarer_win_rate"`
+	AverageContract float64 `json:"average_contract"`
+}
+
+// Streak is a player's current run of wins or losses and their best winning run
+type Streak struct {
+	CurrentStreak int `json:"current_streak"` // Consecutive wins when positive, consecutive losses when negative
+	BestStreak    int `json:"best_streak"`
+}
+
 type UserService interface {
-	// Interface methods will be defined in later tasks
+	UpdateProfile(ctx context.Context, userID string, req dto.UpdateProfileRequest) (*dto.PublicProfile, error)
+	GetPublicProfiles(ctx context.Context, userIDs []string) ([]dto.PublicProfile, error)
+	GetStatsAnalytics(ctx context.Context, userID string) (*StatsAnalytics, error)
+	GetStreak(ctx context.Context, userID string) (*Streak, error)
+	GetHeadToHead(ctx context.Context, userID, opponentID string) (*database.HeadToHead, error)
+	GetLeaderboard(ctx context.Context, criteria string, limit int) (*dto.LeaderboardResponse, error)
 }
 
 type userService struct {
-	repo        repository.UserRepository
-	redisClient *redis.Client
+	repo          repository.UserRepository
+	redisClient   *redis.Client
+	leaderboard   database.LeaderboardReader
+	nameValidator NameValidator
 }
 
-func NewUserService(repo repository.UserRepository, redisClient *redis.Client) UserService {
+// NewUserService creates a user service that reads leaderboards through the
+// given reader. A nil name validator uses the default one.
+func NewUserService(repo repository.UserRepository, redisClient *redis.Client, leaderboard database.LeaderboardReader, nameValidator NameValidator) UserService {
+	if nameValidator == nil {
+		nameValidator = NewDefaultNameValidator()
+	}
 	return &userService{
-		repo:        repo,
-		redisClient: redisClient,
+		repo:          repo,
+		redisClient:   redisClient,
+		leaderboard:   leaderboard,
+		nameValidator: nameValidator,
+	}
+}
+
+// UpdateProfile changes the user's name and avatar, checking a new name with
+// the name validator before anything is saved
+func (s *userService) UpdateProfile(ctx context.Context, userID string, req dto.UpdateProfileRequest) (*dto.PublicProfile, error) {
+	fields := make(map[string]interface{}, 2)
+	if req.Name != nil {
+		if err := s.nameValidator.ValidateName(*req.Name); err != nil {
+			return nil, err
+		}
+		fields["name"] = *req.Name
+	}
+	if req.Avatar != nil {
+		fields["avatar"] = *req.Avatar
+	}
+
+	if len(fields) > 0 {
+		if err := s.repo.UpdateUserFields(ctx, userID, fields); err != nil {
+			return nil, fmt.Errorf("failed to update profile: %w", err)
+		}
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &dto.PublicProfile{ID: user.ID, Name: user.Name, Avatar: user.Avatar}, nil
+}
+
+// GetHeadToHead returns the record of the games a player has played with an opponent
+func (s *userService) GetHeadToHead(ctx context.Context, userID, opponentID string) (*database.HeadToHead, error) {
+	if userID == opponentID {
+		return nil, ErrSameUser
+	}
+
+	record, err := s.repo.GetHeadToHead(ctx, userID, opponentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head-to-head record: %w", err)
+	}
+	return &record, nil
+}
+
+// GetPublicProfiles returns the public profiles of the given users, ignoring
+// duplicate and unknown IDs
+func (s *userService) GetPublicProfiles(ctx context.Context, userIDs []string) ([]dto.PublicProfile, error) {
+	seen := make(map[string]bool, len(userIDs))
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxProfileBatchSize {
+		return nil, ErrBatchTooLarge
 	}
-}
\ No newline at end of file
+
+	users, err := s.repo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	profiles := make([]dto.PublicProfile, 0, len(users))
+	for _, user := range users {
+		profiles = append(profiles, dto.PublicProfile{
+			ID:     user.ID,
+			Name:   user.Name,
+			Avatar: user.Avatar,
+		})
+	}
+	return profiles, nil
+}
+
+// GetStatsAnalytics derives win rates and the average contract from a player's
+// statistics. Players who have not finished a game get empty analytics.
+func (s *userService) GetStatsAnalytics(ctx context.Context, userID string) (*StatsAnalytics, error) {
+	stats, err := s.repo.GetUserStats(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &StatsAnalytics{}, nil
+		}
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	return &StatsAnalytics{
+		GamesPlayed:     stats.GamesPlayed,
+		GamesWon:        stats.GamesWon,
+		WinRate:         rate(stats.GamesWon, stats.GamesPlayed),
+		GamesAsDeclarer: stats.GamesAsDeclarer,
+		DeclarerWins:    stats.DeclarerWins,
+		DeclarerWinRate: rate(stats.DeclarerWins, stats.GamesAsDeclarer),
+		AverageContract: stats.AverageBid,
+	}, nil
+}
+
+// GetStreak returns the player's current and best streaks. Players who have
+// not finished a game have no streak.
+func (s *userService) GetStreak(ctx context.Context, userID string) (*Streak, error) {
+	stats, err := s.repo.GetUserStats(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &Streak{}, nil
+		}
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	return &Streak{
+		CurrentStreak: stats.CurrentStreak,
+		BestStreak:    stats.BestStreak,
+	}, nil
+}
+
+// rate returns wins as a fraction of games, or zero when no games were played
+func rate(wins, games int) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(wins) / float64(games)
+}
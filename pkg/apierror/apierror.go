@@ -0,0 +1,94 @@
+// Package apierror maps errors returned by services to HTTP statuses and the
+// shared ErrorResponse envelope, so every handler reports the same error the
+// same way.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Error codes used in ErrorResponse
+const (
+	CodeValidation     = "VALIDATION_ERROR"
+	CodeAuthentication = "AUTHENTICATION_ERROR"
+	CodeAuthorization  = "AUTHORIZATION_ERROR"
+	CodeNotFound       = "NOT_FOUND"
+	CodeConflict       = "CONFLICT"
+	CodeInternal       = "INTERNAL_ERROR"
+)
+
+// Mapping describes the response for errors matching Err with errors.Is
+type Mapping struct {
+	Err         error
+	Status      int
+	Code        string
+	Message     string
+	ShowDetails bool // Include the error text in the response details
+}
+
+// Mapper translates errors using the first mapping that matches them. Errors
+// that match no mapping are internal errors.
+type Mapper struct {
+	mappings []Mapping
+}
+
+// NewMapper creates a mapper trying the mappings in order
+func NewMapper(mappings ...Mapping) *Mapper {
+	return &Mapper{mappings: mappings}
+}
+
+// Extend returns a mapper that tries the given mappings after m's own
+func (m *Mapper) Extend(mappings ...Mapping) *Mapper {
+	extended := make([]Mapping, 0, len(m.mappings)+len(mappings))
+	extended = append(extended, m.mappings...)
+	return NewMapper(append(extended, mappings...)...)
+}
+
+// Map returns the HTTP status and response for err. Unmapped errors are
+// reported as internal errors with the given message.
+func (m *Mapper) Map(err error, message string) (int, dto.ErrorResponse) {
+	for _, mapping := range m.mappings {
+		if !errors.Is(err, mapping.Err) {
+			continue
+		}
+		response := dto.ErrorResponse{Code: mapping.Code, Message: mapping.Message}
+		if mapping.ShowDetails {
+			response.Details = err.Error()
+		}
+		return mapping.Status, response
+	}
+
+	return http.StatusInternalServerError, dto.ErrorResponse{
+		Code:    CodeInternal,
+		Message: message,
+		Details: err.Error(),
+	}
+}
+
+// Respond writes the response for err, tagged with the request's trace ID
+func (m *Mapper) Respond(c *gin.Context, err error, message string) {
+	status, response := m.Map(err, message)
+	response.TraceID = c.GetString("trace_id")
+	c.JSON(status, response)
+}
+
+// Default maps the game domain's errors and missing database records
+var Default = NewMapper(
+	Mapping{Err: domain.ErrWrongPhase, Status: http.StatusConflict, Code: CodeConflict, Message: "Action not allowed in the current phase", ShowDetails: true},
+	Mapping{Err: domain.ErrNotYourTurn, Status: http.StatusConflict, Code: CodeConflict, Message: "Not your turn", ShowDetails: true},
+	Mapping{Err: domain.ErrInvalidBid, Status: http.StatusBadRequest, Code: CodeValidation, Message: "Invalid bid", ShowDetails: true},
+	Mapping{Err: domain.ErrCardNotHeld, Status: http.StatusBadRequest, Code: CodeValidation, Message: "Card not held", ShowDetails: true},
+	Mapping{Err: gorm.ErrRecordNotFound, Status: http.StatusNotFound, Code: CodeNotFound, Message: "Resource not found"},
+)
+
+// RespondError writes the response for err using the default mappings
+func RespondError(c *gin.Context, err error) {
+	Default.Respond(c, err, "Internal server error")
+}
@@ -0,0 +1,76 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chinese-bridge-game/internal/game/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDefault_Map(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantDetails bool
+	}{
+		{"Wrong phase", domain.ErrWrongPhase, http.StatusConflict, CodeConflict, true},
+		{"Not your turn", domain.ErrNotYourTurn, http.StatusConflict, CodeConflict, true},
+		{"Invalid bid", domain.ErrInvalidBid, http.StatusBadRequest, CodeValidation, true},
+		{"Card not held", domain.ErrCardNotHeld, http.StatusBadRequest, CodeValidation, true},
+		{"Record not found", gorm.ErrRecordNotFound, http.StatusNotFound, CodeNotFound, false},
+		{"Wrapped error", fmt.Errorf("bid rejected: %w", domain.ErrInvalidBid), http.StatusBadRequest, CodeValidation, true},
+		{"Unmapped error", errors.New("connection reset"), http.StatusInternalServerError, CodeInternal, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := Default.Map(tt.err, "Request failed")
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, response.Code)
+			if tt.wantDetails {
+				assert.Equal(t, tt.err.Error(), response.Details)
+			} else {
+				assert.Empty(t, response.Details)
+			}
+		})
+	}
+}
+
+func TestMapper_Extend(t *testing.T) {
+	errInvalidMove := errors.New("invalid move")
+	mapper := Default.Extend(Mapping{Err: errInvalidMove, Status: http.StatusBadRequest, Code: CodeValidation, Message: "Invalid move"})
+
+	// The default mappings are tried before the extension
+	status, response := mapper.Map(fmt.Errorf("%w: %w", errInvalidMove, domain.ErrWrongPhase), "Request failed")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, CodeConflict, response.Code)
+
+	status, response = mapper.Map(errInvalidMove, "Request failed")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Invalid move", response.Message)
+
+	// Extending does not change the original mapper
+	status, _ = Default.Map(errInvalidMove, "Request failed")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("trace_id", "test-trace-id")
+
+	RespondError(c, domain.ErrNotYourTurn)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"code":"CONFLICT","message":"Not your turn","details":"not player's turn","trace_id":"test-trace-id"}`, w.Body.String())
+}
@@ -0,0 +1,124 @@
+// Package audit records security-relevant events, such as logins and rejected
+// tokens, as structured entries kept separate from the request logs.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of security event being recorded
+type EventType string
+
+const (
+	EventLogin           EventType = "login"
+	EventLogout          EventType = "logout"
+	EventTokenRefresh    EventType = "token_refresh"
+	EventTokenValidation EventType = "token_validation"
+)
+
+// Outcome records whether the audited action succeeded
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is a single audit log entry
+type Event struct {
+	Type      EventType `json:"event_type"`
+	UserID    string    `json:"user_id,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	TraceID   string    `json:"trace_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Outcome   Outcome   `json:"outcome"`
+	Reason    string    `json:"reason,omitempty"` // Why a failed action was rejected
+}
+
+// Sink stores audit events, e.g. by writing them to stdout or shipping them
+// to a log pipeline
+type Sink interface {
+	Write(event Event) error
+}
+
+// JSONSink writes each event as a line of JSON
+type JSONSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONSink creates a sink writing JSON lines to w
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{encoder: json.NewEncoder(w)}
+}
+
+// Write encodes the event as a single line
+func (s *JSONSink) Write(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(event)
+}
+
+// Logger fills in the request details of audit events and hands them to its
+// sink. A nil Logger discards every event.
+type Logger struct {
+	sink Sink
+}
+
+// NewLogger creates an audit logger writing to the given sink
+func NewLogger(sink Sink) *Logger {
+	return &Logger{sink: sink}
+}
+
+// Log records the event, taking the IP and trace ID from the context when the
+// event does not set them
+func (l *Logger) Log(ctx context.Context, event Event) {
+	if l == nil || l.sink == nil {
+		return
+	}
+
+	if info, ok := ctx.Value(requestInfoKey{}).(requestInfo); ok {
+		if event.IP == "" {
+			event.IP = info.ip
+		}
+		if event.TraceID == "" {
+			event.TraceID = info.traceID
+		}
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	if err := l.sink.Write(event); err != nil {
+		log.Printf("Failed to write audit event %s: %v", event.Type, err)
+	}
+}
+
+// LogResult records an event whose outcome is decided by err, using the error
+// as the reason when the action failed
+func (l *Logger) LogResult(ctx context.Context, eventType EventType, userID string, err error) {
+	event := Event{Type: eventType, UserID: userID, Outcome: OutcomeSuccess}
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		event.Reason = err.Error()
+	}
+	l.Log(ctx, event)
+}
+
+type requestInfoKey struct{}
+
+type requestInfo struct {
+	ip      string
+	traceID string
+}
+
+// WithRequest returns a context carrying the client IP and trace ID of the
+// request, so events logged further down the call chain include them
+func WithRequest(ctx context.Context, ip, traceID string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, requestInfo{ip: ip, traceID: traceID})
+}
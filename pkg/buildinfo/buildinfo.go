@@ -0,0 +1,55 @@
+// Package buildinfo holds the version information injected into the service
+// binaries at build time, e.g.
+//
+//	go build -ldflags "-X chinese-bridge-game/pkg/buildinfo.Version=v1.2.0 -X chinese-bridge-game/pkg/buildinfo.Commit=abc1234"
+package buildinfo
+
+import (
+	"time"
+)
+
+// Build variables, overridden with -ldflags at build time
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// startedAt records when the process started
+var startedAt = time.Now()
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	StartedAt string `json:"started_at"`
+	Uptime    string `json:"uptime"`
+}
+
+// Health is the response body of the services' health endpoints
+type Health struct {
+	Status  string `json:"status"`
+	Service string `json:"service"`
+	Info
+}
+
+// Get returns the build information and current uptime
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		StartedAt: startedAt.UTC().Format(time.RFC3339),
+		Uptime:    time.Since(startedAt).Round(time.Second).String(),
+	}
+}
+
+// NewHealth creates a health response for a service
+func NewHealth(status, service string) Health {
+	return Health{
+		Status:  status,
+		Service: service,
+		Info:    Get(),
+	}
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_FiltersByLevel(t *testing.T) {
+	tests := []struct {
+		level     string
+		wantDebug bool
+	}{
+		{"info", false},
+		{"debug", true},
+		{"DEBUG", true},
+		{"warn", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLevel(tt.level)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			logger := New(&buf, level)
+			logger.Debug("cache entry invalidated", "user_id", "user-1")
+			logger.Error("cache cleanup failed")
+
+			assert.Equal(t, tt.wantDebug, bytes.Contains(buf.Bytes(), []byte("cache entry invalidated")))
+			assert.Contains(t, buf.String(), "cache cleanup failed")
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelInfo, level)
+
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
+}
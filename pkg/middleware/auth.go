@@ -1,57 +1,54 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"chinese-bridge-game/internal/auth/dto"
 	"chinese-bridge-game/internal/auth/service"
+	"chinese-bridge-game/pkg/audit"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
 )
 
-// JWTAuth middleware for JWT token validation
-func JWTAuth(authService service.AuthService) gin.HandlerFunc {
+// JWTAuth middleware for JWT token validation. Rejected requests are recorded
+// with the audit logger, which may be nil.
+func JWTAuth(authService service.AuthService, auditLogger *audit.Logger) gin.HandlerFunc {
+	return jwtAuth(authService, auditLogger, false)
+}
+
+// JWTAuthWS is JWTAuth for WebSocket routes. Browsers cannot set headers on a
+// WebSocket upgrade request, so the handshake may instead pass the token in
+// the token query parameter. Requests that are not upgrades still need the
+// Authorization header.
+func JWTAuthWS(authService service.AuthService, auditLogger *audit.Logger) gin.HandlerFunc {
+	return jwtAuth(authService, auditLogger, true)
+}
+
+func jwtAuth(authService service.AuthService, auditLogger *audit.Logger, allowQueryToken bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Code:    "AUTHENTICATION_ERROR",
-				Message: "Authorization header is required",
-				TraceID: c.GetString("trace_id"),
-			})
-			c.Abort()
-			return
-		}
+		ctx := audit.WithRequest(c.Request.Context(), c.ClientIP(), c.GetString("trace_id"))
 
-		// Check if the header starts with "Bearer "
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Code:    "AUTHENTICATION_ERROR",
-				Message: "Invalid authorization header format",
-				TraceID: c.GetString("trace_id"),
-			})
-			c.Abort()
-			return
+		var tokenString string
+		if allowQueryToken && isWebSocketUpgrade(c.Request) {
+			tokenString = c.Query("token")
 		}
-
-		// Extract the token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Code:    "AUTHENTICATION_ERROR",
-				Message: "Token is required",
-				TraceID: c.GetString("trace_id"),
-			})
-			c.Abort()
-			return
+			var ok bool
+			if tokenString, ok = bearerToken(ctx, c, auditLogger); !ok {
+				return
+			}
 		}
 
 		// Validate the token
-		claims, err := authService.ValidateToken(c.Request.Context(), tokenString)
+		claims, err := authService.ValidateToken(ctx, tokenString)
 		if err != nil {
+			auditLogger.LogResult(ctx, audit.EventTokenValidation, "", err)
 			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
 				Code:    "AUTHENTICATION_ERROR",
 				Message: "Invalid or expired token",
@@ -66,6 +63,73 @@ func JWTAuth(authService service.AuthService) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_name", claims.Name)
+		c.Set("user_role", claims.Role)
+
+		c.Next()
+	}
+}
+
+// bearerToken reads the token from the request's Authorization header. A
+// missing or malformed header is audited and rejected, aborting the request.
+func bearerToken(ctx context.Context, c *gin.Context, auditLogger *audit.Logger) (string, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		auditLogger.LogResult(ctx, audit.EventTokenValidation, "", errors.New("missing authorization header"))
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    "AUTHENTICATION_ERROR",
+			Message: "Authorization header is required",
+			TraceID: c.GetString("trace_id"),
+		})
+		c.Abort()
+		return "", false
+	}
+
+	// Check if the header starts with "Bearer "
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		auditLogger.LogResult(ctx, audit.EventTokenValidation, "", errors.New("invalid authorization header format"))
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    "AUTHENTICATION_ERROR",
+			Message: "Invalid authorization header format",
+			TraceID: c.GetString("trace_id"),
+		})
+		c.Abort()
+		return "", false
+	}
+
+	// Extract the token
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == "" {
+		auditLogger.LogResult(ctx, audit.EventTokenValidation, "", errors.New("missing token"))
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Code:    "AUTHENTICATION_ERROR",
+			Message: "Token is required",
+			TraceID: c.GetString("trace_id"),
+		})
+		c.Abort()
+		return "", false
+	}
+	return tokenString, true
+}
+
+// isWebSocketUpgrade reports whether the request is a WebSocket handshake
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// RequireRole middleware restricts a route to users with the given role. It
+// must run after JWTAuth so the caller's role is known.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_role") != role {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Code:    "AUTHORIZATION_ERROR",
+				Message: "Insufficient role",
+				Details: "This endpoint requires the " + role + " role",
+				TraceID: c.GetString("trace_id"),
+			})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -119,6 +183,41 @@ func IPRateLimiter(requestsPerSecond float64, burstSize int) gin.HandlerFunc {
 	}
 }
 
+// UserRateLimiter middleware for per-user rate limiting. Requests are keyed by
+// the authenticated user, falling back to the client IP for anonymous requests.
+func UserRateLimiter(requestsPerSecond float64, burstSize int) gin.HandlerFunc {
+	var mu sync.Mutex
+	limiters := make(map[string]*rate.Limiter)
+
+	return func(c *gin.Context) {
+		key := c.GetString("user_id")
+		if key == "" {
+			key = "ip:" + c.ClientIP()
+		}
+
+		mu.Lock()
+		limiter, exists := limiters[key]
+		if !exists {
+			limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burstSize)
+			limiters[key] = limiter
+		}
+		mu.Unlock()
+
+		if !limiter.Allow() {
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Code:    "RATE_LIMIT_EXCEEDED",
+				Message: "Too many requests",
+				Details: "Rate limit exceeded, please try again later",
+				TraceID: c.GetString("trace_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // SecurityHeaders middleware adds security headers
 func SecurityHeaders() gin.HandlerFunc {
 	return func(c *gin.Context) {
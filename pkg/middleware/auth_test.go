@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chinese-bridge-game/internal/auth/dto"
+	"chinese-bridge-game/internal/auth/service"
+	"chinese-bridge-game/internal/common/config"
+	"chinese-bridge-game/pkg/audit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTAuth_AuditsFailedValidation(t *testing.T) {
+	authService, err := service.NewAuthService(nil, nil, &config.Config{JWTSecret: "test-secret"}, nil)
+	require.NoError(t, err)
+
+	var auditLog bytes.Buffer
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("trace_id", "test-trace-id")
+		c.Next()
+	})
+	router.GET("/protected", JWTAuth(authService, audit.NewLogger(audit.NewJSONSink(&auditLog))), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	req.RemoteAddr = "203.0.113.7:51234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var event audit.Event
+	require.NoError(t, json.Unmarshal(auditLog.Bytes(), &event))
+	assert.Equal(t, audit.EventTokenValidation, event.Type)
+	assert.Equal(t, audit.OutcomeFailure, event.Outcome)
+	assert.Equal(t, "203.0.113.7", event.IP)
+	assert.Equal(t, "test-trace-id", event.TraceID)
+	assert.NotEmpty(t, event.Reason)
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+// stubAuthService accepts a single valid token. Other methods are not used by
+// the middleware and panic if called.
+type stubAuthService struct {
+	service.AuthService
+}
+
+func (s stubAuthService) ValidateToken(ctx context.Context, tokenString string) (*dto.JWTClaims, error) {
+	if tokenString != "valid-token" {
+		return nil, errors.New("invalid token")
+	}
+	return &dto.JWTClaims{UserID: "user-1", Role: "player"}, nil
+}
+
+func TestJWTAuthWS_AcceptsQueryTokenOnUpgrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		upgrade    bool
+		query      string
+		header     string
+		wantStatus int
+	}{
+		{"Query token on upgrade", true, "?token=valid-token", "", http.StatusOK},
+		{"Header on upgrade", true, "", "Bearer valid-token", http.StatusOK},
+		{"Invalid query token on upgrade", true, "?token=forged", "", http.StatusUnauthorized},
+		{"No token on upgrade", true, "", "", http.StatusUnauthorized},
+		{"Query token without upgrade", false, "?token=valid-token", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/ws", JWTAuthWS(stubAuthService{}, nil), func(c *gin.Context) {
+				assert.Equal(t, "user-1", c.GetString("user_id"))
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/ws"+tt.query, nil)
+			if tt.upgrade {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestJWTAuth_IgnoresQueryToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/protected", JWTAuth(stubAuthService{}, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/protected?token=valid-token", nil)
+	req.Header.Set("Upgrade", "websocket")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req, _ = http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		wantStatus int
+	}{
+		{"Allowed role", "admin", http.StatusOK},
+		{"Other role", "player", http.StatusForbidden},
+		{"No role", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "test-user")
+				if tt.role != "" {
+					c.Set("user_role", tt.role)
+				}
+				c.Next()
+			})
+			router.GET("/admin", RequireRole("admin"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/admin", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
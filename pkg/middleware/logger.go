@@ -2,18 +2,24 @@ package middleware
 
 import (
 	"log"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// redactedQueryParams are query parameters that carry credentials, such as
+// the token WebSocket clients authenticate with, and are never logged
+var redactedQueryParams = []string{"token"}
+
 // Logger middleware for structured logging
 func Logger() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		log.Printf("[%s] %s %s %d %s %s %s\n",
 			param.TimeStamp.Format(time.RFC3339),
 			param.Method,
-			param.Path,
+			redactPath(param.Path),
 			param.StatusCode,
 			param.Latency,
 			param.ClientIP,
@@ -21,4 +27,30 @@ func Logger() gin.HandlerFunc {
 		)
 		return ""
 	})
-}
\ No newline at end of file
+}
+
+// redactPath replaces the values of credential query parameters in a request
+// path with REDACTED
+func redactPath(path string) string {
+	base, rawQuery, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Drop a query that cannot be parsed rather than risk logging a token
+		return base + "?REDACTED"
+	}
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if _, ok := query[name]; ok {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	return base + "?" + query.Encode()
+}
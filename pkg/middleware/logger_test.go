@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_RedactsQueryToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	output := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(output)
+
+	router := gin.New()
+	router.Use(Logger())
+	router.GET("/ws", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/ws?room=room-1&token=secret-jwt", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), "secret-jwt")
+	assert.Contains(t, buf.String(), "/ws?room=room-1&token=REDACTED")
+}
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"No query", "/api/v1/games/game-1", "/api/v1/games/game-1"},
+		{"Query without token", "/api/v1/rooms?limit=10", "/api/v1/rooms?limit=10"},
+		{"Token only", "/ws?token=abc", "/ws?token=REDACTED"},
+		{"Unparseable query", "/ws?token=%zz", "/ws?REDACTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactPath(tt.path))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMetricsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/metrics", MetricsHandler())
+	router.GET("/games/:gameId", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("gameId")})
+	})
+	return router
+}
+
+// scrapeMetric fetches /metrics and returns the value of the named series, or
+// zero if it has not been recorded yet
+func scrapeMetric(t *testing.T, router *gin.Engine, series string) float64 {
+	t.Helper()
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	scanner := bufio.NewScanner(strings.NewReader(w.Body.String()))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, series+" ") {
+			value, err := strconv.ParseFloat(strings.TrimPrefix(line, series+" "), 64)
+			require.NoError(t, err)
+			return value
+		}
+	}
+	return 0
+}
+
+func TestMetrics_CountsHandledRequests(t *testing.T) {
+	router := setupMetricsRouter()
+	series := `http_requests_total{method="GET",route="/games/:gameId",status="200"}`
+
+	before := scrapeMetric(t, router, series)
+
+	req, _ := http.NewRequest("GET", "/games/game-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, before+1, scrapeMetric(t, router, series))
+	assert.Greater(t, scrapeMetric(t, router, `http_request_duration_seconds_count{method="GET",route="/games/:gameId",status="200"}`), 0.0)
+}
+
+func TestMetrics_UnmatchedRoutes(t *testing.T) {
+	router := setupMetricsRouter()
+	series := `http_requests_total{method="GET",route="unmatched",status="404"}`
+
+	before := scrapeMetric(t, router, series)
+
+	req, _ := http.NewRequest("GET", "/no/such/path", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, before+1, scrapeMetric(t, router, series))
+}
@@ -14,10 +14,15 @@ CREATE TABLE IF NOT EXISTS users (
     email VARCHAR(255) UNIQUE NOT NULL,
     name VARCHAR(255) NOT NULL,
     avatar VARCHAR(500),
+    role VARCHAR(20) NOT NULL DEFAULT 'player',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
 );
 
+-- Add the role column to databases created before roles existed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'player';
+
 -- User statistics table
 CREATE TABLE IF NOT EXISTS user_stats (
     user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
@@ -27,10 +32,17 @@ CREATE TABLE IF NOT EXISTS user_stats (
     declarer_wins INTEGER DEFAULT 0,
     total_points INTEGER DEFAULT 0,
     average_bid DECIMAL(5,2) DEFAULT 0,
+    rating INTEGER DEFAULT 1500,
+    current_streak INTEGER DEFAULT 0,
+    best_streak INTEGER DEFAULT 0,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Add the streak columns to databases created before streaks existed
+ALTER TABLE user_stats ADD COLUMN IF NOT EXISTS current_streak INTEGER DEFAULT 0;
+ALTER TABLE user_stats ADD COLUMN IF NOT EXISTS best_streak INTEGER DEFAULT 0;
+
 -- Rooms table
 CREATE TABLE IF NOT EXISTS rooms (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -39,10 +51,14 @@ CREATE TABLE IF NOT EXISTS rooms (
     max_players INTEGER DEFAULT 4,
     current_players INTEGER DEFAULT 0,
     status VARCHAR(50) DEFAULT 'waiting',
+    rules JSONB,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Add the house rules column to databases created before house rules existed
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS rules JSONB;
+
 -- Room participants junction table
 CREATE TABLE IF NOT EXISTS room_participants (
     room_id UUID REFERENCES rooms(id) ON DELETE CASCADE,
@@ -64,9 +80,19 @@ CREATE TABLE IF NOT EXISTS games (
     game_data JSONB,
     started_at TIMESTAMP WITH TIME ZONE,
     ended_at TIMESTAMP WITH TIME ZONE,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+    aborted BOOLEAN DEFAULT FALSE,
+    deal_seed BIGINT,
+    rematch_room_id VARCHAR(36),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Add the columns games gained after the initial schema
+ALTER TABLE games ADD COLUMN IF NOT EXISTS aborted BOOLEAN DEFAULT FALSE;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS deal_seed BIGINT;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS rematch_room_id VARCHAR(36);
+ALTER TABLE games ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW();
+
 -- Game participants junction table
 CREATE TABLE IF NOT EXISTS game_participants (
     game_id UUID REFERENCES games(id) ON DELETE CASCADE,
@@ -77,14 +103,34 @@ CREATE TABLE IF NOT EXISTS game_participants (
     PRIMARY KEY (game_id, user_id)
 );
 
+-- Sessions table
+CREATE TABLE IF NOT EXISTS sessions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL,
+    device_label VARCHAR(255),
+    access_token_id VARCHAR(36),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Add the device columns to databases created before devices were listed
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS device_label VARCHAR(255);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS access_token_id VARCHAR(36);
+
 -- Create indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id);
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at);
 CREATE INDEX IF NOT EXISTS idx_rooms_host_id ON rooms(host_id);
 CREATE INDEX IF NOT EXISTS idx_rooms_status ON rooms(status);
 CREATE INDEX IF NOT EXISTS idx_games_room_id ON games(room_id);
 CREATE INDEX IF NOT EXISTS idx_games_declarer_id ON games(declarer_id);
 CREATE INDEX IF NOT EXISTS idx_games_started_at ON games(started_at);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_token ON sessions(token);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at);
+CREATE INDEX IF NOT EXISTS idx_sessions_access_token_id ON sessions(access_token_id);
 
 -- Insert initial data for development
 INSERT INTO users (google_id, email, name, avatar) VALUES 